@@ -74,7 +74,7 @@ func WriteBit(w io.Writer) {
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func Example_ReadContainer() {
+func Example_readContainer() {
 	r := bytes.NewReader([]byte{
 		0x12, 0x34, 0x61, 0x62, 0x63, 0x64, 0x65, 0x66,
 		0x67, 0x68, 0xc1, 0xc2, 0xc3, 0xc4, 0xf1, 0xf2,
@@ -97,7 +97,7 @@ func Example_ReadContainer() {
 	// CRC  = f1f2f3f4
 }
 
-func Example_WriteContainer() {
+func Example_writeContainer() {
 	c := &Container{
 		Sign: []byte{0x01, 0x02, 0x03},
 		Size: 4,
@@ -120,7 +120,7 @@ func Example_WriteContainer() {
 	//   = f3f4
 }
 
-func Example_ReadBit() {
+func Example_readBit() {
 	r := bytes.NewReader([]byte{0x12, 0x34, 0x56, 0x78})
 
 	b1, b2, b3 := ReadBit(r)
@@ -135,7 +135,7 @@ func Example_ReadBit() {
 	// b3 = 5678
 }
 
-func Example_WriteBit() {
+func Example_writeBit() {
 	w := new(bytes.Buffer)
 
 	WriteBit(w)
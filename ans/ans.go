@@ -0,0 +1,146 @@
+// Package ans implements asymmetric numeral systems (tANS and rANS) entropy coders
+// on bitio.BitReader and bitio.BitWriter.
+//
+// ANS encodes symbols in reverse order, so encoder buffers output and writes it reversed.
+// Decoder reads the stream forward. Encoded stream is the final state followed by
+// renormalization bits, and the number of symbols is not stored.
+package ans
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+
+	"github.com/hidez8891/bitio"
+)
+
+// MaxTableLog is max log2 of table size.
+const MaxTableLog = 16
+
+var (
+	// ErrInvalidSymbol is returned when symbol has no frequency.
+	ErrInvalidSymbol = errors.New("ans: invalid symbol")
+	// ErrCorrupted is returned when encoded data is invalid.
+	ErrCorrupted = errors.New("ans: corrupted data")
+)
+
+// Normalize returns frequencies scaled to sum 1<<tableLog.
+// Symbol of non-zero frequency keeps non-zero frequency.
+func Normalize(freqs []uint64, tableLog int) ([]uint32, error) {
+	if tableLog < 1 || tableLog > MaxTableLog {
+		return nil, fmt.Errorf("ans: table log %d is out of range [1, %d]", tableLog, MaxTableLog)
+	}
+
+	total, nSym := uint64(0), 0
+	for _, f := range freqs {
+		if f > 0 {
+			var carry uint64
+			if total, carry = bits.Add64(total, f, 0); carry != 0 {
+				return nil, fmt.Errorf("ans: sum of frequencies exceeds 64 bit")
+			}
+			nSym++
+		}
+	}
+	size := uint64(1) << uint(tableLog)
+	if nSym == 0 {
+		return nil, fmt.Errorf("ans: no symbol")
+	}
+	if uint64(nSym) > size {
+		return nil, fmt.Errorf("ans: %d symbols exceed table size %d", nSym, size)
+	}
+
+	norm := make([]uint32, len(freqs))
+	sum := uint64(0)
+	for s, f := range freqs {
+		if f == 0 {
+			continue
+		}
+		hi, lo := bits.Mul64(f, size)
+		n, _ := bits.Div64(hi, lo, total) // f <= total, so no overflow
+		if n == 0 {
+			n = 1
+		}
+		norm[s] = uint32(n)
+		sum += n
+	}
+
+	// adjust the largest frequencies
+	order := make([]int, 0, nSym)
+	for s, n := range norm {
+		if n > 0 {
+			order = append(order, s)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return norm[order[i]] > norm[order[j]]
+	})
+	for i := 0; sum != size; i = (i + 1) % len(order) {
+		s := order[i]
+		if sum < size {
+			norm[s]++
+			sum++
+		} else if norm[s] > 1 {
+			norm[s]--
+			sum--
+		}
+	}
+
+	return norm, nil
+}
+
+// tableSize returns log2 of sum of normalized frequencies.
+func tableSize(norm []uint32, maxLog int) (int, error) {
+	sum := uint64(0)
+	for _, n := range norm {
+		sum += uint64(n)
+	}
+	for log := 1; log <= maxLog; log++ {
+		if sum == 1<<uint(log) {
+			return log, nil
+		}
+	}
+	return 0, fmt.Errorf("ans: sum of frequencies %d is not power of 2 in [2, %d]", sum, 1<<uint(maxLog))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// chunk is renormalization bits of encoder.
+type chunk struct {
+	v uint32
+	n int
+}
+
+// writeReversed writes final state and chunks in reverse order of encoding.
+func writeReversed(w bitio.BitWriter, state uint64, stateBits int, chunks []chunk) error {
+	if err := bitio.Write(w, stateBits, bitio.BigEndian, state); err != nil {
+		return err
+	}
+	for i := len(chunks) - 1; i >= 0; i-- {
+		if chunks[i].n == 0 {
+			continue
+		}
+		if err := bitio.Write(w, chunks[i].n, bitio.BigEndian, chunks[i].v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBits reads nBit bits value.
+func readBits(r bitio.BitReader, nBit int) (uint64, error) {
+	var v uint64
+	if err := bitio.Read(r, nBit, bitio.BigEndian, &v); err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
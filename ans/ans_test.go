@@ -0,0 +1,157 @@
+package ans_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/ans"
+)
+
+// coder is ANS coder.
+type coder interface {
+	Encode(w bitio.BitWriter, syms []int) error
+	Decode(r bitio.BitReader, dst []int) error
+}
+
+// skewedSymbols returns n symbols of geometric distribution and their frequencies.
+func skewedSymbols(n, nSym int, seed int64) ([]int, []uint64) {
+	rnd := rand.New(rand.NewSource(seed))
+	syms := make([]int, n)
+	freqs := make([]uint64, nSym)
+	for i := range syms {
+		s := int(rnd.ExpFloat64() * 6)
+		if s >= nSym {
+			s = nSym - 1
+		}
+		syms[i] = s
+		freqs[s]++
+	}
+	return syms, freqs
+}
+
+// entropyBits returns Shannon entropy of symbols in bits.
+func entropyBits(freqs []uint64) float64 {
+	total := 0.0
+	for _, f := range freqs {
+		total += float64(f)
+	}
+	bits := 0.0
+	for _, f := range freqs {
+		if f > 0 {
+			bits -= float64(f) * math.Log2(float64(f)/total)
+		}
+	}
+	return bits
+}
+
+// testRoundTrip encodes and decodes symbols, and returns encoded data.
+func testRoundTrip(t *testing.T, name string, c coder, syms []int) []byte {
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	if err := c.Encode(w, syms); err != nil {
+		t.Fatalf("%s encode error: %v", name, err)
+	}
+	w.Flush()
+
+	dst := make([]int, len(syms))
+	r := bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes()))
+	if err := c.Decode(r, dst); err != nil {
+		t.Fatalf("%s decode error: %v", name, err)
+	}
+	if reflect.DeepEqual(dst, syms) == false {
+		t.Fatalf("%s decode mismatch", name)
+	}
+	return b.Bytes()
+}
+
+// testErrors checks errors of coder whose symbol 1 has no frequency.
+func testErrors(t *testing.T, name string, c coder) {
+	if err := c.Encode(bitio.NewBitWriteBuffer(io.Discard), []int{0, 1}); errors.Is(err, ans.ErrInvalidSymbol) == false {
+		t.Fatalf("%s encode error %v, want %v", name, err, ans.ErrInvalidSymbol)
+	}
+	if err := c.Encode(bitio.NewBitWriteBuffer(io.Discard), []int{-1}); errors.Is(err, ans.ErrInvalidSymbol) == false {
+		t.Fatalf("%s encode error %v, want %v", name, err, ans.ErrInvalidSymbol)
+	}
+
+	syms := []int{0, 2, 2, 0, 3, 0, 0, 2, 3, 3, 0, 2}
+	raw := testRoundTrip(t, name, c, syms)
+
+	// decode one more symbol
+	dst := make([]int, len(syms)+1)
+	if err := c.Decode(bitio.NewBitReadBuffer(bytes.NewReader(raw)), dst); err == nil {
+		t.Fatalf("%s decode of extra symbol wants error", name)
+	}
+	// truncated data
+	for n := 0; n < len(raw)-1; n++ {
+		dst := make([]int, len(syms))
+		err := c.Decode(bitio.NewBitReadBuffer(bytes.NewReader(raw[:n])), dst)
+		if errors.Is(err, io.EOF) == false && errors.Is(err, io.ErrUnexpectedEOF) == false && errors.Is(err, ans.ErrCorrupted) == false {
+			t.Fatalf("%s decode of %d byte error %v, want EOF", name, n, err)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		freqs    []uint64
+		tableLog int
+		norm     []uint32
+	}{
+		{[]uint64{1, 1, 1, 1}, 2, []uint32{1, 1, 1, 1}},
+		{[]uint64{3, 0, 1}, 4, []uint32{12, 0, 4}},
+		{[]uint64{1, 1, 1000}, 3, []uint32{1, 1, 6}},
+		{[]uint64{1, 2, 3, 4, 5}, 5, []uint32{2, 4, 6, 9, 11}},
+		{[]uint64{math.MaxUint64 / 2, math.MaxUint64 / 2}, 16, []uint32{1 << 15, 1 << 15}},
+	}
+	for _, tt := range tests {
+		norm, err := ans.Normalize(tt.freqs, tt.tableLog)
+		if err != nil {
+			t.Fatalf("Normalize(%v, %d) error: %v", tt.freqs, tt.tableLog, err)
+		}
+		if reflect.DeepEqual(norm, tt.norm) == false {
+			t.Fatalf("Normalize(%v, %d) returns %v, want %v", tt.freqs, tt.tableLog, norm, tt.norm)
+		}
+	}
+
+	_, freqs := skewedSymbols(1<<16, 256, 1)
+	for tableLog := 8; tableLog <= ans.MaxTableLog; tableLog++ {
+		norm, err := ans.Normalize(freqs, tableLog)
+		if err != nil {
+			t.Fatalf("Normalize(%d) error: %v", tableLog, err)
+		}
+		sum := 0
+		for s, n := range norm {
+			if (n == 0) != (freqs[s] == 0) {
+				t.Fatalf("Normalize(%d) symbol %d frequency %d, original %d", tableLog, s, n, freqs[s])
+			}
+			sum += int(n)
+		}
+		if sum != 1<<tableLog {
+			t.Fatalf("Normalize(%d) sum %d, want %d", tableLog, sum, 1<<tableLog)
+		}
+	}
+}
+
+func TestNormalize_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		freqs    []uint64
+		tableLog int
+	}{
+		{"table log 0", []uint64{1}, 0},
+		{"table log 17", []uint64{1}, 17},
+		{"no symbol", []uint64{0, 0}, 4},
+		{"too many symbols", []uint64{1, 1, 1, 1, 1}, 2},
+	}
+	for _, tt := range tests {
+		if _, err := ans.Normalize(tt.freqs, tt.tableLog); err == nil {
+			t.Fatalf("Normalize %s wants error", tt.name)
+		}
+	}
+}
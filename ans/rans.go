@@ -0,0 +1,113 @@
+package ans
+
+import (
+	"fmt"
+
+	"github.com/hidez8891/bitio"
+)
+
+const (
+	ransLow   = 1 << 23 // lower bound of state
+	ransBits  = 32      // bit size of state
+	ransShift = 8       // renormalization bits
+)
+
+// RANS is range ANS coder.
+// States are 32 bits, and renormalized by 8 bits.
+type RANS struct {
+	scaleBits int
+	freq      []uint32
+	cum       []uint32 // cumulative frequency
+	slots     []int    // symbol of slot [0, 1<<scaleBits)
+}
+
+// NewRANS returns RANS of normalized frequencies.
+// Sum of frequencies must be power of 2, up to 1<<MaxTableLog.
+func NewRANS(norm []uint32) (*RANS, error) {
+	scaleBits, err := tableSize(norm, MaxTableLog)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &RANS{
+		scaleBits: scaleBits,
+		freq:      append([]uint32(nil), norm...),
+		cum:       make([]uint32, len(norm)),
+		slots:     make([]int, 1<<uint(scaleBits)),
+	}
+
+	c := uint32(0)
+	for s, f := range norm {
+		t.cum[s] = c
+		for i := uint32(0); i < f; i++ {
+			t.slots[c+i] = s
+		}
+		c += f
+	}
+
+	return t, nil
+}
+
+// ScaleBits returns log2 of sum of frequencies.
+func (t *RANS) ScaleBits() int {
+	return t.scaleBits
+}
+
+// Encode writes symbols.
+// If error happen, err will be set.
+func (t *RANS) Encode(w bitio.BitWriter, syms []int) error {
+	chunks := make([]chunk, 0, len(syms))
+
+	// encode in reverse order (state x is in [ransLow, ransLow<<ransShift))
+	x := uint64(ransLow)
+	for i := len(syms) - 1; i >= 0; i-- {
+		s := syms[i]
+		if s < 0 || s >= len(t.freq) || t.freq[s] == 0 {
+			return fmt.Errorf("ans: symbol %d: %w", s, ErrInvalidSymbol)
+		}
+
+		f := uint64(t.freq[s])
+		max := (ransLow >> uint(t.scaleBits) << ransShift) * f
+		for x >= max {
+			chunks = append(chunks, chunk{v: uint32(x & (1<<ransShift - 1)), n: ransShift})
+			x >>= ransShift
+		}
+		x = (x/f)<<uint(t.scaleBits) + x%f + uint64(t.cum[s])
+	}
+
+	return writeReversed(w, x, ransBits, chunks)
+}
+
+// Decode reads len(dst) symbols to dst.
+// If error happen, err will be set.
+func (t *RANS) Decode(r bitio.BitReader, dst []int) error {
+	x, err := readBits(r, ransBits)
+	if err != nil {
+		return err
+	}
+	if x < ransLow {
+		return fmt.Errorf("%w: initial state %#x", ErrCorrupted, x)
+	}
+
+	mask := uint64(1)<<uint(t.scaleBits) - 1
+	for i := range dst {
+		slot := x & mask
+		s := t.slots[slot]
+		dst[i] = s
+
+		x = uint64(t.freq[s])*(x>>uint(t.scaleBits)) + slot - uint64(t.cum[s])
+		for x < ransLow {
+			v, err := readBits(r, ransShift)
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			x = x<<ransShift | v
+		}
+	}
+
+	// decoder returns to the initial state of encoder
+	if x != ransLow {
+		return fmt.Errorf("%w: final state %#x", ErrCorrupted, x)
+	}
+	return nil
+}
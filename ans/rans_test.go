@@ -0,0 +1,56 @@
+package ans_test
+
+import (
+	"testing"
+
+	"github.com/hidez8891/bitio/ans"
+)
+
+func TestRANS(t *testing.T) {
+	syms, freqs := skewedSymbols(1<<16, 64, 2)
+	for _, scaleBits := range []int{6, 8, 12, 16} {
+		norm, _ := ans.Normalize(freqs, scaleBits)
+		c, err := ans.NewRANS(norm)
+		if err != nil {
+			t.Fatalf("NewRANS(%d) error: %v", scaleBits, err)
+		}
+		if c.ScaleBits() != scaleBits {
+			t.Fatalf("ScaleBits returns %d, want %d", c.ScaleBits(), scaleBits)
+		}
+
+		raw := testRoundTrip(t, "rANS", c, syms)
+		if bits, limit := len(raw)*8, entropyBits(freqs)*1.05; scaleBits >= 12 && float64(bits) > limit {
+			t.Fatalf("rANS(%d) encoded %d bit, want <= %.0f bit", scaleBits, bits, limit)
+		}
+	}
+}
+
+func TestRANS_Small(t *testing.T) {
+	tests := []struct {
+		name string
+		norm []uint32
+		syms []int
+	}{
+		{"single symbol", []uint32{0, 2}, []int{1, 1, 1}},
+		{"uniform", []uint32{1, 1, 1, 1}, []int{3, 0, 1, 2, 2}},
+		{"empty", []uint32{1, 3}, []int{}},
+	}
+	for _, tt := range tests {
+		c, err := ans.NewRANS(tt.norm)
+		if err != nil {
+			t.Fatalf("%s NewRANS error: %v", tt.name, err)
+		}
+		testRoundTrip(t, tt.name, c, tt.syms)
+	}
+}
+
+func TestRANS_Error(t *testing.T) {
+	for _, norm := range [][]uint32{{}, {1}, {1, 2}, {1 << 17}} {
+		if _, err := ans.NewRANS(norm); err == nil {
+			t.Fatalf("NewRANS(%v) wants error", norm)
+		}
+	}
+
+	c, _ := ans.NewRANS([]uint32{5, 0, 2, 1})
+	testErrors(t, "rANS", c)
+}
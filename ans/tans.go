@@ -0,0 +1,132 @@
+package ans
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/hidez8891/bitio"
+)
+
+// TANS is table-based ANS coder. (same as FSE of Zstandard)
+// States are tableLog bits, and each symbol is coded with 0 or more renormalization bits.
+type TANS struct {
+	tableLog int
+	norm     []uint32
+	enc      [][]uint32 // next state of symbol and sub-state (x_s - norm[s])
+	dec      []tansEntry
+}
+
+// tansEntry is decoding table entry of state.
+type tansEntry struct {
+	sym      int
+	nBit     int    // renormalization bits
+	baseline uint32 // next state without renormalization bits
+}
+
+// NewTANS returns TANS of normalized frequencies.
+// Sum of frequencies must be power of 2, up to 1<<MaxTableLog.
+func NewTANS(norm []uint32) (*TANS, error) {
+	tableLog, err := tableSize(norm, MaxTableLog)
+	if err != nil {
+		return nil, err
+	}
+	size := uint32(1) << uint(tableLog)
+
+	t := &TANS{
+		tableLog: tableLog,
+		norm:     append([]uint32(nil), norm...),
+		enc:      make([][]uint32, len(norm)),
+		dec:      make([]tansEntry, size),
+	}
+
+	// spread symbols (step is odd, so all positions are visited)
+	spread := make([]int, size)
+	step := (size>>1 + size>>3 + 3) | 1
+	pos := uint32(0)
+	for s, n := range norm {
+		for i := uint32(0); i < n; i++ {
+			spread[pos] = s
+			pos = (pos + step) & (size - 1)
+		}
+	}
+
+	// x_s of state is counted up in state order
+	next := append([]uint32(nil), norm...)
+	for s, n := range norm {
+		t.enc[s] = make([]uint32, 0, n)
+	}
+	for i, s := range spread {
+		xs := next[s]
+		next[s]++
+
+		nBit := tableLog - (bits.Len32(xs) - 1)
+		t.dec[i] = tansEntry{
+			sym:      s,
+			nBit:     nBit,
+			baseline: xs<<uint(nBit) - size,
+		}
+		t.enc[s] = append(t.enc[s], uint32(i))
+	}
+
+	return t, nil
+}
+
+// TableLog returns log2 of table size.
+func (t *TANS) TableLog() int {
+	return t.tableLog
+}
+
+// Encode writes symbols.
+// If error happen, err will be set.
+func (t *TANS) Encode(w bitio.BitWriter, syms []int) error {
+	size := uint32(1) << uint(t.tableLog)
+	chunks := make([]chunk, 0, len(syms))
+
+	// encode in reverse order (state x is in [size, 2*size))
+	x := size
+	for i := len(syms) - 1; i >= 0; i-- {
+		s := syms[i]
+		if s < 0 || s >= len(t.norm) || t.norm[s] == 0 {
+			return fmt.Errorf("ans: symbol %d: %w", s, ErrInvalidSymbol)
+		}
+
+		// x >> nBit is in [norm, 2*norm)
+		n := t.norm[s]
+		nBit := bits.Len32(x) - bits.Len32(n)
+		if x>>uint(nBit) < n {
+			nBit--
+		}
+		chunks = append(chunks, chunk{v: x & (1<<uint(nBit) - 1), n: nBit})
+		x = size + t.enc[s][x>>uint(nBit)-n]
+	}
+
+	return writeReversed(w, uint64(x-size), t.tableLog, chunks)
+}
+
+// Decode reads len(dst) symbols to dst.
+// If error happen, err will be set.
+func (t *TANS) Decode(r bitio.BitReader, dst []int) error {
+	state, err := readBits(r, t.tableLog)
+	if err != nil {
+		return err
+	}
+
+	for i := range dst {
+		e := t.dec[state]
+		dst[i] = e.sym
+
+		v := uint64(0)
+		if e.nBit > 0 {
+			if v, err = readBits(r, e.nBit); err != nil {
+				return unexpectedEOF(err)
+			}
+		}
+		state = uint64(e.baseline) + v
+	}
+
+	// decoder returns to the initial state of encoder
+	if state != 0 {
+		return fmt.Errorf("%w: final state %d", ErrCorrupted, state)
+	}
+	return nil
+}
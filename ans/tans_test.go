@@ -0,0 +1,56 @@
+package ans_test
+
+import (
+	"testing"
+
+	"github.com/hidez8891/bitio/ans"
+)
+
+func TestTANS(t *testing.T) {
+	syms, freqs := skewedSymbols(1<<16, 64, 1)
+	for _, tableLog := range []int{6, 8, 11, 16} {
+		norm, _ := ans.Normalize(freqs, tableLog)
+		c, err := ans.NewTANS(norm)
+		if err != nil {
+			t.Fatalf("NewTANS(%d) error: %v", tableLog, err)
+		}
+		if c.TableLog() != tableLog {
+			t.Fatalf("TableLog returns %d, want %d", c.TableLog(), tableLog)
+		}
+
+		raw := testRoundTrip(t, "tANS", c, syms)
+		if bits, limit := len(raw)*8, entropyBits(freqs)*1.05; tableLog >= 11 && float64(bits) > limit {
+			t.Fatalf("tANS(%d) encoded %d bit, want <= %.0f bit", tableLog, bits, limit)
+		}
+	}
+}
+
+func TestTANS_Small(t *testing.T) {
+	tests := []struct {
+		name string
+		norm []uint32
+		syms []int
+	}{
+		{"single symbol", []uint32{0, 2}, []int{1, 1, 1}},
+		{"uniform", []uint32{1, 1, 1, 1}, []int{3, 0, 1, 2, 2}},
+		{"empty", []uint32{1, 3}, []int{}},
+	}
+	for _, tt := range tests {
+		c, err := ans.NewTANS(tt.norm)
+		if err != nil {
+			t.Fatalf("%s NewTANS error: %v", tt.name, err)
+		}
+		testRoundTrip(t, tt.name, c, tt.syms)
+	}
+}
+
+func TestTANS_Error(t *testing.T) {
+	for _, norm := range [][]uint32{{}, {1}, {1, 2}, {1 << 17}} {
+		if _, err := ans.NewTANS(norm); err == nil {
+			t.Fatalf("NewTANS(%v) wants error", norm)
+		}
+	}
+
+	c, _ := ans.NewTANS([]uint32{5, 0, 2, 1})
+	testErrors(t, "tANS", c)
+}
@@ -0,0 +1,253 @@
+// Package arith implements adaptive binary arithmetic coding (range coding) on bitio.BitReader and bitio.BitWriter.
+//
+// The coder is the same as LZMA range coder: 11 bit probabilities, 32 bit range and byte output.
+// Encoded data is byte sequence written by bitio.BitWriter, so it can be embedded in bit stream.
+package arith
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hidez8891/bitio"
+)
+
+// ErrCorrupted is returned when encoded data is invalid.
+var ErrCorrupted = errors.New("arith: corrupted data")
+
+const (
+	probBits  = 11
+	probMax   = 1 << probBits
+	probInit  = probMax / 2
+	moveBits  = 5
+	topValue  = 1 << 24
+	initBytes = 5
+)
+
+// Model is adaptive probability model of a bit.
+// The zero value is ready to use, and predicts 0 and 1 equally.
+type Model struct {
+	p uint16 // probability of 0 (0: initial value)
+}
+
+// prob returns probability of 0.
+func (m *Model) prob() uint32 {
+	if m.p == 0 {
+		return probInit
+	}
+	return uint32(m.p)
+}
+
+// update adapts probability to bit.
+func (m *Model) update(bit byte) {
+	p := m.prob()
+	if bit == 0 {
+		p += (probMax - p) >> moveBits
+	} else {
+		p -= p >> moveBits
+	}
+	m.p = uint16(p)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// NewEncoder returns Encoder
+func NewEncoder(w bitio.BitWriter) *Encoder {
+	return &Encoder{
+		w:         w,
+		low:       0,
+		rng:       0xFFFFFFFF,
+		cache:     0,
+		cacheSize: 1,
+	}
+}
+
+// Encoder is binary arithmetic encoder.
+// Encoded bytes are written to BitWriter, and Close must be called after the last bit.
+type Encoder struct {
+	w         bitio.BitWriter
+	low       uint64
+	rng       uint32
+	cache     byte
+	cacheSize int64 // number of pending bytes (cache and following 0xFF)
+}
+
+// EncodeBit writes bit (0 or 1) with probability model m, and adapts m.
+// If error happen, err will be set.
+func (e *Encoder) EncodeBit(m *Model, bit byte) error {
+	bound := (e.rng >> probBits) * m.prob()
+	if bit == 0 {
+		e.rng = bound
+	} else {
+		e.low += uint64(bound)
+		e.rng -= bound
+	}
+	m.update(bit)
+
+	return e.normalize()
+}
+
+// EncodeDirect writes lower nBit bits (<= 32) of v with fixed probability 1/2.
+// If error happen, err will be set.
+func (e *Encoder) EncodeDirect(v uint32, nBit int) error {
+	if nBit < 0 || nBit > 32 {
+		return fmt.Errorf("arith: direct bit size %d is out of range [0, 32]", nBit)
+	}
+
+	for i := nBit - 1; i >= 0; i-- {
+		e.rng >>= 1
+		if v>>uint(i)&1 == 1 {
+			e.low += uint64(e.rng)
+		}
+		if err := e.normalize(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the rest of encoded data.
+// It does not flush BitWriter.
+// If error happen, err will be set.
+func (e *Encoder) Close() error {
+	for i := 0; i < initBytes; i++ {
+		if err := e.shiftLow(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalize keeps range 24 bit or more.
+func (e *Encoder) normalize() error {
+	for e.rng < topValue {
+		e.rng <<= 8
+		if err := e.shiftLow(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shiftLow writes the top byte of low, propagating carry to pending bytes.
+func (e *Encoder) shiftLow() error {
+	if uint32(e.low) < 0xFF000000 || e.low>>32 != 0 {
+		carry := byte(e.low >> 32)
+		b := e.cache
+		for ; e.cacheSize > 0; e.cacheSize-- {
+			if _, err := e.w.WriteBit(b+carry, 8); err != nil {
+				return err
+			}
+			b = 0xFF
+		}
+		e.cache = byte(e.low >> 24)
+	}
+	e.cacheSize++
+	e.low = (e.low & 0x00FFFFFF) << 8
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// NewDecoder returns Decoder.
+// It reads the first 5 bytes of encoded data.
+// If error happen, err will be set.
+func NewDecoder(r bitio.BitReader) (*Decoder, error) {
+	d := &Decoder{
+		r:    r,
+		rng:  0xFFFFFFFF,
+		code: 0,
+	}
+
+	for i := 0; i < initBytes; i++ {
+		b, err := d.readByte()
+		if err != nil {
+			if i == 0 && err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return nil, err
+		}
+		if i == 0 && b != 0 {
+			return nil, fmt.Errorf("%w: first byte is %#02x", ErrCorrupted, b)
+		}
+		d.code = d.code<<8 | uint32(b)
+	}
+	if d.code == d.rng {
+		return nil, fmt.Errorf("%w: invalid code", ErrCorrupted)
+	}
+
+	return d, nil
+}
+
+// Decoder is binary arithmetic decoder.
+type Decoder struct {
+	r    bitio.BitReader
+	rng  uint32
+	code uint32
+}
+
+// DecodeBit reads bit with probability model m, and adapts m.
+// If error happen, err will be set.
+func (d *Decoder) DecodeBit(m *Model) (byte, error) {
+	var bit byte
+
+	bound := (d.rng >> probBits) * m.prob()
+	if d.code < bound {
+		d.rng = bound
+	} else {
+		d.code -= bound
+		d.rng -= bound
+		bit = 1
+	}
+	m.update(bit)
+
+	return bit, d.normalize()
+}
+
+// DecodeDirect reads nBit bits (<= 32) with fixed probability 1/2.
+// If error happen, err will be set.
+func (d *Decoder) DecodeDirect(nBit int) (uint32, error) {
+	if nBit < 0 || nBit > 32 {
+		return 0, fmt.Errorf("arith: direct bit size %d is out of range [0, 32]", nBit)
+	}
+
+	var v uint32
+	for i := 0; i < nBit; i++ {
+		d.rng >>= 1
+		v <<= 1
+		if d.code >= d.rng {
+			d.code -= d.rng
+			v |= 1
+		}
+		if err := d.normalize(); err != nil {
+			return 0, err
+		}
+	}
+	return v, nil
+}
+
+// normalize keeps range 24 bit or more.
+func (d *Decoder) normalize() error {
+	for d.rng < topValue {
+		b, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		d.rng <<= 8
+		d.code = d.code<<8 | uint32(b)
+	}
+	return nil
+}
+
+// readByte reads a byte of encoded data.
+func (d *Decoder) readByte() (byte, error) {
+	var b byte
+	n, err := d.r.ReadBit(&b, 8)
+	switch {
+	case err == io.EOF || (err == nil && n != 8):
+		return 0, io.ErrUnexpectedEOF
+	case err != nil:
+		return 0, err
+	}
+	return b, nil
+}
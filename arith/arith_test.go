@@ -0,0 +1,220 @@
+package arith_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/arith"
+)
+
+// encode returns encoded data of f.
+func encode(t testing.TB, f func(e *arith.Encoder) error) []byte {
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	e := arith.NewEncoder(w)
+	if err := f(e); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+	w.Flush()
+	return b.Bytes()
+}
+
+// decoder returns Decoder of encoded data.
+func decoder(t testing.TB, raw []byte) *arith.Decoder {
+	d, err := arith.NewDecoder(bitio.NewBitReadBuffer(bytes.NewReader(raw)))
+	if err != nil {
+		t.Fatalf("NewDecoder error: %v", err)
+	}
+	return d
+}
+
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		name string
+		bits string
+		v    uint32 // 16 bit direct value
+		raw  []byte
+	}{
+		{"empty", "", 0, []byte{0x00, 0x00, 0x00, 0x00, 0x00}},
+		{"bits", "0010110001000011", 0xabcd, []byte{0x00, 0x2f, 0x9c, 0x67, 0x9d, 0xa5, 0xcd, 0x17, 0x00}},
+	}
+
+	for _, tt := range tests {
+		raw := encode(t, func(e *arith.Encoder) error {
+			var m arith.Model
+			for _, c := range tt.bits {
+				if err := e.EncodeBit(&m, byte(c-'0')); err != nil {
+					return err
+				}
+			}
+			if tt.bits == "" {
+				return nil
+			}
+			return e.EncodeDirect(tt.v, 16)
+		})
+		if reflect.DeepEqual(raw, tt.raw) == false {
+			t.Fatalf("%s encode %#v, want %#v", tt.name, raw, tt.raw)
+		}
+
+		d := decoder(t, raw)
+		var m arith.Model
+		for i, c := range tt.bits {
+			if bit, err := d.DecodeBit(&m); err != nil || bit != byte(c-'0') {
+				t.Fatalf("%s decode #%d %d (%v), want %c", tt.name, i, bit, err, c)
+			}
+		}
+		if tt.bits != "" {
+			if v, err := d.DecodeDirect(16); err != nil || v != tt.v {
+				t.Fatalf("%s decode direct %#x (%v), want %#x", tt.name, v, err, tt.v)
+			}
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	const size = 1 << 20
+
+	// 10% of bits are 1, and direct values are inserted
+	rnd := rand.New(rand.NewSource(1))
+	bits := make([]byte, size)
+	for i := range bits {
+		if rnd.Intn(10) == 0 {
+			bits[i] = 1
+		}
+	}
+	direct := make([]uint32, size/1024)
+	for i := range direct {
+		direct[i] = rnd.Uint32()
+	}
+
+	f := func(e *arith.Encoder) error {
+		var m arith.Model
+		for i, bit := range bits {
+			if err := e.EncodeBit(&m, bit); err != nil {
+				return err
+			}
+			if i%1024 == 0 {
+				if err := e.EncodeDirect(direct[i/1024], 32); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	raw := encode(t, f)
+
+	// entropy of bits is 0.47 bit
+	if n := len(raw); n > size/8*6/10 {
+		t.Fatalf("encoded size %d byte, want <= %d byte", n, size/8*6/10)
+	}
+	if again := encode(t, f); bytes.Equal(raw, again) == false {
+		t.Fatalf("encoded data is not deterministic")
+	}
+
+	d := decoder(t, raw)
+	var m arith.Model
+	for i, exp := range bits {
+		if bit, err := d.DecodeBit(&m); err != nil || bit != exp {
+			t.Fatalf("decode #%d %d (%v), want %d", i, bit, err, exp)
+		}
+		if i%1024 == 0 {
+			if v, err := d.DecodeDirect(32); err != nil || v != direct[i/1024] {
+				t.Fatalf("decode direct #%d %#x (%v), want %#x", i/1024, v, err, direct[i/1024])
+			}
+		}
+	}
+}
+
+func TestRoundTrip_BitStream(t *testing.T) {
+	// encoded data is embedded between unaligned bit fields
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	w.WriteBit(0x5, 3)
+	e := arith.NewEncoder(w)
+	var m arith.Model
+	for i := 0; i < 100; i++ {
+		e.EncodeBit(&m, byte(i%3/2))
+	}
+	e.Close()
+	w.WriteBit(0x3, 2)
+	w.Flush()
+
+	r := bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes()))
+	var head, tail byte
+	r.ReadBit(&head, 3)
+	d, err := arith.NewDecoder(r)
+	if err != nil {
+		t.Fatalf("NewDecoder error: %v", err)
+	}
+	m = arith.Model{}
+	for i := 0; i < 100; i++ {
+		if bit, err := d.DecodeBit(&m); err != nil || bit != byte(i%3/2) {
+			t.Fatalf("decode #%d %d (%v), want %d", i, bit, err, i%3/2)
+		}
+	}
+	r.ReadBit(&tail, 2)
+	if head != 0x5 || tail != 0x3 {
+		t.Fatalf("read fields %#x, %#x, want 0x5, 0x3", head, tail)
+	}
+}
+
+func TestDecoder_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{"empty", []byte{}, io.EOF},
+		{"short header", []byte{0x00, 0x00}, io.ErrUnexpectedEOF},
+		{"first byte", []byte{0x01, 0x00, 0x00, 0x00, 0x00}, arith.ErrCorrupted},
+		{"invalid code", []byte{0x00, 0xff, 0xff, 0xff, 0xff}, arith.ErrCorrupted},
+	}
+	for _, tt := range tests {
+		if _, err := arith.NewDecoder(bitio.NewBitReadBuffer(bytes.NewReader(tt.raw))); errors.Is(err, tt.err) == false {
+			t.Fatalf("%s error %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	// truncated data
+	raw := encode(t, func(e *arith.Encoder) error { return e.EncodeDirect(0xffffffff, 32) })
+	d := decoder(t, raw[:6])
+	if _, err := d.DecodeDirect(32); errors.Is(err, io.ErrUnexpectedEOF) == false {
+		t.Fatalf("truncated data error %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	e := arith.NewEncoder(bitio.NewBitWriteBuffer(io.Discard))
+	if err := e.EncodeDirect(0, 33); err == nil {
+		t.Fatalf("EncodeDirect 33 bit wants error")
+	}
+	if _, err := d.DecodeDirect(33); err == nil {
+		t.Fatalf("DecodeDirect 33 bit wants error")
+	}
+}
+
+func BenchmarkDecodeBit(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	raw := encode(b, func(e *arith.Encoder) error {
+		var m arith.Model
+		for i := 0; i < 1<<16; i++ {
+			e.EncodeBit(&m, byte(rnd.Intn(4)/3))
+		}
+		return nil
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d := decoder(b, raw)
+		var m arith.Model
+		for j := 0; j < 1<<16; j++ {
+			d.DecodeBit(&m)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package arith
+
+import "fmt"
+
+// MaxTreeBits is max bit size of BitTree.
+const MaxTreeBits = 16
+
+// BitTree is adaptive probability model of nBit value.
+// Each bit is coded with the model selected by higher bits. (ex: LZMA literal, length)
+type BitTree struct {
+	nBit   int
+	models []Model // models[1<<nBit]: node 1 is root
+}
+
+// NewBitTree returns BitTree of nBit (1..16) value.
+func NewBitTree(nBit int) (*BitTree, error) {
+	if nBit < 1 || nBit > MaxTreeBits {
+		return nil, fmt.Errorf("arith: bit tree size %d is out of range [1, %d]", nBit, MaxTreeBits)
+	}
+	return &BitTree{
+		nBit:   nBit,
+		models: make([]Model, 1<<uint(nBit)),
+	}, nil
+}
+
+// Bits returns bit size of value.
+func (t *BitTree) Bits() int {
+	return t.nBit
+}
+
+// Encode writes v from the most significant bit.
+// If error happen, err will be set.
+func (t *BitTree) Encode(e *Encoder, v uint32) error {
+	if v>>uint(t.nBit) != 0 {
+		return fmt.Errorf("arith: value %d exceeds %d bit", v, t.nBit)
+	}
+
+	m := uint32(1)
+	for i := t.nBit - 1; i >= 0; i-- {
+		bit := byte(v >> uint(i) & 1)
+		if err := e.EncodeBit(&t.models[m], bit); err != nil {
+			return err
+		}
+		m = m<<1 | uint32(bit)
+	}
+	return nil
+}
+
+// Decode reads value from the most significant bit.
+// If error happen, err will be set.
+func (t *BitTree) Decode(d *Decoder) (uint32, error) {
+	m := uint32(1)
+	for i := 0; i < t.nBit; i++ {
+		bit, err := d.DecodeBit(&t.models[m])
+		if err != nil {
+			return 0, err
+		}
+		m = m<<1 | uint32(bit)
+	}
+	return m - 1<<uint(t.nBit), nil
+}
+
+// ReverseEncode writes v from the least significant bit.
+// If error happen, err will be set.
+func (t *BitTree) ReverseEncode(e *Encoder, v uint32) error {
+	if v>>uint(t.nBit) != 0 {
+		return fmt.Errorf("arith: value %d exceeds %d bit", v, t.nBit)
+	}
+
+	m := uint32(1)
+	for i := 0; i < t.nBit; i++ {
+		bit := byte(v >> uint(i) & 1)
+		if err := e.EncodeBit(&t.models[m], bit); err != nil {
+			return err
+		}
+		m = m<<1 | uint32(bit)
+	}
+	return nil
+}
+
+// ReverseDecode reads value from the least significant bit.
+// If error happen, err will be set.
+func (t *BitTree) ReverseDecode(d *Decoder) (uint32, error) {
+	m, v := uint32(1), uint32(0)
+	for i := 0; i < t.nBit; i++ {
+		bit, err := d.DecodeBit(&t.models[m])
+		if err != nil {
+			return 0, err
+		}
+		m = m<<1 | uint32(bit)
+		v |= uint32(bit) << uint(i)
+	}
+	return v, nil
+}
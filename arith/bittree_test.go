@@ -0,0 +1,70 @@
+package arith_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/hidez8891/bitio/arith"
+)
+
+func TestBitTree(t *testing.T) {
+	for _, nBit := range []int{1, 3, 8, 16} {
+		// skewed values
+		rnd := rand.New(rand.NewSource(int64(nBit)))
+		values := make([]uint32, 1<<14)
+		for i := range values {
+			values[i] = uint32(rnd.ExpFloat64()*4) & (1<<uint(nBit) - 1)
+		}
+
+		tree, _ := arith.NewBitTree(nBit)
+		rev, _ := arith.NewBitTree(nBit)
+		raw := encode(t, func(e *arith.Encoder) error {
+			for _, v := range values {
+				if err := tree.Encode(e, v); err != nil {
+					return err
+				}
+				if err := rev.ReverseEncode(e, v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if n := len(raw); nBit >= 8 && n > len(values)*2*nBit/8/2 {
+			t.Fatalf("%d bit tree encoded size %d byte, want <= %d byte", nBit, n, len(values)*2*nBit/8/2)
+		}
+
+		tree, _ = arith.NewBitTree(nBit)
+		rev, _ = arith.NewBitTree(nBit)
+		d := decoder(t, raw)
+		for i, exp := range values {
+			if v, err := tree.Decode(d); err != nil || v != exp {
+				t.Fatalf("%d bit tree decode #%d %d (%v), want %d", nBit, i, v, err, exp)
+			}
+			if v, err := rev.ReverseDecode(d); err != nil || v != exp {
+				t.Fatalf("%d bit tree reverse decode #%d %d (%v), want %d", nBit, i, v, err, exp)
+			}
+		}
+	}
+}
+
+func TestBitTree_Error(t *testing.T) {
+	for _, nBit := range []int{0, 17} {
+		if _, err := arith.NewBitTree(nBit); err == nil {
+			t.Fatalf("NewBitTree(%d) wants error", nBit)
+		}
+	}
+
+	tree, _ := arith.NewBitTree(4)
+	if tree.Bits() != 4 {
+		t.Fatalf("Bits returns %d, want 4", tree.Bits())
+	}
+	encode(t, func(e *arith.Encoder) error {
+		if err := tree.Encode(e, 16); err == nil {
+			t.Fatalf("Encode 16 wants error")
+		}
+		if err := tree.ReverseEncode(e, 16); err == nil {
+			t.Fatalf("ReverseEncode 16 wants error")
+		}
+		return nil
+	})
+}
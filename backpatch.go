@@ -0,0 +1,208 @@
+package bitio
+
+import (
+	"fmt"
+	"io"
+)
+
+// NewBufferedBitWriter returns BufferedBitWriter
+func NewBufferedBitWriter(w io.Writer) *BufferedBitWriter {
+	return &BufferedBitWriter{
+		w: w,
+	}
+}
+
+// BufferedBitWriter is BitWriter which buffers bits until Flush.
+// Reserve makes placeholder of bits, which is filled later. (size of chunk, offset of box, ...)
+type BufferedBitWriter struct {
+	w            io.Writer
+	buf          BitBuffer
+	placeholders []*Placeholder // placeholders of buffer
+	flushed      int            // bit size of flushed data
+}
+
+// WriteBit writes single data (bitSize) and returns write size.
+// If error happen, err will be set.
+// Input data is stored right justified. (4bit = 0x0f)
+func (obj *BufferedBitWriter) WriteBit(p byte, bitSize int) (nBit int, err error) {
+	return obj.buf.WriteBit(p, bitSize)
+}
+
+// WriteBits writes data (bitSize) and returns write size.
+// If error happen, err will be set.
+// Input data is stored right justified. (12bit = 0x0f 0xff)
+func (obj *BufferedBitWriter) WriteBits(p []byte, bitSize int) (nBit int, err error) {
+	return obj.buf.WriteBits(p, bitSize)
+}
+
+// Write writes data len(p) size and returns write size.
+// If error happen, err will be set.
+func (obj *BufferedBitWriter) Write(p []byte) (nByte int, err error) {
+	return obj.buf.Write(p)
+}
+
+// Offset returns bit offset from the start of stream.
+func (obj *BufferedBitWriter) Offset() int {
+	return obj.flushed + obj.buf.Len()
+}
+
+// Reserve writes nBits zero bits and returns placeholder of them.
+// Return error if nBits is not positive.
+func (obj *BufferedBitWriter) Reserve(nBits int) (*Placeholder, error) {
+	if nBits < 1 {
+		return nil, fmt.Errorf("bitio: Reserve requires positive size, set %d bits", nBits)
+	}
+
+	ph := &Placeholder{
+		w:    obj,
+		pos:  obj.buf.Len(),
+		size: nBits,
+	}
+	for left := nBits; left > 0; left -= 8 {
+		obj.buf.WriteBit(0, min(left, 8))
+	}
+	obj.placeholders = append(obj.placeholders, ph)
+	return ph, nil
+}
+
+// Flush writes buffered data. (0 right padding)
+// If error happen, err will be set, and the written bytes are dropped from buffer.
+// Return error wrapping ErrUnresolvedPlaceholder if any placeholder is not filled, and nothing is written.
+func (obj *BufferedBitWriter) Flush() error {
+	unresolved := 0
+	for _, ph := range obj.placeholders {
+		if !ph.Resolved() {
+			unresolved++
+		}
+	}
+	if unresolved > 0 {
+		return fmt.Errorf("bitio: %d placeholder(s) are not filled: %w", unresolved, ErrUnresolvedPlaceholder)
+	}
+
+	n, err := obj.w.Write(obj.buf.Bytes())
+	if err == nil {
+		n = len(obj.buf.Bytes())
+	}
+	obj.drop(n)
+	return err
+}
+
+// drop drops the first n bytes of buffer, which are flushed.
+func (obj *BufferedBitWriter) drop(n int) {
+	if n <= 0 {
+		return
+	}
+	if n >= len(obj.buf.Bytes()) {
+		n = len(obj.buf.Bytes())
+		obj.flushed += 8 * n
+		obj.buf.Reset()
+	} else {
+		obj.flushed += 8 * n
+		obj.buf.buf = append(obj.buf.buf[:0], obj.buf.buf[n:]...)
+		obj.buf.n -= 8 * n
+	}
+
+	// placeholders are out of buffer (partially flushed one too)
+	rest := obj.placeholders[:0]
+	for _, ph := range obj.placeholders {
+		if ph.pos < 8*n {
+			ph.w = nil
+			continue
+		}
+		ph.pos -= 8 * n
+		rest = append(rest, ph)
+	}
+	obj.placeholders = rest
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Placeholder is reserved bits of BufferedBitWriter.
+// Placeholder is BitWriter, which fills the reserved bits from the start.
+type Placeholder struct {
+	w    *BufferedBitWriter // nil: flushed
+	pos  int                // bit position in buffer
+	size int
+	n    int // filled bits
+}
+
+// Len returns bit size of placeholder.
+func (obj *Placeholder) Len() int {
+	return obj.size
+}
+
+// Resolved reports whether all bits of placeholder are filled.
+func (obj *Placeholder) Resolved() bool {
+	return obj.n == obj.size
+}
+
+// check checks that bitSize bits can be filled.
+func (obj *Placeholder) check(bitSize int) error {
+	if obj.w == nil {
+		return fmt.Errorf("bitio: placeholder is already flushed")
+	}
+	if obj.n+bitSize > obj.size {
+		return fmt.Errorf("placeholder of %d bits is filled %d bits, want %d bits: %w", obj.size, obj.n, bitSize, ErrValueOverflow)
+	}
+	return nil
+}
+
+// WriteBit fills single data (bitSize) and returns write size.
+// If error happen, err will be set.
+// Input data is stored right justified. (4bit = 0x0f)
+func (obj *Placeholder) WriteBit(p byte, bitSize int) (nBit int, err error) {
+	if bitSize < 0 || bitSize > 8 {
+		return 0, fmt.Errorf("bitio: WriteBit requires write size <= 8")
+	}
+	if err = obj.check(bitSize); err != nil {
+		return
+	}
+
+	buf := obj.w.buf.buf
+	for i := 0; i < bitSize; i++ {
+		k := obj.pos + obj.n + i
+		if p>>uint(bitSize-1-i)&1 != 0 {
+			buf[k/8] |= 0x80 >> uint(k%8)
+		} else {
+			buf[k/8] &^= 0x80 >> uint(k%8)
+		}
+	}
+	obj.n += bitSize
+	return bitSize, nil
+}
+
+// WriteBits fills data (bitSize) and returns write size.
+// If error happen, err will be set.
+// Input data is stored right justified. (12bit = 0x0f 0xff)
+func (obj *Placeholder) WriteBits(p []byte, bitSize int) (nBit int, err error) {
+	if bitSize < 0 || len(p)*8 < bitSize {
+		return 0, fmt.Errorf("bitio: argument p[] is %d bits, want %d bits", len(p)*8, bitSize)
+	}
+	if err = obj.check(bitSize); err != nil {
+		return
+	}
+
+	p = p[len(p)-(bitSize+7)/8:]
+	if n := bitSize % 8; n > 0 {
+		obj.WriteBit(p[0], n)
+		p = p[1:]
+	}
+	for _, b := range p {
+		obj.WriteBit(b, 8)
+	}
+	return bitSize, nil
+}
+
+// Write fills data len(p) size and returns write size.
+// If error happen, err will be set.
+func (obj *Placeholder) Write(p []byte) (nByte int, err error) {
+	if _, err = obj.WriteBits(p, 8*len(p)); err != nil {
+		return
+	}
+	return len(p), nil
+}
+
+// Flush does nothing. Data is written by Flush of BufferedBitWriter.
+func (obj *Placeholder) Flush() error {
+	return nil
+}
@@ -0,0 +1,190 @@
+package bitio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+type riffSize struct {
+	Size uint32 `bit:"32"`
+}
+
+type boxSize struct {
+	Size uint32 `bit:"32" endian:"big"`
+}
+
+func TestBufferedBitWriter(t *testing.T) {
+	b := new(bytes.Buffer)
+	w := bitio.NewBufferedBitWriter(b)
+
+	// RIFF chunk (little-endian size)
+	w.Write([]byte("RIFF"))
+	riff, _ := w.Reserve(32)
+	start := w.Offset()
+	w.Write([]byte("WAVE"))
+
+	// MP4 box in RIFF (big-endian size includes header)
+	box := w.Offset()
+	size, _ := w.Reserve(32)
+	w.Write([]byte("free"))
+	w.Write([]byte{1, 2, 3})
+	if _, err := bitio.NewBitFieldWriter2(size).WriteStruct(&boxSize{uint32((w.Offset() - box) / 8)}); err != nil {
+		t.Fatalf("WriteStruct to box placeholder error: %v", err)
+	}
+
+	// Flush fails until all placeholders are filled
+	if err := w.Flush(); !errors.Is(err, bitio.ErrUnresolvedPlaceholder) {
+		t.Fatalf("Flush error %v, want %v", err, bitio.ErrUnresolvedPlaceholder)
+	}
+	if b.Len() != 0 {
+		t.Fatalf("Flush writes %x with unresolved placeholder", b.Bytes())
+	}
+
+	if _, err := bitio.NewBitFieldWriter2(riff).WriteStruct(&riffSize{uint32((w.Offset() - start) / 8)}); err != nil {
+		t.Fatalf("WriteStruct to riff placeholder error: %v", err)
+	}
+	if !riff.Resolved() || !size.Resolved() {
+		t.Fatalf("placeholders are not resolved")
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+
+	exp := hex.EncodeToString([]byte("RIFF")) + "0f000000" + hex.EncodeToString([]byte("WAVE")) +
+		"0000000b" + hex.EncodeToString([]byte("free")) + "010203"
+	if got := hex.EncodeToString(b.Bytes()); got != exp {
+		t.Fatalf("BufferedBitWriter writes %s, want %s", got, exp)
+	}
+	if w.Offset() != 8*b.Len() {
+		t.Fatalf("Offset returns %d, want %d", w.Offset(), 8*b.Len())
+	}
+}
+
+func TestBufferedBitWriter_Bits(t *testing.T) {
+	b := new(bytes.Buffer)
+	w := bitio.NewBufferedBitWriter(b)
+
+	// placeholder is not byte aligned
+	w.WriteBit(0x7, 3)
+	ph, _ := w.Reserve(11)
+	w.WriteBit(0x1, 2)
+	ph.WriteBit(0x2, 2)
+	ph.WriteBits([]byte{0x01, 0x55}, 9)
+	if ph.Len() != 11 || !ph.Resolved() {
+		t.Fatalf("placeholder of %d bits is not resolved", ph.Len())
+	}
+	w.Flush()
+
+	if exp := binaryToByteArray("111_10_101010101_01"); !bytes.Equal(b.Bytes(), exp) {
+		t.Fatalf("BufferedBitWriter writes %x, want %x", b.Bytes(), exp)
+	}
+}
+
+func TestBufferedBitWriter_Error(t *testing.T) {
+	w := bitio.NewBufferedBitWriter(new(bytes.Buffer))
+	if _, err := w.Reserve(0); err == nil {
+		t.Fatalf("Reserve(0) must be error")
+	}
+
+	ph, _ := w.Reserve(8)
+	if _, err := ph.WriteBits([]byte{0x01, 0x00}, 9); !errors.Is(err, bitio.ErrValueOverflow) {
+		t.Fatalf("WriteBits 9 bits to 8 bits placeholder error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	ph.WriteBit(0x3, 4)
+	if ph.Resolved() {
+		t.Fatalf("placeholder is resolved by 4 bits")
+	}
+	if _, err := ph.Write([]byte{0xff}); !errors.Is(err, bitio.ErrValueOverflow) {
+		t.Fatalf("Write to filled placeholder error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	if err := w.Flush(); !errors.Is(err, bitio.ErrUnresolvedPlaceholder) {
+		t.Fatalf("Flush error %v, want %v", err, bitio.ErrUnresolvedPlaceholder)
+	}
+
+	ph.WriteBit(0x0, 4)
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+
+	// placeholder of flushed data
+	if _, err := ph.WriteBit(0, 1); err == nil || !strings.Contains(err.Error(), "already flushed") {
+		t.Fatalf("WriteBit to flushed placeholder error %v, want already flushed", err)
+	}
+}
+
+func TestBufferedBitWriter_Placeholder(t *testing.T) {
+	w := bitio.NewBufferedBitWriter(new(bytes.Buffer))
+	ph, _ := w.Reserve(16)
+	ph.Write([]byte{0x12, 0x34})
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+
+	// placeholder is out of buffer after Flush
+	writes := map[string]func() error{
+		"WriteBit":  func() error { _, err := ph.WriteBit(0, 1); return err },
+		"WriteBits": func() error { _, err := ph.WriteBits([]byte{0}, 1); return err },
+		"Write":     func() error { _, err := ph.Write([]byte{0}); return err },
+	}
+	for name, write := range writes {
+		if err := write(); err == nil || !strings.Contains(err.Error(), "already flushed") {
+			t.Fatalf("%s to flushed placeholder error %v, want already flushed", name, err)
+		}
+	}
+}
+
+// shortWriter is io.Writer which writes at most n bytes.
+type shortWriter struct {
+	bytes.Buffer
+	n int
+}
+
+var errShortWrite = errors.New("short write")
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		w.Buffer.Write(p[:w.n])
+		n := w.n
+		w.n = 0
+		return n, errShortWrite
+	}
+	w.n -= len(p)
+	return w.Buffer.Write(p)
+}
+
+func TestBufferedBitWriter_ShortWrite(t *testing.T) {
+	b := &shortWriter{n: 3}
+	w := bitio.NewBufferedBitWriter(b)
+	w.Write([]byte{0x01, 0x02})
+	ph1, _ := w.Reserve(16)
+	ph2, _ := w.Reserve(8)
+	ph1.Write([]byte{0x03, 0x04})
+	ph2.Write([]byte{0x05})
+
+	// written bytes are dropped
+	if err := w.Flush(); err != errShortWrite {
+		t.Fatalf("Flush error %v, want %v", err, errShortWrite)
+	}
+	if w.Offset() != 5*8 {
+		t.Fatalf("Offset returns %d, want %d", w.Offset(), 5*8)
+	}
+	if _, err := ph1.WriteBit(0, 1); err == nil {
+		t.Fatalf("WriteBit to partially flushed placeholder must be error")
+	}
+	if _, err := ph2.WriteBit(0, 1); !errors.Is(err, bitio.ErrValueOverflow) {
+		t.Fatalf("WriteBit to filled placeholder error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	b.n = 10
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if exp := []byte{0x01, 0x02, 0x03, 0x04, 0x05}; !bytes.Equal(b.Bytes(), exp) {
+		t.Fatalf("BufferedBitWriter writes %x, want %x", b.Bytes(), exp)
+	}
+}
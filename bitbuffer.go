@@ -3,6 +3,7 @@ package bitio
 import (
 	"fmt"
 	"io"
+	"math/bits"
 )
 
 // BitReader is the interface bit/byte reading method
@@ -12,6 +13,15 @@ type BitReader interface {
 	Read(p []byte) (nByte int, err error)
 }
 
+// BitPeeker is the interface bit look ahead method
+// PeekBits returns next bits (bitSize <= 56) right justified without consuming them.
+// nBit is less than bitSize only at the end of data, and missing bits are 0.
+// SkipBits consumes bits (bitSize) and returns consumed size.
+type BitPeeker interface {
+	PeekBits(bitSize int) (v uint64, nBit int, err error)
+	SkipBits(bitSize int) (nBit int, err error)
+}
+
 // BitWriter is the interface bit/byte writting method
 type BitWriter interface {
 	WriteBit(p byte, bitSize int) (nBit int, err error)
@@ -25,15 +35,15 @@ type BitWriter interface {
 // NewBitReadBuffer returns BitReadBuffer
 func NewBitReadBuffer(r io.Reader) *BitReadBuffer {
 	return &BitReadBuffer{
-		r:    r,
+		r:    byteQueue{r: r},
 		buff: 0,
 		left: 0,
 	}
 }
 
-// BitReadBuffer is implemented by BitReader
+// BitReadBuffer is implemented by BitReader and BitPeeker
 type BitReadBuffer struct {
-	r    io.Reader
+	r    byteQueue
 	buff byte
 	left int
 }
@@ -96,7 +106,7 @@ func (obj *BitReadBuffer) ReadBits(p []byte, bitSize int) (nBit int, err error)
 	wantReadBytes := (bitSize - obj.left + 7) / 8
 	bufBits := wantReadBytes * 8
 	buf := make([]byte, wantReadBytes, wantReadBytes+2)
-	if _, err = obj.r.Read(buf[:wantReadBytes]); err != nil {
+	if _, err = obj.r.ReadFull(buf[:wantReadBytes]); err != nil {
 		return
 	}
 
@@ -136,6 +146,88 @@ func (obj *BitReadBuffer) Read(p []byte) (nByte int, err error) {
 	return nBit / 8, err
 }
 
+// PeekBits returns next bits (bitSize <= 56) without consuming them.
+// Output data is stored right justified. (4bit = 0x0f)
+// nBit is less than bitSize only at the end of data, and missing bits are 0.
+func (obj *BitReadBuffer) PeekBits(bitSize int) (v uint64, nBit int, err error) {
+	if bitSize < 0 || bitSize > 56 {
+		return 0, 0, fmt.Errorf("bitio: PeekBits requires read size <= 56")
+	}
+
+	queued, err := obj.r.fill((bitSize - obj.left + 7) / 8)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	v = uint64(obj.buff >> uint(8-obj.left))
+	nBit = obj.left
+	for _, b := range queued {
+		if nBit >= bitSize {
+			break
+		}
+		v = v<<8 | uint64(b)
+		nBit += 8
+	}
+
+	if nBit >= bitSize {
+		return v >> uint(nBit-bitSize), bitSize, nil
+	}
+	return v << uint(bitSize-nBit), nBit, nil
+}
+
+// SkipBits consumes bits (bitSize) and returns consumed size.
+// If error happen, err will be set.
+func (obj *BitReadBuffer) SkipBits(bitSize int) (nBit int, err error) {
+	var b byte
+	for nBit < bitSize {
+		n := bitSize - nBit
+		if n > 8 {
+			n = 8
+		}
+		m, err := obj.ReadBit(&b, n)
+		nBit += m
+		if err != nil {
+			return nBit, err
+		}
+	}
+	return nBit, nil
+}
+
+// scanZeros reads zero bits until 1 bit, and returns the number of zero bits.
+// The terminating 1 bit is consumed.
+// Returns error if the number of zero bits exceeds max.
+func (obj *BitReadBuffer) scanZeros(max int) (int, error) {
+	zeros := 0
+	for {
+		if obj.left > 0 {
+			lz := bits.LeadingZeros8(obj.buff)
+			if lz < obj.left {
+				if zeros += lz; zeros > max {
+					break
+				}
+				obj.buff <<= uint(lz + 1)
+				obj.left -= lz + 1
+				return zeros, nil
+			}
+
+			zeros += obj.left
+			obj.buff = 0
+			obj.left = 0
+			if zeros > max {
+				break
+			}
+		}
+
+		if err := obj.forceRead(); err != nil {
+			if zeros > 0 {
+				err = unexpectedEOF(err)
+			}
+			return 0, err
+		}
+	}
+	return 0, fmt.Errorf("unary prefix exceeds %d bit: %w", max, ErrValueOverflow)
+}
+
 // tryRead reads 1 byte data if obj.buff is empty.
 // If error happen, returns err.
 func (obj *BitReadBuffer) tryRead() error {
@@ -148,13 +240,12 @@ func (obj *BitReadBuffer) tryRead() error {
 // forceRead reads 1 byte data.
 // If error happen, returns err.
 func (obj *BitReadBuffer) forceRead() error {
-	b := make([]byte, 1)
-
-	if _, err := obj.r.Read(b); err != nil {
+	b, err := obj.r.ReadByte()
+	if err != nil {
 		return err
 	}
 
-	obj.buff = b[0]
+	obj.buff = b
 	obj.left = 8
 	return nil
 }
@@ -182,6 +273,9 @@ type BitWriteBuffer struct {
 // Input data is stored left justified. (4bit = 0x0f)
 // Output data is stored right justified. (4bit = 0xf0)
 func (obj *BitWriteBuffer) WriteBit(p byte, bitSize int) (nBit int, err error) {
+	if bitSize > 8 {
+		return 0, fmt.Errorf("bitio: WriteBit requires write size <= 8")
+	}
 	p <<= uint(8 - bitSize)
 
 	if obj.left+bitSize > 8 {
@@ -298,3 +392,71 @@ func (obj *BitWriteBuffer) forceWrite() error {
 	obj.left = 0
 	return nil
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+// byteQueue is io.Reader with look ahead bytes.
+type byteQueue struct {
+	r    io.Reader
+	buf  []byte // look ahead bytes are buf[head:]
+	head int
+	tmp  [8]byte
+}
+
+// ReadFull reads len(p) bytes. (same as io.ReadFull)
+func (q *byteQueue) ReadFull(p []byte) (int, error) {
+	n := copy(p, q.buf[q.head:])
+	q.consume(n)
+	if n == len(p) {
+		return n, nil
+	}
+
+	m, err := io.ReadFull(q.r, p[n:])
+	if err == io.EOF && n > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n + m, err
+}
+
+// ReadByte reads 1 byte.
+func (q *byteQueue) ReadByte() (byte, error) {
+	if q.head < len(q.buf) {
+		b := q.buf[q.head]
+		q.consume(1)
+		return b, nil
+	}
+
+	if _, err := io.ReadFull(q.r, q.tmp[:1]); err != nil {
+		return 0, err
+	}
+	return q.tmp[0], nil
+}
+
+// fill reads bytes until n bytes are queued, and returns queued bytes.
+// Queued bytes are less than n only at the end of data.
+func (q *byteQueue) fill(n int) ([]byte, error) {
+	for len(q.buf)-q.head < n {
+		want := n - (len(q.buf) - q.head)
+		if want > len(q.tmp) {
+			want = len(q.tmp)
+		}
+
+		m, err := io.ReadFull(q.r, q.tmp[:want])
+		q.buf = append(q.buf, q.tmp[:m]...)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return q.buf[q.head:], nil
+}
+
+// consume removes n bytes from queue.
+func (q *byteQueue) consume(n int) {
+	q.head += n
+	if q.head == len(q.buf) {
+		q.buf = q.buf[:0]
+		q.head = 0
+	}
+}
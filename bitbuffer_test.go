@@ -19,6 +19,8 @@ func TestBit_interface(t *testing.T) {
 	_ = r1
 	var r2 io.Reader = r
 	_ = r2
+	var r3 bitio.BitPeeker = r
+	_ = r3
 
 	w := &bitio.BitWriteBuffer{}
 	var w1 bitio.BitWriter = w
@@ -220,6 +222,40 @@ func TestBitReadBuffer_Read(t *testing.T) {
 	}
 }
 
+func TestBitReadBuffer_PeekBits(t *testing.T) {
+	r := bitio.NewBitReadBuffer(bytes.NewReader([]byte{0x5c, 0xab, 0x21}))
+
+	tests := []struct {
+		skip int
+		peek int
+		v    uint64
+		nBit int
+	}{
+		{0, 4, 0x5, 4},
+		{4, 12, 0xcab, 12},
+		{0, 16, 0xcab2, 16},
+		{12, 16, 0x2100, 8},
+		{8, 8, 0x00, 0},
+	}
+	for _, tt := range tests {
+		if n, err := r.SkipBits(tt.skip); err != nil || n != tt.skip {
+			t.Fatalf("SkipBits(%d) = %d (%v)", tt.skip, n, err)
+		}
+		v, n, err := r.PeekBits(tt.peek)
+		if err != nil || v != tt.v || n != tt.nBit {
+			t.Fatalf("PeekBits(%d) = %#x, %d (%v), want %#x, %d", tt.peek, v, n, err, tt.v, tt.nBit)
+		}
+	}
+
+	// peeked data is readable
+	r = bitio.NewBitReadBuffer(bytes.NewReader([]byte{0x5c, 0xab, 0x21}))
+	r.PeekBits(20)
+	p := make([]byte, 3)
+	if _, err := r.Read(p); err != nil || reflect.DeepEqual(p, []byte{0x5c, 0xab, 0x21}) == false {
+		t.Fatalf("Read after PeekBits %#v (%v)", p, err)
+	}
+}
+
 func TestBitReadBuffer_Read_Combination(t *testing.T) {
 	datas := [][]byte{
 		binaryToByteArray("" +
@@ -270,6 +270,27 @@ var bitfieldTests = []struct {
 		},
 		bits: 24,
 	},
+	{
+		name: "nested struct 01",
+		raw:  []byte{0x12, 0x02, 0x31, 0x50},
+		ptr: &struct {
+			Val1 struct {
+				A uint8 `bit:"4"`
+				B uint8 `bit:"4"`
+			}
+			Val2 uint8 `bit:"8"`
+			Val3 []struct {
+				C uint8 `bit:"4"`
+				D bool  `bit:"4"`
+			} `len:"Val2"`
+		}{},
+		exp: map[string]interface{}{
+			"Val1": "{1 2}",
+			"Val2": 0x02,
+			"Val3": "[{3 true} {5 false}]",
+		},
+		bits: 32,
+	},
 }
 
 func TestBitFieldReader_Read(t *testing.T) {
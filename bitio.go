@@ -27,27 +27,9 @@ func Read[T constraints.Integer](br BitReader, nBit int, order ByteOrder, dst *T
 		return fmt.Errorf("unsupport %T type", *dst)
 	}
 
-	buf := make([]byte, 8)
-	if n, err := br.ReadBits(buf, nBit); err != nil {
+	value, err := readUint64(br, nBit, order, make([]byte, 8))
+	if err != nil {
 		return err
-	} else if n != nBit {
-		return fmt.Errorf("insufficient size of read, want %d bit, read %d bit", nBit, n)
-	}
-
-	var value uint64
-	if order == LittleEndian {
-		// little endian
-		// 12bit: 0x123 = 0x*****231 -> 0x****2301 -> 0x2301****
-		if nBit%8 > 0 {
-			leftShift(buf, uint(8-nBit%8))
-			buf[len(buf)-1] >>= uint(8 - nBit%8)
-		}
-		leftShift(buf, uint(8*(8-(nBit+7)/8)))
-		value = binary.LittleEndian.Uint64(buf)
-	} else {
-		// big endian (no shift)
-		// 12bit: 0x123 = 0x*****123
-		value = binary.BigEndian.Uint64(buf)
 	}
 
 	*dst = T(value)
@@ -68,7 +50,8 @@ func ReadSlice[T constraints.Integer](br BitReader, elemBit int, order ByteOrder
 
 // Write write T type value to BitWriter as specified number of bits.
 // Return error if writing to writer fails or number of write bits is exceeds T size.
-func Write[T constraints.Integer](bw BitWriter, nBit int, order ByteOrder, src T) error {
+// If value does not fit in number of bits, it is handled by OverflowPolicy option. (default: OverflowError)
+func Write[T constraints.Integer](bw BitWriter, nBit int, order ByteOrder, src T, opts ...WriteOption) error {
 	tsize := int(unsafe.Sizeof(src))
 	if tsize*8 < nBit {
 		return fmt.Errorf("write size %d bit exceeds %T type size %d bit", nBit, src, tsize*8)
@@ -77,11 +60,67 @@ func Write[T constraints.Integer](bw BitWriter, nBit int, order ByteOrder, src T
 		return fmt.Errorf("unsupport %T type", src)
 	}
 
-	buf := make([]byte, 8)
+	config := newWriteConfig(opts)
+
+	var value uint64
+	var err error
+	if zero := T(0); ^zero < zero {
+		value, err = fitInt(int64(src), nBit, config.overflow)
+	} else {
+		value, err = fitUint(uint64(src), nBit, config.overflow)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeUint64(bw, nBit, order, value, make([]byte, 8))
+}
+
+// WriteSlice writes a slice of T to BitWriter.
+// Return error if element write fails.
+func WriteSlice[T constraints.Integer](bw BitWriter, elemBit int, order ByteOrder, src []T, opts ...WriteOption) error {
+	length := len(src)
+	for i := 0; i < length; i++ {
+		if err := Write(bw, elemBit, order, src[i], opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readUint64 reads nBit bits from BitReader and converts to uint64 value.
+// buf is a work space of 8 bytes.
+func readUint64(br BitReader, nBit int, order ByteOrder, buf []byte) (uint64, error) {
+	clear(buf)
+	if n, err := br.ReadBits(buf, nBit); err != nil {
+		return 0, err
+	} else if n != nBit {
+		return 0, fmt.Errorf("insufficient size of read, want %d bit, read %d bit: %w", nBit, n, ErrUnexpectedEOF)
+	}
+
+	if order == LittleEndian {
+		// little endian
+		// 12bit: 0x123 = 0x*****231 -> 0x****2301 -> 0x2301****
+		if nBit%8 > 0 {
+			leftShift(buf, uint(8-nBit%8))
+			buf[len(buf)-1] >>= uint(8 - nBit%8)
+		}
+		leftShift(buf, uint(8*(8-(nBit+7)/8)))
+		return binary.LittleEndian.Uint64(buf), nil
+	}
+
+	// big endian (no shift)
+	// 12bit: 0x123 = 0x*****123
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+// writeUint64 writes uint64 value to BitWriter as nBit bits.
+// buf is a work space of 8 bytes.
+func writeUint64(bw BitWriter, nBit int, order ByteOrder, value uint64, buf []byte) error {
 	if order == LittleEndian {
 		// little endian
 		// 12bit: 0x123 = 0x2301**** -> 0x****2301 -> 0x*****231
-		binary.LittleEndian.PutUint64(buf, uint64(src))
+		binary.LittleEndian.PutUint64(buf, value)
 		rightShift(buf, 8*uint(8-(nBit+7)/8))
 		if nBit%8 > 0 {
 			buf[len(buf)-1] <<= uint(8 - nBit%8)
@@ -90,7 +129,7 @@ func Write[T constraints.Integer](bw BitWriter, nBit int, order ByteOrder, src T
 	} else {
 		// big endian (no shift)
 		// 12bit: 0x123 = 0x****0123
-		binary.BigEndian.PutUint64(buf, uint64(src))
+		binary.BigEndian.PutUint64(buf, value)
 	}
 
 	if n, err := bw.WriteBits(buf, nBit); err != nil {
@@ -101,15 +140,3 @@ func Write[T constraints.Integer](bw BitWriter, nBit int, order ByteOrder, src T
 
 	return nil
 }
-
-// WriteSlice writes a slice of T to BitWriter.
-// Return error if element write fails.
-func WriteSlice[T constraints.Integer](bw BitWriter, elemBit int, order ByteOrder, src []T) error {
-	length := len(src)
-	for i := 0; i < length; i++ {
-		if err := Write(bw, elemBit, order, src[i]); err != nil {
-			return err
-		}
-	}
-	return nil
-}
@@ -14,6 +14,7 @@ type testDataRW[T constraints.Integer] struct {
 	buf   []byte
 	nBit  int
 	order bitio.ByteOrder
+	opts  []bitio.WriteOption
 }
 
 func testRead[T constraints.Integer](t *testing.T, tests []testDataRW[T]) {
@@ -211,7 +212,7 @@ func testWrite[T constraints.Integer](t *testing.T, tests []testDataRW[T]) {
 		b := new(bytes.Buffer)
 		bw := bitio.NewBitWriteBuffer(b)
 
-		err := bitio.Write(bw, tt.nBit, tt.order, tt.value)
+		err := bitio.Write(bw, tt.nBit, tt.order, tt.value, tt.opts...)
 		if err != nil {
 			t.Fatalf("Write[%T] write fail:%v [testcase-%d]", tt.value, err, i)
 		}
@@ -229,17 +230,25 @@ func testWrite[T constraints.Integer](t *testing.T, tests []testDataRW[T]) {
 
 func TestWrite(t *testing.T) {
 	testWrite(t, []testDataRW[int8]{
+		{
+			value: 0,
+			nBit:  0,
+			order: bitio.BigEndian,
+			buf:   nil,
+		},
 		{
 			value: -int8(^(uint8(0xab) - 1)),
 			nBit:  4,
 			order: bitio.LittleEndian,
 			buf:   []byte{0xb0},
+			opts:  []bitio.WriteOption{bitio.OverflowTruncate},
 		},
 		{
 			value: -int8(^(uint8(0xab) - 1)),
 			nBit:  4,
 			order: bitio.BigEndian,
 			buf:   []byte{0xb0},
+			opts:  []bitio.WriteOption{bitio.OverflowTruncate},
 		},
 		{
 			value: -int8(^(uint8(0xab) - 1)),
@@ -256,6 +265,12 @@ func TestWrite(t *testing.T) {
 	})
 
 	testWrite(t, []testDataRW[uint8]{
+		{
+			value: 0,
+			nBit:  0,
+			order: bitio.LittleEndian,
+			buf:   nil,
+		},
 		{
 			value: 0xab,
 			nBit:  8,
@@ -276,12 +291,14 @@ func TestWrite(t *testing.T) {
 			nBit:  12,
 			order: bitio.LittleEndian,
 			buf:   []byte{0xcd, 0xb0},
+			opts:  []bitio.WriteOption{bitio.OverflowTruncate},
 		},
 		{
 			value: -int16(^(uint16(0xabcd) - 1)),
 			nBit:  12,
 			order: bitio.BigEndian,
 			buf:   []byte{0xbc, 0xd0},
+			opts:  []bitio.WriteOption{bitio.OverflowTruncate},
 		},
 		{
 			value: -int16(^(uint16(0xabcd) - 1)),
@@ -318,12 +335,14 @@ func TestWrite(t *testing.T) {
 			nBit:  28,
 			order: bitio.LittleEndian,
 			buf:   []byte{0xcd, 0x34, 0x12, 0xb0},
+			opts:  []bitio.WriteOption{bitio.OverflowTruncate},
 		},
 		{
 			value: -int32(^(uint32(0xab1234cd) - 1)),
 			nBit:  28,
 			order: bitio.BigEndian,
 			buf:   []byte{0xb1, 0x23, 0x4c, 0xd0},
+			opts:  []bitio.WriteOption{bitio.OverflowTruncate},
 		},
 		{
 			value: -int32(^(uint32(0xab1234cd) - 1)),
@@ -360,12 +379,14 @@ func TestWrite(t *testing.T) {
 			nBit:  60,
 			order: bitio.LittleEndian,
 			buf:   []byte{0xcd, 0xbc, 0x9a, 0x78, 0x56, 0x34, 0x12, 0xb0},
+			opts:  []bitio.WriteOption{bitio.OverflowTruncate},
 		},
 		{
 			value: -int64(^(uint64(0xab123456789abccd) - 1)),
 			nBit:  60,
 			order: bitio.BigEndian,
 			buf:   []byte{0xb1, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcc, 0xd0},
+			opts:  []bitio.WriteOption{bitio.OverflowTruncate},
 		},
 		{
 			value: -int64(^(uint64(0xab123456789abccd) - 1)),
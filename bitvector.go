@@ -0,0 +1,221 @@
+package bitio
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// rankBlock is number of words counted by an entry of rank index. (512 bits)
+const rankBlock = 8
+
+// BitVector is bit array with rank/select queries.
+//
+// Rank and select use auxiliary index tables, which are built by BuildIndex
+// or by the first query after modification. Queries are safe for concurrent use after BuildIndex.
+//
+// Serialized form is 64 bit length and bits from index 0.
+type BitVector struct {
+	words   bitWords
+	n       int
+	indexed bool
+	ranks   []int // ones before word (j * rankBlock)
+	ones    selectIndex
+	zeros   selectIndex
+}
+
+// NewBitVector returns BitVector of n zero bits.
+func NewBitVector(n int) *BitVector {
+	n = max(n, 0)
+	return &BitVector{words: newBitWords(n), n: n}
+}
+
+// Len returns number of bits.
+func (v *BitVector) Len() int {
+	return v.n
+}
+
+// Get returns bit i. Bit out of range is 0 (false).
+func (v *BitVector) Get(i int) bool {
+	return i >= 0 && i < v.n && v.words.get(i)
+}
+
+// Set sets bit i to 1.
+func (v *BitVector) Set(i int) error {
+	if err := v.check(i, 1); err != nil {
+		return err
+	}
+	v.words.set(i)
+	v.indexed = false
+	return nil
+}
+
+// Clear sets bit i to 0.
+func (v *BitVector) Clear(i int) error {
+	if err := v.check(i, 1); err != nil {
+		return err
+	}
+	v.words[i/64] &^= 1 << uint(i%64)
+	v.indexed = false
+	return nil
+}
+
+// Flip inverts bit i.
+func (v *BitVector) Flip(i int) error {
+	if err := v.check(i, 1); err != nil {
+		return err
+	}
+	v.words[i/64] ^= 1 << uint(i%64)
+	v.indexed = false
+	return nil
+}
+
+// Append appends a bit.
+func (v *BitVector) Append(bit bool) {
+	if v.n%64 == 0 {
+		v.words = append(v.words, 0)
+	}
+	if bit {
+		v.words.set(v.n)
+	}
+	v.n++
+	v.indexed = false
+}
+
+// CopyBits copies n bits of src from srcPos to dstPos of v.
+// src can be v, and overlapped range is copied as copy() does.
+func (v *BitVector) CopyBits(dstPos int, src *BitVector, srcPos, n int) error {
+	if n < 0 {
+		return fmt.Errorf("bitio: number of bits %d is negative", n)
+	}
+	if err := src.check(srcPos, n); err != nil {
+		return err
+	}
+	if err := v.check(dstPos, n); err != nil {
+		return err
+	}
+
+	if src == v && dstPos > srcPos {
+		// backward not to overwrite source bits
+		for end := n; end > 0; {
+			m := min(64, end)
+			end -= m
+			v.words.putBits(dstPos+end, m, src.words.getBits(srcPos+end, m))
+		}
+	} else {
+		for off := 0; off < n; off += 64 {
+			m := min(64, n-off)
+			v.words.putBits(dstPos+off, m, src.words.getBits(srcPos+off, m))
+		}
+	}
+	v.indexed = false
+	return nil
+}
+
+// check checks range [i, i+n) is in BitVector.
+func (v *BitVector) check(i, n int) error {
+	if i < 0 || n > v.n-i {
+		return fmt.Errorf("bitio: range [%d, %d) is out of range [0, %d)", i, i+n, v.n)
+	}
+	return nil
+}
+
+// OnesCount returns number of ones.
+func (v *BitVector) OnesCount() int {
+	n := 0
+	for _, w := range v.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// BuildIndex builds index tables of rank and select queries.
+func (v *BitVector) BuildIndex() {
+	if v.indexed {
+		return
+	}
+
+	v.ranks = append(v.ranks[:0], 0)
+	rank := 0
+	for k, w := range v.words {
+		rank += bits.OnesCount64(w)
+		if (k+1)%rankBlock == 0 {
+			v.ranks = append(v.ranks, rank)
+		}
+	}
+	v.ones = newSelectIndex(v.words, v.n, true)
+	v.zeros = newSelectIndex(v.words, v.n, false)
+	v.indexed = true
+}
+
+// Rank1 returns number of ones in [0, i). i is clamped to [0, Len()].
+func (v *BitVector) Rank1(i int) int {
+	v.BuildIndex()
+	i = min(max(i, 0), v.n)
+
+	k := i / 64
+	rank := v.ranks[k/rankBlock]
+	for j := k / rankBlock * rankBlock; j < k; j++ {
+		rank += bits.OnesCount64(v.words[j])
+	}
+	if off := uint(i % 64); off > 0 {
+		rank += bits.OnesCount64(v.words[k] << (64 - off))
+	}
+	return rank
+}
+
+// Rank0 returns number of zeros in [0, i). i is clamped to [0, Len()].
+func (v *BitVector) Rank0(i int) int {
+	i = min(max(i, 0), v.n)
+	return i - v.Rank1(i)
+}
+
+// Select1 returns position of the k-th (0 origin) 1, or -1 if there is not.
+func (v *BitVector) Select1(k int) int {
+	v.BuildIndex()
+	return v.ones.find(v.words, v.n, k)
+}
+
+// Select0 returns position of the k-th (0 origin) 0, or -1 if there is not.
+func (v *BitVector) Select0(k int) int {
+	v.BuildIndex()
+	return v.zeros.find(v.words, v.n, k)
+}
+
+// nextOne returns position of the first 1 at or after bit i, or -1.
+func (v *BitVector) nextOne(i int) int {
+	return v.words.nextOne(i)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// WriteBitVector writes serialized form of BitVector.
+// Return error if writing to writer fails.
+func WriteBitVector(bw BitWriter, v *BitVector) error {
+	buf := make([]byte, 8)
+	if err := writeUint64(bw, 64, BigEndian, uint64(v.n), buf); err != nil {
+		return err
+	}
+	return writeBitWords(bw, v.words, v.n, buf)
+}
+
+// ReadBitVector reads serialized form of BitVector.
+// Return error if reading from reader fails or data is invalid.
+func ReadBitVector(br BitReader) (*BitVector, error) {
+	buf := make([]byte, 8)
+	n, err := readUint64(br, 64, BigEndian, buf)
+	if err != nil {
+		return nil, err
+	}
+	if n > math.MaxInt {
+		return nil, fmt.Errorf("bitio: bit vector length %d is too large", n)
+	}
+
+	words, err := readBitWords(br, int(n), buf)
+	if err != nil {
+		return nil, err
+	}
+	return &BitVector{words: words, n: int(n)}, nil
+}
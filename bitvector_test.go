@@ -0,0 +1,226 @@
+package bitio_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+// checkBitVector compares BitVector with []bool by all queries.
+func checkBitVector(t *testing.T, v *bitio.BitVector, exp []bool) {
+	if v.Len() != len(exp) {
+		t.Fatalf("Len() returns %d, want %d", v.Len(), len(exp))
+	}
+
+	ones, zeros := 0, 0
+	for i, b := range exp {
+		if v.Get(i) != b {
+			t.Fatalf("Get(%d) returns %v, want %v", i, v.Get(i), b)
+		}
+		if r := v.Rank1(i); r != ones {
+			t.Fatalf("Rank1(%d) returns %d, want %d", i, r, ones)
+		}
+		if r := v.Rank0(i); r != zeros {
+			t.Fatalf("Rank0(%d) returns %d, want %d", i, r, zeros)
+		}
+		if b {
+			if p := v.Select1(ones); p != i {
+				t.Fatalf("Select1(%d) returns %d, want %d", ones, p, i)
+			}
+			ones++
+		} else {
+			if p := v.Select0(zeros); p != i {
+				t.Fatalf("Select0(%d) returns %d, want %d", zeros, p, i)
+			}
+			zeros++
+		}
+	}
+
+	if v.OnesCount() != ones || v.Rank1(len(exp)) != ones || v.Rank1(len(exp)+10) != ones || v.Rank0(len(exp)) != zeros {
+		t.Fatalf("OnesCount() returns %d, Rank1(Len()) returns %d, want %d", v.OnesCount(), v.Rank1(len(exp)), ones)
+	}
+	if v.Select1(ones) != -1 || v.Select0(zeros) != -1 || v.Select1(-1) != -1 {
+		t.Fatalf("Select out of range must return -1")
+	}
+	if v.Get(-1) || v.Get(len(exp)) {
+		t.Fatalf("Get() out of range must return false")
+	}
+}
+
+func randomBits(rnd *rand.Rand, n int, density float64) []bool {
+	b := make([]bool, n)
+	for i := range b {
+		b[i] = rnd.Float64() < density
+	}
+	return b
+}
+
+func TestBitVector(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 63, 64, 65, 512, 513, 5000} {
+		for _, density := range []float64{0, 0.01, 0.5, 0.99, 1} {
+			exp := randomBits(rnd, n, density)
+
+			v := bitio.NewBitVector(n)
+			for i, b := range exp {
+				if b {
+					if err := v.Set(i); err != nil {
+						t.Fatalf("Set(%d) error: %v", i, err)
+					}
+				}
+			}
+			checkBitVector(t, v, exp)
+
+			w := bitio.NewBitVector(0)
+			for _, b := range exp {
+				w.Append(b)
+			}
+			checkBitVector(t, w, exp)
+		}
+	}
+}
+
+func TestBitVector_Modify(t *testing.T) {
+	v := bitio.NewBitVector(100)
+	exp := make([]bool, 100)
+	for _, i := range []int{0, 5, 63, 64, 99} {
+		v.Set(i)
+		exp[i] = true
+	}
+	checkBitVector(t, v, exp)
+
+	// index is rebuilt after modification
+	v.Clear(5)
+	v.Flip(64)
+	v.Flip(70)
+	exp[5], exp[64], exp[70] = false, false, true
+	checkBitVector(t, v, exp)
+
+	for _, i := range []int{-1, 100} {
+		if v.Set(i) == nil || v.Clear(i) == nil || v.Flip(i) == nil {
+			t.Fatalf("Set/Clear/Flip(%d) must be error", i)
+		}
+	}
+}
+
+func TestBitVector_CopyBits(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	src := randomBits(rnd, 1000, 0.5)
+
+	tests := []struct {
+		dstPos, srcPos, n int
+		self              bool
+	}{
+		{0, 0, 1000, false},
+		{3, 130, 500, false},
+		{700, 1, 300, false},
+		{10, 0, 0, false},
+		{0, 100, 700, true},
+		{100, 0, 700, true},
+		{1, 0, 999, true},
+		{0, 1, 999, true},
+		{5, 5, 200, true},
+	}
+
+	for _, tt := range tests {
+		sv := bitio.NewBitVector(0)
+		for _, b := range src {
+			sv.Append(b)
+		}
+		dst := randomBits(rnd, 1000, 0.5)
+		dv := bitio.NewBitVector(0)
+		for _, b := range dst {
+			dv.Append(b)
+		}
+		if tt.self {
+			dst, dv = src, sv
+		}
+
+		exp := append([]bool{}, dst...)
+		copy(exp[tt.dstPos:], src[tt.srcPos:tt.srcPos+tt.n])
+		if err := dv.CopyBits(tt.dstPos, sv, tt.srcPos, tt.n); err != nil {
+			t.Fatalf("CopyBits(%d, %d, %d) error: %v", tt.dstPos, tt.srcPos, tt.n, err)
+		}
+		checkBitVector(t, dv, exp)
+	}
+
+	v := bitio.NewBitVector(10)
+	for _, tt := range [][3]int{{0, 0, 11}, {5, 0, 6}, {0, -1, 2}, {0, 0, -1}} {
+		if err := v.CopyBits(tt[0], v, tt[1], tt[2]); err == nil {
+			t.Fatalf("CopyBits(%v) must be error", tt)
+		}
+	}
+}
+
+func TestBitVector_Serialize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 64, 100, 1000} {
+		exp := randomBits(rnd, n, 0.3)
+		v := bitio.NewBitVector(0)
+		for _, b := range exp {
+			v.Append(b)
+		}
+
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		if err := bitio.WriteBitVector(w, v); err != nil {
+			t.Fatalf("WriteBitVector() error: %v", err)
+		}
+		w.Flush()
+		if size := 8 + (n+7)/8; b.Len() != size {
+			t.Fatalf("WriteBitVector() writes %d bytes, want %d", b.Len(), size)
+		}
+
+		// bits are written from index 0
+		if n > 0 && (b.Bytes()[8]>>7 == 1) != exp[0] {
+			t.Fatalf("WriteBitVector() writes bit 0 as %x", b.Bytes()[8])
+		}
+
+		data := b.Bytes()
+		got, err := bitio.ReadBitVector(bitio.NewBitReadBuffer(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("ReadBitVector() error: %v", err)
+		}
+		checkBitVector(t, got, exp)
+
+		if n > 0 {
+			if _, err := bitio.ReadBitVector(bitio.NewBitReadBuffer(bytes.NewReader(data[:len(data)-1]))); err != io.ErrUnexpectedEOF {
+				t.Fatalf("ReadBitVector() of truncated data error %v, want ErrUnexpectedEOF", err)
+			}
+		}
+	}
+
+	if _, err := bitio.ReadBitVector(bitio.NewBitReadBuffer(bytes.NewReader(bytes.Repeat([]byte{0xff}, 8)))); err == nil {
+		t.Fatalf("ReadBitVector() of huge length must be error")
+	}
+}
+
+func BenchmarkBitVector_Rank1(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	v := bitio.NewBitVector(0)
+	for _, bit := range randomBits(rnd, 1<<22, 0.5) {
+		v.Append(bit)
+	}
+	v.BuildIndex()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.Rank1(i * 7919 % v.Len())
+	}
+}
+
+func BenchmarkBitVector_Select1(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	v := bitio.NewBitVector(0)
+	for _, bit := range randomBits(rnd, 1<<22, 0.5) {
+		v.Append(bit)
+	}
+	v.BuildIndex()
+	ones := v.OnesCount()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.Select1(i * 7919 % ones)
+	}
+}
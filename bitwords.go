@@ -0,0 +1,174 @@
+package bitio
+
+import "math/bits"
+
+// bitWords is bit array of 64 bit words. Bit i is bit (i % 64) of word (i / 64).
+type bitWords []uint64
+
+// newBitWords returns bitWords of n bits.
+func newBitWords(n int) bitWords {
+	return make(bitWords, (n+63)/64)
+}
+
+// get returns bit i.
+func (w bitWords) get(i int) bool {
+	return w[i/64]>>uint(i%64)&1 == 1
+}
+
+// set sets bit i to 1.
+func (w bitWords) set(i int) {
+	w[i/64] |= 1 << uint(i%64)
+}
+
+// getBits returns n (<= 64) bits from bit pos. The first bit is the least significant bit.
+func (w bitWords) getBits(pos, n int) uint64 {
+	if n == 0 {
+		return 0
+	}
+	k, off := pos/64, uint(pos%64)
+	v := w[k] >> off
+	if int(off)+n > 64 {
+		v |= w[k+1] << (64 - off)
+	}
+	if n < 64 {
+		v &= 1<<uint(n) - 1
+	}
+	return v
+}
+
+// setBits sets n (<= 64) bits from bit pos to v. The bits must be 0.
+func (w bitWords) setBits(pos, n int, v uint64) {
+	if n == 0 {
+		return
+	}
+	k, off := pos/64, uint(pos%64)
+	w[k] |= v << off
+	if int(off)+n > 64 {
+		w[k+1] |= v >> (64 - off)
+	}
+}
+
+// putBits overwrites n (<= 64) bits from bit pos by v.
+func (w bitWords) putBits(pos, n int, v uint64) {
+	if n == 0 {
+		return
+	}
+	mask := ^uint64(0)
+	if n < 64 {
+		mask = 1<<uint(n) - 1
+	}
+	k, off := pos/64, uint(pos%64)
+	w[k] = w[k]&^(mask<<off) | (v&mask)<<off
+	if int(off)+n > 64 {
+		w[k+1] = w[k+1]&^(mask>>(64-off)) | (v&mask)>>(64-off)
+	}
+}
+
+// nextOne returns position of the first 1 at or after bit i, or -1.
+func (w bitWords) nextOne(i int) int {
+	k := i / 64
+	if k >= len(w) {
+		return -1
+	}
+	v := w[k] >> uint(i%64) << uint(i%64)
+	for v == 0 {
+		if k++; k >= len(w) {
+			return -1
+		}
+		v = w[k]
+	}
+	return k*64 + bits.TrailingZeros64(v)
+}
+
+// selectInWord returns position of the k-th (0 origin) 1 in v.
+func selectInWord(v uint64, k int) int {
+	for ; k > 0; k-- {
+		v &= v - 1
+	}
+	return bits.TrailingZeros64(v)
+}
+
+// writeBitWords writes the first n bits. Bit i is written as the i-th bit of stream.
+func writeBitWords(bw BitWriter, w bitWords, n int, buf []byte) error {
+	for pos := 0; pos < n; pos += 64 {
+		m := min(64, n-pos)
+		v := bits.Reverse64(w.getBits(pos, m)) >> uint(64-m)
+		if err := writeUint64(bw, m, BigEndian, v, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBitWords reads n bits written by writeBitWords.
+// Words grow while reading, not to allocate by corrupted size.
+func readBitWords(br BitReader, n int, buf []byte) (bitWords, error) {
+	var w bitWords
+	for pos := 0; pos < n; pos += 64 {
+		m := min(64, n-pos)
+		v, err := readUint64(br, m, BigEndian, buf)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		w = append(w, bits.Reverse64(v<<uint(64-m)))
+	}
+	return w, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// selectSample is interval of sampled ones (zeros) of selectIndex.
+const selectSample = 512
+
+// selectIndex is sampled index of select query.
+// samples[j] is word index of the (j * selectSample)-th 1 (or 0), and ranks[j] is number of ones (or zeros) before the word.
+type selectIndex struct {
+	samples []int
+	ranks   []int
+	ones    bool
+	count   int
+}
+
+// newSelectIndex returns selectIndex of ones (or zeros) in the first n bits.
+func newSelectIndex(w bitWords, n int, ones bool) selectIndex {
+	idx := selectIndex{ones: ones}
+	for k := range w {
+		c := bits.OnesCount64(idx.word(w, n, k))
+		for idx.count+c > len(idx.samples)*selectSample {
+			idx.samples = append(idx.samples, k)
+			idx.ranks = append(idx.ranks, idx.count)
+		}
+		idx.count += c
+	}
+	return idx
+}
+
+// word returns word k of counted bits (zeros are inverted, and bits after n are not counted).
+func (idx *selectIndex) word(w bitWords, n, k int) uint64 {
+	v := w[k]
+	if !idx.ones {
+		v = ^v
+	}
+	if rest := n - k*64; rest < 64 {
+		v &= 1<<uint(rest) - 1
+	}
+	return v
+}
+
+// find returns position of the k-th (0 origin) 1 (or 0), or -1.
+func (idx *selectIndex) find(w bitWords, n, k int) int {
+	if k < 0 || k >= idx.count {
+		return -1
+	}
+
+	j := k / selectSample
+	rank := idx.ranks[j]
+	for wk := idx.samples[j]; ; wk++ {
+		v := idx.word(w, n, wk)
+		c := bits.OnesCount64(v)
+		if rank+c > k {
+			return wk*64 + selectInWord(v, k-rank)
+		}
+		rank += c
+	}
+}
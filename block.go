@@ -0,0 +1,468 @@
+package bitio
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// BlockEncoding is encoding of integer slice block.
+//
+// Block is self-describing, and bit width is computed from values:
+//
+//	count        ue(v)
+//	head values  order values (Delta: first value, DeltaOfDelta: first value and delta)
+//	base         7 bit size n + n bits (minimum of residuals)
+//	width        7 bit (0..64)
+//	residuals    (count - order) x width bits (residual - base)
+//
+// Residuals of FrameOfReference are values, and those of Delta/DeltaOfDelta are zigzag deltas.
+// Signed values (head values, FrameOfReference base) are zigzag encoded.
+// Head values, base and width are omitted if there are no values.
+// Width 0 is limited to 2^20 residuals, more residuals are written with width 1.
+type BlockEncoding int
+
+const (
+	// FrameOfReference writes values as difference from minimum value.
+	FrameOfReference BlockEncoding = iota
+	// Delta writes differences of adjacent values.
+	Delta
+	// DeltaOfDelta writes differences of adjacent deltas.
+	DeltaOfDelta
+)
+
+// String returns name of BlockEncoding.
+func (enc BlockEncoding) String() string {
+	switch enc {
+	case FrameOfReference:
+		return "for"
+	case Delta:
+		return "delta"
+	case DeltaOfDelta:
+		return "delta:2"
+	default:
+		return "BlockEncoding(" + strconv.Itoa(int(enc)) + ")"
+	}
+}
+
+// readChunk is max number of values allocated at once by ReadBlock.
+const readChunk = 4096
+
+// maxBlockRun is max number of residuals of width 0, which are read without input.
+const maxBlockRun = 1 << 20
+
+// WriteBlock writes src as self-describing block of BlockEncoding.
+// Return error if writing to writer fails.
+func WriteBlock[T constraints.Integer](bw BitWriter, enc BlockEncoding, src []T) error {
+	values := make([]uint64, len(src))
+	for i, v := range src {
+		values[i] = uint64(v) // signed value is sign extended
+	}
+	_, err := writeBlock(bw, enc, values, isSigned[T](), make([]byte, 8))
+	return err
+}
+
+// ReadBlock reads self-describing block of BlockEncoding.
+// Returns error wrapping ErrValueOverflow if value does not fit in T,
+// or width 0 block has more than 2^20 residuals.
+func ReadBlock[T constraints.Integer](br BitReader, enc BlockEncoding) ([]T, error) {
+	values, _, err := readBlock(br, enc, isSigned[T](), make([]byte, 8))
+	if err != nil {
+		return nil, err
+	}
+
+	dst := make([]T, len(values))
+	for i, v := range values {
+		dst[i] = T(v)
+		if uint64(dst[i]) != v {
+			return nil, fmt.Errorf("element %d: value %d exceeds %T: %w", i, v, dst[i], ErrValueOverflow)
+		}
+	}
+	return dst, nil
+}
+
+// BlockLen returns bit size of src as block of BlockEncoding.
+func BlockLen[T constraints.Integer](enc BlockEncoding, src []T) int {
+	values := make([]uint64, len(src))
+	for i, v := range src {
+		values[i] = uint64(v)
+	}
+	return blockLen(enc, values, isSigned[T]())
+}
+
+// isSigned returns T is signed integer.
+func isSigned[T constraints.Integer]() bool {
+	zero := T(0)
+	return ^zero < zero
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// order returns number of head values.
+func (enc BlockEncoding) order() int {
+	switch enc {
+	case Delta:
+		return 1
+	case DeltaOfDelta:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// residuals returns head values and residuals of values.
+// Values are uint64 bits, and signed value is sign extended.
+func (enc BlockEncoding) residuals(values []uint64, signed bool) (head, res []uint64) {
+	k := min(enc.order(), len(values))
+	head = make([]uint64, k)
+
+	switch enc {
+	case Delta:
+		copy(head, values)
+		for i := 1; i < len(values); i++ {
+			res = append(res, foldSigned(int64(values[i]-values[i-1])))
+		}
+	case DeltaOfDelta:
+		copy(head, values)
+		if k == 2 {
+			head[1] = foldSigned(int64(values[1] - values[0]))
+		}
+		for i := 2; i < len(values); i++ {
+			d1 := values[i] - values[i-1]
+			d0 := values[i-1] - values[i-2]
+			res = append(res, foldSigned(int64(d1-d0)))
+		}
+	default:
+		res = values
+	}
+
+	if signed && k > 0 {
+		head[0] = foldSigned(int64(head[0]))
+	}
+	return head, res
+}
+
+// restore restores values from head values and residuals.
+func (enc BlockEncoding) restore(head, res []uint64, signed bool) []uint64 {
+	if enc == FrameOfReference {
+		return res
+	}
+
+	values := make([]uint64, 0, len(head)+len(res))
+	if len(head) > 0 {
+		v := head[0]
+		if signed {
+			v = uint64(unfoldSigned(v))
+		}
+		values = append(values, v)
+	}
+
+	switch enc {
+	case Delta:
+		for _, r := range res {
+			values = append(values, values[len(values)-1]+uint64(unfoldSigned(r)))
+		}
+	case DeltaOfDelta:
+		if len(head) < 2 {
+			break
+		}
+		d := uint64(unfoldSigned(head[1]))
+		values = append(values, values[0]+d)
+		for _, r := range res {
+			d += uint64(unfoldSigned(r))
+			values = append(values, values[len(values)-1]+d)
+		}
+	}
+	return values
+}
+
+// frameOf returns base and bit width of residuals.
+// FrameOfReference of signed values uses signed minimum.
+func frameOf(res []uint64, signed bool) (base uint64, width int) {
+	if len(res) == 0 {
+		return 0, 0
+	}
+
+	lo, hi := res[0], res[0]
+	for _, v := range res[1:] {
+		if signed {
+			lo = uint64(min(int64(lo), int64(v)))
+			hi = uint64(max(int64(hi), int64(v)))
+		} else {
+			lo = min(lo, v)
+			hi = max(hi, v)
+		}
+	}
+	width = bits.Len64(hi - lo)
+	if width == 0 && len(res) > maxBlockRun {
+		width = 1 // residuals of width 0 are limited
+	}
+	return lo, width
+}
+
+// writeBlock writes values as block and returns write size.
+// buf is a work space of 8 bytes.
+func writeBlock(w BitWriter, enc BlockEncoding, values []uint64, signed bool, buf []byte) (int, error) {
+	if enc < FrameOfReference || enc > DeltaOfDelta {
+		return 0, fmt.Errorf("bitio: unknown block encoding %d", enc)
+	}
+
+	n, err := writeExpGolomb(w, 0, uint64(len(values)), buf)
+	if err != nil || len(values) == 0 {
+		return n, err
+	}
+
+	head, res := enc.residuals(values, signed)
+	for _, v := range head {
+		m, err := writeSizedValue(w, v, buf)
+		if err != nil {
+			return n, err
+		}
+		n += m
+	}
+	if len(res) == 0 {
+		return n, nil
+	}
+
+	frameSigned := signed && enc == FrameOfReference
+	base, width := frameOf(res, frameSigned)
+	stored := base
+	if frameSigned {
+		stored = foldSigned(int64(base))
+	}
+	m, err := writeSizedValue(w, stored, buf)
+	if err != nil {
+		return n, err
+	}
+	n += m
+	if err := writeUint64(w, 7, BigEndian, uint64(width), buf); err != nil {
+		return n, err
+	}
+	n += 7
+
+	if width > 0 {
+		diffs := make([]uint64, len(res))
+		for i, v := range res {
+			diffs[i] = v - base
+		}
+		if err := WritePackedUints(w, width, diffs); err != nil {
+			return n, err
+		}
+		n += width * len(res)
+	}
+	return n, nil
+}
+
+// readBlock reads block and returns values and read size.
+// buf is a work space of 8 bytes.
+func readBlock(r BitReader, enc BlockEncoding, signed bool, buf []byte) ([]uint64, int, error) {
+	if enc < FrameOfReference || enc > DeltaOfDelta {
+		return nil, 0, fmt.Errorf("bitio: unknown block encoding %d", enc)
+	}
+
+	n, count, err := readExpGolomb(r, 0, buf)
+	if err != nil {
+		return nil, 0, err
+	}
+	if count > math.MaxInt {
+		return nil, n, fmt.Errorf("block count %d exceeds int: %w", count, ErrValueOverflow)
+	}
+	if count == 0 {
+		return []uint64{}, n, nil
+	}
+
+	head := make([]uint64, min(enc.order(), int(count)))
+	for i := range head {
+		m, v, err := readSizedValue(r, buf)
+		if err != nil {
+			return nil, n, unexpectedEOF(err)
+		}
+		head[i] = v
+		n += m
+	}
+
+	var res []uint64
+	if size := int(count) - len(head); size > 0 {
+		m, base, err := readSizedValue(r, buf)
+		if err != nil {
+			return nil, n, unexpectedEOF(err)
+		}
+		n += m
+		if signed && enc == FrameOfReference {
+			base = uint64(unfoldSigned(base))
+		}
+
+		width, err := readUint64(r, 7, BigEndian, buf)
+		if err != nil {
+			return nil, n, unexpectedEOF(err)
+		}
+		n += 7
+		if width > 64 {
+			return nil, n, fmt.Errorf("block width %d exceeds 64 bit: %w", width, ErrValueOverflow)
+		}
+		if width == 0 && size > maxBlockRun {
+			return nil, n, fmt.Errorf("block of width 0 has %d values, exceeds %d: %w", size, maxBlockRun, ErrValueOverflow)
+		}
+
+		// allocate by chunk, count may be broken (reading of width > 0 fails)
+		res = make([]uint64, 0, min(size, readChunk))
+		for len(res) < size {
+			chunk := make([]uint64, min(size-len(res), readChunk))
+			if width > 0 {
+				if err := ReadPackedUints(r, int(width), chunk); err != nil {
+					return nil, n, unexpectedEOF(err)
+				}
+				n += int(width) * len(chunk)
+			}
+			for i := range chunk {
+				chunk[i] += base
+			}
+			res = append(res, chunk...)
+		}
+	}
+
+	return enc.restore(head, res, signed), n, nil
+}
+
+// blockLen returns bit size of values as block.
+func blockLen(enc BlockEncoding, values []uint64, signed bool) int {
+	n := expGolombLen(0, uint64(len(values)))
+	if len(values) == 0 {
+		return n
+	}
+
+	head, res := enc.residuals(values, signed)
+	for _, v := range head {
+		n += 7 + bits.Len64(v)
+	}
+	if len(res) == 0 {
+		return n
+	}
+
+	frameSigned := signed && enc == FrameOfReference
+	base, width := frameOf(res, frameSigned)
+	if frameSigned {
+		base = foldSigned(int64(base))
+	}
+	return n + 7 + bits.Len64(base) + 7 + width*len(res)
+}
+
+// writeSizedValue writes 7 bit size n and n bits of v, and returns write size.
+func writeSizedValue(w BitWriter, v uint64, buf []byte) (int, error) {
+	size := bits.Len64(v)
+	if err := writeUint64(w, 7, BigEndian, uint64(size), buf); err != nil {
+		return 0, err
+	}
+	if size > 0 {
+		if err := writeUint64(w, size, BigEndian, v, buf); err != nil {
+			return 0, err
+		}
+	}
+	return 7 + size, nil
+}
+
+// readSizedValue reads 7 bit size n and n bits value, and returns read size and value.
+func readSizedValue(r BitReader, buf []byte) (int, uint64, error) {
+	size, err := readUint64(r, 7, BigEndian, buf)
+	if err != nil {
+		return 0, 0, err
+	}
+	if size > 64 {
+		return 0, 0, fmt.Errorf("value size %d exceeds 64 bit: %w", size, ErrValueOverflow)
+	}
+	if size == 0 {
+		return 7, 0, nil
+	}
+
+	v, err := readUint64(r, int(size), BigEndian, buf)
+	if err != nil {
+		return 0, 0, unexpectedEOF(err)
+	}
+	return 7 + int(size), v, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// blockEncodings maps encoding name of slice tag to BlockEncoding.
+// (ex: `encoding:"for"`, `encoding:"delta"`, `encoding:"delta:2"`)
+var blockEncodings = map[string]BlockEncoding{
+	"for":   FrameOfReference,
+	"delta": Delta,
+}
+
+// fieldBlock is block encoding of integer slice field.
+type fieldBlock struct {
+	enc    BlockEncoding
+	signed bool
+}
+
+// compileBlock returns fieldBlock of `encoding` tag of slice field.
+// Returns nil if encoding is not block encoding.
+func compileBlock(tag reflect.StructTag, kind, elem reflect.Kind) (*fieldBlock, error) {
+	v, ok := tag.Lookup("encoding")
+	if !ok {
+		return nil, nil
+	}
+	name, param, _ := strings.Cut(v, ":")
+	enc, ok := blockEncodings[name]
+	if !ok {
+		return nil, nil
+	}
+
+	if kind != reflect.Slice || !isIntegerKind(elem) {
+		return nil, invalidTag("encoding %q needs integer slice type", v)
+	}
+	for _, key := range []string{"bit", "byte", "len"} {
+		if _, ok := tag.Lookup(key); ok {
+			return nil, invalidTag("encoding %q does not need %s", v, key)
+		}
+	}
+
+	switch {
+	case param == "":
+	case enc == Delta && param == "1":
+	case enc == Delta && param == "2":
+		enc = DeltaOfDelta
+	default:
+		return nil, invalidTag("encoding %q, parameter %q", v, param)
+	}
+
+	return &fieldBlock{enc: enc, signed: isSignedKind(elem)}, nil
+}
+
+// values returns integer slice ptr as uint64 bits.
+func (fb *fieldBlock) values(ptr reflect.Value) []uint64 {
+	values := make([]uint64, ptr.Len())
+	for i := range values {
+		values[i] = encodedValue(ptr.Index(i))
+	}
+	return values
+}
+
+func (fb *fieldBlock) read(r BitReader, ptr reflect.Value, buf []byte) (int, error) {
+	values, n, err := readBlock(r, fb.enc, fb.signed, buf)
+	if err != nil {
+		return n, err
+	}
+
+	ptr.Set(reflect.MakeSlice(ptr.Type(), len(values), len(values)))
+	for i, v := range values {
+		if err := setEncodedValue(ptr.Index(i), v); err != nil {
+			return n, &indexError{index: i, err: err}
+		}
+	}
+	return n, nil
+}
+
+func (fb *fieldBlock) write(w BitWriter, ptr reflect.Value, buf []byte) (int, error) {
+	return writeBlock(w, fb.enc, fb.values(ptr), fb.signed, buf)
+}
+
+func (fb *fieldBlock) bitLen(ptr reflect.Value) int {
+	return blockLen(fb.enc, fb.values(ptr), fb.signed)
+}
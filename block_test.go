@@ -0,0 +1,229 @@
+package bitio_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+var blockEncodings = []bitio.BlockEncoding{bitio.FrameOfReference, bitio.Delta, bitio.DeltaOfDelta}
+
+func testBlock[T uint8 | uint32 | uint64 | int8 | int64](t *testing.T, values []T) {
+	for _, enc := range blockEncodings {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		w.WriteBit(0x1, 1)
+		if err := bitio.WriteBlock(w, enc, values); err != nil {
+			t.Fatalf("WriteBlock(%v, %T %v) error: %v", enc, values, values, err)
+		}
+		w.Flush()
+
+		if n, exp := bitio.BlockLen(enc, values), (b.Len()-1)*8; n+1 <= exp || n+1 > exp+8 {
+			t.Fatalf("BlockLen(%v, %T) returns %d, written %d bytes", enc, values, n, b.Len())
+		}
+
+		r := bitio.NewBitReadBuffer(b)
+		var head byte
+		r.ReadBit(&head, 1)
+		got, err := bitio.ReadBlock[T](r, enc)
+		if err != nil {
+			t.Fatalf("ReadBlock(%v, %T %v) error: %v", enc, values, values, err)
+		}
+		if len(got) != len(values) || (len(got) > 0 && reflect.DeepEqual(got, values) == false) {
+			t.Fatalf("ReadBlock(%v) read %v, want %v", enc, got, values)
+		}
+	}
+}
+
+func TestBlock(t *testing.T) {
+	testBlock(t, []uint8{})
+	testBlock(t, []uint8{7})
+	testBlock(t, []uint8{5, 7})
+	testBlock(t, []uint8{5, 7, 6, 255, 0})
+	testBlock(t, []int8{-128, 127, 0, -1, 1})
+	testBlock(t, []uint32{3, 3, 3, 3})
+	testBlock(t, []uint64{0, math.MaxUint64, 1, math.MaxUint64 - 1})
+	testBlock(t, []int64{math.MinInt64, math.MaxInt64, 0, -1, math.MinInt64})
+
+	rnd := rand.New(rand.NewSource(1))
+	walk := make([]int64, 3000)
+	times := make([]uint64, 3000)
+	for i := range walk {
+		if i > 0 {
+			walk[i] = walk[i-1] + rnd.Int63n(201) - 100
+		}
+		times[i] = 1700000000000 + uint64(i)*1000 + uint64(rnd.Intn(3))
+	}
+	testBlock(t, walk)
+	testBlock(t, times)
+
+	// regular timestamps: delta-of-delta residuals are 4 bits
+	if n := bitio.BlockLen(bitio.DeltaOfDelta, times); n > len(times)*4+100 {
+		t.Fatalf("BlockLen(delta:2) returns %d, want <= %d", n, len(times)*4+100)
+	}
+}
+
+func TestWriteBlock(t *testing.T) {
+	tests := []struct {
+		enc    bitio.BlockEncoding
+		values []int8
+		raw    string
+	}{
+		// count=3, base=5 (3 bit), width=2, residuals 0, 2, 1
+		{bitio.FrameOfReference, []int8{-3, -1, -2}, "00100_0000011_101_0000010_00_10_01"},
+		// count=3, first=-3 (zigzag 5), base=1 (deltas 2, -1 -> 4, 1), width=2, residuals 3, 0
+		{bitio.Delta, []int8{-3, -1, -2}, "00100_0000011_101_0000001_1_0000010_11_00"},
+		// count=3, first=-3, delta=2 (zigzag 4), base=5 (delta of delta -3), width=0
+		{bitio.DeltaOfDelta, []int8{-3, -1, -2}, "00100_0000011_101_0000011_100_0000011_101_0000000"},
+		{bitio.Delta, []int8{}, "1"},
+	}
+
+	for _, tt := range tests {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		if err := bitio.WriteBlock(w, tt.enc, tt.values); err != nil {
+			t.Fatalf("WriteBlock(%v, %v) error: %v", tt.enc, tt.values, err)
+		}
+		w.Flush()
+
+		if exp := binaryToByteArray(tt.raw); reflect.DeepEqual(b.Bytes(), exp) == false {
+			t.Fatalf("WriteBlock(%v, %v) write %#v, want %#v", tt.enc, tt.values, b.Bytes(), exp)
+		}
+	}
+}
+
+func TestReadBlock_Error(t *testing.T) {
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	bitio.WriteBlock(w, bitio.FrameOfReference, []uint32{1, 1000, 70000})
+	w.Flush()
+	raw := b.Bytes()
+
+	if _, err := bitio.ReadBlock[uint16](bitio.NewBitReadBuffer(bytes.NewReader(raw)), bitio.FrameOfReference); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("ReadBlock uint16 error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	for n := 1; n < len(raw); n++ {
+		if _, err := bitio.ReadBlock[uint32](bitio.NewBitReadBuffer(bytes.NewReader(raw[:n])), bitio.FrameOfReference); errors.Is(err, bitio.ErrUnexpectedEOF) == false {
+			t.Fatalf("ReadBlock %d bytes error %v, want %v", n, err, bitio.ErrUnexpectedEOF)
+		}
+	}
+
+	// width 127
+	raw = binaryToByteArray("010_0000000_1111111")
+	if _, err := bitio.ReadBlock[uint32](bitio.NewBitReadBuffer(bytes.NewReader(raw)), bitio.FrameOfReference); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("ReadBlock width 127 error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	// broken count of width 0
+	b.Reset()
+	bitio.WriteUE(w, 1<<22)
+	w.WriteBits([]byte{0x00, 0x00}, 14) // base 0, width 0
+	w.Flush()
+	if _, err := bitio.ReadBlock[uint32](bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes())), bitio.FrameOfReference); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("ReadBlock width 0 of 2^22 values error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	if err := bitio.WriteBlock(w, bitio.BlockEncoding(3), []uint32{1}); err == nil {
+		t.Fatalf("WriteBlock unknown encoding wants error")
+	}
+}
+
+func TestBlock_LongRun(t *testing.T) {
+	// width 0 is limited, long run is written with width 1
+	values := make([]uint8, 1<<20+1)
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	if err := bitio.WriteBlock(w, bitio.FrameOfReference, values); err != nil {
+		t.Fatalf("WriteBlock error: %v", err)
+	}
+	w.Flush()
+
+	got, err := bitio.ReadBlock[uint8](bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes())), bitio.FrameOfReference)
+	if err != nil || !reflect.DeepEqual(got, values) {
+		t.Fatalf("ReadBlock of %d values error: %v", len(values), err)
+	}
+}
+
+type blockRecord struct {
+	Flag  uint8    `bit:"4"`
+	Times []uint64 `encoding:"delta:2"`
+	IDs   []uint32 `encoding:"for"`
+	Diffs []int16  `encoding:"delta"`
+}
+
+func TestBlock_Struct(t *testing.T) {
+	v := &blockRecord{
+		Flag:  0x5,
+		Times: []uint64{1000, 2000, 3000, 4001, 5001},
+		IDs:   []uint32{100, 103, 101},
+		Diffs: []int16{-5, 5, -5},
+	}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitFieldWriter(b)
+	n, err := w.WriteStruct(v)
+	if err != nil {
+		t.Fatalf("WriteStruct error: %v", err)
+	}
+	w.Flush()
+
+	if size, err := bitio.SizeOf(v); err != nil || size != n {
+		t.Fatalf("SizeOf returns %d (%v), want %d", size, err, n)
+	}
+
+	got := &blockRecord{}
+	r := bitio.NewBitFieldReader(b)
+	if m, err := r.ReadStruct(got); err != nil || m != n {
+		t.Fatalf("ReadStruct read %d bit (%v), want %d bit", m, err, n)
+	}
+	if reflect.DeepEqual(got, v) == false {
+		t.Fatalf("ReadStruct read %+v, want %+v", got, v)
+	}
+
+	codec, err := bitio.Compile[blockRecord]()
+	if err != nil {
+		t.Fatalf("Compile error: %v", err)
+	}
+	layout, _ := bitio.Layout(reflect.TypeOf(blockRecord{}))
+	if layout[1].Dynamic == false {
+		t.Fatalf("Layout of Times is not dynamic")
+	}
+	b.Reset()
+	bw := bitio.NewBitWriteBuffer(b)
+	if m, err := codec.Write(bw, v); err != nil || m != n {
+		t.Fatalf("Codec.Write write %d bit (%v), want %d bit", m, err, n)
+	}
+}
+
+func TestBlock_Struct_Error(t *testing.T) {
+	invalid := []interface{}{
+		&struct {
+			Val uint32 `encoding:"for"`
+		}{},
+		&struct {
+			Val []string `encoding:"for"`
+		}{},
+		&struct {
+			Val []uint32 `encoding:"for" len:"3"`
+		}{},
+		&struct {
+			Val []uint32 `encoding:"delta" bit:"8"`
+		}{},
+		&struct {
+			Val []uint32 `encoding:"delta:3"`
+		}{},
+		&struct {
+			Val []uint32 `encoding:"for:1"`
+		}{},
+	}
+	for _, ptr := range invalid {
+		if _, err := bitio.SizeOf(ptr); errors.Is(err, bitio.ErrInvalidTag) == false {
+			t.Fatalf("%T error %v, want %v", ptr, err, bitio.ErrInvalidTag)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package bitio
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hidez8891/bitio/crc"
+)
+
+// fieldCheck is checksum of `crc:"name" over:"First..Last"` field.
+type fieldCheck struct {
+	params  *crc.Params
+	digests sync.Pool // *crc.Digest of params (table is built once)
+	from    int       // plan.fields index of the first field of range
+	to      int       // plan.fields index of the last field of range
+}
+
+// compileChecksum parses `crc` and `over` tags of field.
+// If field has `crc` tag, fp.check, fp.bits and fp.endian are set.
+// plan and fieldIndex hold the preceding fields.
+func compileChecksum(field reflect.StructField, fp *fieldPlan, plan *structPlan, fieldIndex map[string]int) error {
+	name, ok := field.Tag.Lookup("crc")
+	if !ok {
+		if v, ok := field.Tag.Lookup("over"); ok {
+			return invalidTag("over %q needs crc", v)
+		}
+		return nil
+	}
+
+	switch fp.kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return invalidTag("crc %q needs unsigned integer type", name)
+	}
+	if v, ok := field.Tag.Lookup("encoding"); ok {
+		return invalidTag("crc %q can not have encoding %q", name, v)
+	}
+
+	params := crc.Lookup(name)
+	if params == nil {
+		return invalidTag("crc %q", name)
+	}
+	if params.Width > field.Type.Bits() {
+		return invalidTag("crc %q of %d bit exceeds %v", name, params.Width, field.Type)
+	}
+	d, err := crc.New(params)
+	if err != nil {
+		return invalidTag("crc %q: %v", name, err)
+	}
+	fp.check = &fieldCheck{params: params}
+	fp.check.digests.New = func() interface{} {
+		d, _ := crc.New(params)
+		return d
+	}
+	fp.check.digests.Put(d)
+	fp.bits = params.Width
+
+	// size is optional
+	bits := -1
+	if v, ok := field.Tag.Lookup("byte"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			bits = 8 * n
+		}
+	} else if v, ok := field.Tag.Lookup("bit"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			bits = n
+		}
+	} else {
+		bits = fp.bits
+	}
+	if bits != fp.bits {
+		return invalidTag("crc %q needs size %d bit", name, fp.bits)
+	}
+
+	// CRC is stored in order of register (reflected register is little-endian)
+	fp.endian = BigEndian
+	if params.RefOut {
+		fp.endian = LittleEndian
+	}
+	if v, ok := field.Tag.Lookup("endian"); ok {
+		switch v {
+		case "big":
+			fp.endian = BigEndian
+		case "little":
+			fp.endian = LittleEndian
+		default:
+			return invalidTag("endian %q", v)
+		}
+	}
+
+	// range of preceding fields (default: all)
+	if len(plan.fields) == 0 {
+		return invalidTag("crc %q needs preceding fields", name)
+	}
+	fp.check.from, fp.check.to = 0, len(plan.fields)-1
+	if v, ok := field.Tag.Lookup("over"); ok {
+		first, last, found := strings.Cut(v, "..")
+		if !found {
+			last = first
+		}
+		from, ok1 := fieldIndex[first]
+		to, ok2 := fieldIndex[last]
+		if !ok1 || !ok2 || from > to {
+			return invalidTag("over %q needs range of preceding fields", v)
+		}
+		fp.check.from, fp.check.to = from, to
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// checksumState collects bits of checksum ranges while reading/writing struct.
+// It implements BitReader and BitWriter, which pass through bits to r/w.
+type checksumState struct {
+	plan   *structPlan
+	r      BitReader
+	w      BitWriter
+	bits   []BitBuffer  // bits of range of plan.checks
+	active []*BitBuffer // ranges of current field
+}
+
+func newChecksumState(plan *structPlan) *checksumState {
+	return &checksumState{
+		plan: plan,
+		bits: make([]BitBuffer, len(plan.checks)),
+	}
+}
+
+// enter sets ranges which have field i.
+// Returns false if field i is out of all ranges.
+func (s *checksumState) enter(i int) bool {
+	s.active = s.active[:0]
+	for k, j := range s.plan.checks {
+		c := s.plan.fields[j].check
+		if c.from <= i && i <= c.to {
+			s.active = append(s.active, &s.bits[k])
+		}
+	}
+	return len(s.active) > 0
+}
+
+// reader returns BitReader of field i.
+func (s *checksumState) reader(r BitReader, i int) BitReader {
+	if !s.enter(i) {
+		return r
+	}
+	s.r = r
+	return s
+}
+
+// writer returns BitWriter of field i.
+func (s *checksumState) writer(w BitWriter, i int) BitWriter {
+	if !s.enter(i) {
+		return w
+	}
+	s.w = w
+	return s
+}
+
+// sum returns checksum of crc field i.
+func (s *checksumState) sum(i int) uint64 {
+	for k, j := range s.plan.checks {
+		if j == i {
+			return s.plan.fields[i].check.checksumOf(&s.bits[k])
+		}
+	}
+	return 0
+}
+
+// verify checks value of crc field i.
+func (s *checksumState) verify(i int, ptr reflect.Value) error {
+	fp := &s.plan.fields[i]
+	if v := s.sum(i); v != ptr.Uint() {
+		return &ChecksumError{Name: fp.check.params.Name, Read: ptr.Uint(), Computed: v}
+	}
+	return nil
+}
+
+// write writes checksum of crc field i, and sets it to ptr. (value of struct is updated)
+func (s *checksumState) write(w BitWriter, i int, ptr reflect.Value, buf []byte) (int, error) {
+	fp := &s.plan.fields[i]
+	v := s.sum(i)
+	if ptr.CanSet() {
+		ptr.SetUint(v)
+	}
+	if err := writeUint64(w, fp.bits, fp.endian, v, buf); err != nil {
+		return 0, err
+	}
+	return fp.bits, nil
+}
+
+func (s *checksumState) ReadBit(p *byte, bitSize int) (int, error) {
+	n, err := s.r.ReadBit(p, bitSize)
+	if n == bitSize {
+		for _, c := range s.active {
+			c.WriteBit(*p, n)
+		}
+	}
+	return n, err
+}
+
+func (s *checksumState) ReadBits(p []byte, bitSize int) (int, error) {
+	n, err := s.r.ReadBits(p, bitSize)
+	if n == bitSize {
+		for _, c := range s.active {
+			c.WriteBits(p, n)
+		}
+	}
+	return n, err
+}
+
+func (s *checksumState) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	for _, c := range s.active {
+		c.WriteBits(p[:n], 8*n)
+	}
+	return n, err
+}
+
+func (s *checksumState) WriteBit(p byte, bitSize int) (int, error) {
+	n, err := s.w.WriteBit(p, bitSize)
+	if n == bitSize {
+		for _, c := range s.active {
+			c.WriteBit(p, n)
+		}
+	}
+	return n, err
+}
+
+func (s *checksumState) WriteBits(p []byte, bitSize int) (int, error) {
+	n, err := s.w.WriteBits(p, bitSize)
+	if n == bitSize {
+		for _, c := range s.active {
+			c.WriteBits(p, n)
+		}
+	}
+	return n, err
+}
+
+func (s *checksumState) Write(p []byte) (int, error) {
+	n, err := s.w.Write(p)
+	for _, c := range s.active {
+		c.WriteBits(p[:n], 8*n)
+	}
+	return n, err
+}
+
+func (s *checksumState) Flush() error {
+	return s.w.Flush()
+}
+
+// checksumOf returns CRC of bits of b.
+// Whole bytes are written as bytes (RefIn is applied), and the rest bits are written in stream order.
+func (c *fieldCheck) checksumOf(b *BitBuffer) uint64 {
+	d := c.digests.Get().(*crc.Digest)
+	defer c.digests.Put(d)
+	d.Reset()
+
+	n := b.Len()
+	d.Write(b.Bytes()[:n/8])
+	if r := n % 8; r > 0 {
+		d.WriteBit(b.Bytes()[n/8]>>uint(8-r), r)
+	}
+	return d.Sum64()
+}
@@ -0,0 +1,240 @@
+package bitio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/crc"
+)
+
+// patSection is program association section of MPEG-2 TS with one program.
+type patSection struct {
+	TableID           uint8  `bit:"8"`
+	SectionSyntax     bool   `bit:"1"`
+	Zero              bool   `bit:"1"`
+	Reserved1         uint8  `bit:"2"`
+	SectionLength     uint16 `bit:"12" endian:"big"`
+	TransportStreamID uint16 `bit:"16" endian:"big"`
+	Reserved2         uint8  `bit:"2"`
+	Version           uint8  `bit:"5"`
+	CurrentNext       bool   `bit:"1"`
+	SectionNumber     uint8  `bit:"8"`
+	LastSectionNumber uint8  `bit:"8"`
+	ProgramNumber     uint16 `bit:"16" endian:"big"`
+	Reserved3         uint8  `bit:"3"`
+	PID               uint16 `bit:"13" endian:"big"`
+	CRC               uint32 `crc:"crc32-mpeg2" over:"TableID..PID"`
+}
+
+// modbusRequest is read holding registers request of Modbus RTU.
+type modbusRequest struct {
+	Address  uint8  `bit:"8"`
+	Function uint8  `bit:"8"`
+	Start    uint16 `bit:"16" endian:"big"`
+	Count    uint16 `bit:"16" endian:"big"`
+	CRC      uint16 `crc:"crc-16/modbus"` // little-endian of all preceding fields
+}
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		v   interface{}
+		raw string
+	}{
+		{
+			&patSection{
+				SectionSyntax: true, Reserved1: 3, SectionLength: 13, TransportStreamID: 1,
+				Reserved2: 3, CurrentNext: true, ProgramNumber: 1, Reserved3: 7, PID: 0x1000,
+				CRC: 0x2ab104b2,
+			},
+			"00b00d0001c100000001f000" + "2ab104b2",
+		},
+		{
+			&modbusRequest{Address: 1, Function: 3, Count: 10, CRC: 0xcdc5},
+			"01030000000a" + "c5cd",
+		},
+	}
+
+	for _, tt := range tests {
+		raw, _ := hex.DecodeString(tt.raw)
+
+		// CRC is computed by writer
+		v := reflect.New(reflect.TypeOf(tt.v).Elem())
+		v.Elem().Set(reflect.ValueOf(tt.v).Elem())
+		v.Elem().FieldByName("CRC").SetUint(0)
+
+		b := new(bytes.Buffer)
+		w := bitio.NewBitFieldWriter(b)
+		if n, err := w.WriteStruct(v.Interface()); err != nil || n != 8*len(raw) {
+			t.Fatalf("WriteStruct %T write %d bit (%v), want %d bit", tt.v, n, err, 8*len(raw))
+		}
+		w.Flush()
+		if !bytes.Equal(b.Bytes(), raw) {
+			t.Fatalf("WriteStruct %T write %x, want %x", tt.v, b.Bytes(), raw)
+		}
+		if !reflect.DeepEqual(v.Interface(), tt.v) {
+			t.Fatalf("WriteStruct %T sets %+v, want %+v", tt.v, v.Interface(), tt.v)
+		}
+
+		// CRC is verified by reader
+		got := reflect.New(reflect.TypeOf(tt.v).Elem())
+		r := bitio.NewBitFieldReader(bytes.NewReader(raw))
+		if _, err := r.ReadStruct(got.Interface()); err != nil {
+			t.Fatalf("ReadStruct %T error: %v", tt.v, err)
+		}
+		if !reflect.DeepEqual(got.Interface(), tt.v) {
+			t.Fatalf("ReadStruct %T read %+v, want %+v", tt.v, got.Interface(), tt.v)
+		}
+
+		// corrupted data
+		raw[2] ^= 0x01
+		r = bitio.NewBitFieldReader(bytes.NewReader(raw))
+		var ce *bitio.ChecksumError
+		if _, err := r.ReadStruct(got.Interface()); !errors.As(err, &ce) {
+			t.Fatalf("ReadStruct %T of corrupted data error %v, want ChecksumError", tt.v, err)
+		}
+		if ce.Read == ce.Computed {
+			t.Fatalf("ChecksumError %v has same values", ce)
+		}
+	}
+}
+
+// canFrame is base frame of CAN without bit stuffing.
+type canFrame struct {
+	SOF  uint8   `bit:"1"`
+	ID   uint16  `bit:"11" endian:"big"`
+	RTR  bool    `bit:"1"`
+	IDE  bool    `bit:"1"`
+	R0   bool    `bit:"1"`
+	DLC  uint8   `bit:"4"`
+	Data []uint8 `bit:"8" len:"DLC"`
+	CRC  uint16  `crc:"CRC-15/CAN" bit:"15" over:"SOF..Data"`
+	Del  bool    `bit:"1"`
+}
+
+// outerFrame has checksum of nested struct and checksum.
+type outerFrame struct {
+	Len   uint8 `bit:"8"`
+	Frame canFrame
+	Sum   uint8 `crc:"crc-8/smbus" over:"Frame"`
+}
+
+func TestChecksum_Bits(t *testing.T) {
+	frame := canFrame{ID: 0x123, DLC: 2, Data: []uint8{0xab, 0xcd}, Del: true}
+
+	// bits of SOF..Data are not byte aligned
+	d, _ := crc.New(crc.CRC15CAN)
+	d.WriteBit(0, 1)
+	d.WriteBits([]byte{0x01, 0x23}, 11)
+	d.WriteBit(0, 3)
+	d.WriteBit(2, 4)
+	d.Write([]byte{0xab, 0xcd})
+	exp := uint16(d.Sum64())
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitFieldWriter(b)
+	if n, err := w.WriteStruct(&frame); err != nil || n != 1+11+3+4+16+15+1 {
+		t.Fatalf("WriteStruct write %d bit (%v)", n, err)
+	}
+	w.Flush()
+	if frame.CRC != exp {
+		t.Fatalf("WriteStruct sets CRC %#x, want %#x", frame.CRC, exp)
+	}
+
+	got := canFrame{}
+	if _, err := bitio.NewBitFieldReader(bytes.NewReader(b.Bytes())).ReadStruct(&got); err != nil {
+		t.Fatalf("ReadStruct error: %v", err)
+	}
+	if !reflect.DeepEqual(got, frame) {
+		t.Fatalf("ReadStruct read %+v, want %+v", got, frame)
+	}
+
+	// codec of struct with nested checksum
+	codec, err := bitio.Compile[outerFrame]()
+	if err != nil {
+		t.Fatalf("Compile error: %v", err)
+	}
+	outer := outerFrame{Len: 5, Frame: frame}
+	b.Reset()
+	bw := bitio.NewBitWriteBuffer(b)
+	if _, err := codec.Write(bw, &outer); err != nil {
+		t.Fatalf("Codec.Write error: %v", err)
+	}
+	bw.Flush()
+
+	// Sum is CRC of Frame (51 bits) in stream order
+	sum, _ := crc.New(crc.CRC8SMBUS)
+	sum.Write(b.Bytes()[1:7])
+	sum.WriteBit(b.Bytes()[7]>>5, 3)
+	if uint64(outer.Sum) != sum.Sum64() {
+		t.Fatalf("Codec.Write sets Sum %#x, want %#x", outer.Sum, sum.Sum64())
+	}
+
+	gotOuter := outerFrame{}
+	if _, err := codec.Read(bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes())), &gotOuter); err != nil {
+		t.Fatalf("Codec.Read error: %v", err)
+	}
+	if !reflect.DeepEqual(gotOuter, outer) {
+		t.Fatalf("Codec.Read read %+v, want %+v", gotOuter, outer)
+	}
+}
+
+func TestChecksum_Error(t *testing.T) {
+	invalid := []interface{}{
+		// unknown CRC
+		&struct {
+			A uint8  `bit:"8"`
+			C uint32 `crc:"crc-33"`
+		}{},
+		// signed type
+		&struct {
+			A uint8 `bit:"8"`
+			C int32 `crc:"crc32-mpeg2"`
+		}{},
+		// type is smaller than CRC
+		&struct {
+			A uint8  `bit:"8"`
+			C uint16 `crc:"crc32-mpeg2"`
+		}{},
+		// size is different from CRC
+		&struct {
+			A uint8  `bit:"8"`
+			C uint32 `crc:"crc32-mpeg2" bit:"16"`
+		}{},
+		// no preceding fields
+		&struct {
+			C uint32 `crc:"crc32-mpeg2"`
+		}{},
+		// range of following field
+		&struct {
+			A uint8  `bit:"8"`
+			C uint32 `crc:"crc32-mpeg2" over:"A..B"`
+			B uint8  `bit:"8"`
+		}{},
+		// reversed range
+		&struct {
+			A uint8  `bit:"8"`
+			B uint8  `bit:"8"`
+			C uint32 `crc:"crc32-mpeg2" over:"B..A"`
+		}{},
+		// range without crc
+		&struct {
+			A uint8 `bit:"8" over:"A"`
+		}{},
+	}
+	for _, ptr := range invalid {
+		if _, err := bitio.SizeOf(ptr); !errors.Is(err, bitio.ErrInvalidTag) {
+			t.Fatalf("%T error %v, want %v", ptr, err, bitio.ErrInvalidTag)
+		}
+	}
+
+	// EOF in checksum
+	raw, _ := hex.DecodeString("01030000000ac5")
+	if _, err := bitio.NewBitFieldReader(bytes.NewReader(raw)).ReadStruct(&modbusRequest{}); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("ReadStruct of short data error %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
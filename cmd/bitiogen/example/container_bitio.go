@@ -0,0 +1,348 @@
+// Code generated by bitiogen; DO NOT EDIT.
+
+package example
+
+import (
+	"fmt"
+
+	"github.com/hidez8891/bitio"
+)
+
+// UnmarshalBits reads bit-field data to v and returns read size.
+// If error happen, err will be set.
+func (v *Container) UnmarshalBits(r bitio.BitReader) (nBit int, err error) {
+	var u uint64
+
+	// Sign
+	{
+		n := 3
+		if cap(v.Sign) < n {
+			v.Sign = make([]byte, n)
+		} else {
+			v.Sign = v.Sign[:n]
+		}
+		for i := range v.Sign {
+			if err = bitio.Read(r, 4, bitio.LittleEndian, &u); err != nil {
+				return
+			}
+			v.Sign[i] = byte(u)
+		}
+		nBit += 4 * n
+	}
+
+	// Size
+	if err = bitio.Read(r, 4, bitio.LittleEndian, &u); err != nil {
+		return
+	}
+	v.Size = int(u)
+	nBit += 4
+
+	// Name
+	{
+		b := make([]byte, 8)
+		if err = bitio.ReadSlice(r, 8, bitio.LittleEndian, b); err != nil {
+			return
+		}
+		v.Name = string(b)
+	}
+	nBit += 64
+
+	// Data
+	{
+		n := int(v.Size)
+		if n < 1 {
+			err = fmt.Errorf("slice type needs positive length, set %d length", n)
+			return
+		}
+		if cap(v.Data) < n {
+			v.Data = make([]byte, n)
+		} else {
+			v.Data = v.Data[:n]
+		}
+		for i := range v.Data {
+			if err = bitio.Read(r, 8, bitio.LittleEndian, &u); err != nil {
+				return
+			}
+			v.Data[i] = byte(u)
+		}
+		nBit += 8 * n
+	}
+
+	// CRC
+	if err = bitio.Read(r, 32, bitio.BigEndian, &u); err != nil {
+		return
+	}
+	v.CRC = uint(u)
+	nBit += 32
+
+	return
+}
+
+// MarshalBits writes bit-field data of v and returns write size.
+// If error happen, err will be set.
+func (v *Container) MarshalBits(w bitio.BitWriter) (nBit int, err error) {
+	// Sign
+	{
+		n := 3
+		for i := 0; i < n; i++ {
+			var e byte
+			if i < len(v.Sign) {
+				e = v.Sign[i]
+			}
+			if err = bitio.Write(w, 4, bitio.LittleEndian, uint64(e)); err != nil {
+				return
+			}
+		}
+		nBit += 4 * n
+	}
+
+	// Size
+	v.Size = int(len(v.Data))
+	if err = bitio.Write(w, 4, bitio.LittleEndian, uint64(len(v.Data))); err != nil {
+		return
+	}
+	nBit += 4
+
+	// Name
+	{
+		b := make([]byte, 8)
+		copy(b, v.Name)
+		if err = bitio.WriteSlice(w, 8, bitio.LittleEndian, b); err != nil {
+			return
+		}
+	}
+	nBit += 64
+
+	// Data
+	{
+		n := len(v.Data)
+		if n < 1 {
+			err = fmt.Errorf("slice type needs positive length, set %d length", n)
+			return
+		}
+		for _, e := range v.Data {
+			if err = bitio.Write(w, 8, bitio.LittleEndian, uint64(e)); err != nil {
+				return
+			}
+		}
+		nBit += 8 * n
+	}
+
+	// CRC
+	if err = bitio.Write(w, 32, bitio.BigEndian, uint64(v.CRC)); err != nil {
+		return
+	}
+	nBit += 32
+
+	return
+}
+
+// UnmarshalBits reads bit-field data to v and returns read size.
+// If error happen, err will be set.
+func (v *Header) UnmarshalBits(r bitio.BitReader) (nBit int, err error) {
+	var u uint64
+
+	// Version
+	if err = bitio.Read(r, 3, bitio.LittleEndian, &u); err != nil {
+		return
+	}
+	v.Version = uint8(u)
+	nBit += 3
+
+	// Flag
+	if err = bitio.Read(r, 1, bitio.LittleEndian, &u); err != nil {
+		return
+	}
+	v.Flag = u != 0
+	nBit += 1
+
+	// Kind
+	if err = bitio.Read(r, 4, bitio.LittleEndian, &u); err != nil {
+		return
+	}
+	v.Kind = Kind(u)
+	nBit += 4
+
+	// Level
+	if err = bitio.Read(r, 12, bitio.BigEndian, &u); err != nil {
+		return
+	}
+	v.Level = int16(u)
+	nBit += 12
+
+	// Offset
+	if err = bitio.Read(r, 64, bitio.LittleEndian, &u); err != nil {
+		return
+	}
+	v.Offset = int64(u)
+	nBit += 64
+
+	// Count
+	if err = bitio.Read(r, 2, bitio.LittleEndian, &u); err != nil {
+		return
+	}
+	v.Count = uint16(u)
+	nBit += 2
+
+	// Names
+	{
+		n := int(v.Count)
+		if n < 1 {
+			err = fmt.Errorf("slice type needs positive length, set %d length", n)
+			return
+		}
+		if cap(v.Names) < n {
+			v.Names = make([]string, n)
+		} else {
+			v.Names = v.Names[:n]
+		}
+		for i := range v.Names {
+			{
+				b := make([]byte, 2)
+				if err = bitio.ReadSlice(r, 8, bitio.LittleEndian, b); err != nil {
+					return
+				}
+				v.Names[i] = string(b)
+			}
+		}
+		nBit += 16 * n
+	}
+
+	// Codes
+	{
+		n := 4
+		if cap(v.Codes) < n {
+			v.Codes = make([]int8, n)
+		} else {
+			v.Codes = v.Codes[:n]
+		}
+		for i := range v.Codes {
+			if err = bitio.Read(r, 5, bitio.BigEndian, &u); err != nil {
+				return
+			}
+			v.Codes[i] = int8(u)
+		}
+		nBit += 5 * n
+	}
+
+	// Marks
+	{
+		n := 3
+		if cap(v.Marks) < n {
+			v.Marks = make([]bool, n)
+		} else {
+			v.Marks = v.Marks[:n]
+		}
+		for i := range v.Marks {
+			if err = bitio.Read(r, 1, bitio.LittleEndian, &u); err != nil {
+				return
+			}
+			v.Marks[i] = u != 0
+		}
+		nBit += 1 * n
+	}
+
+	return
+}
+
+// MarshalBits writes bit-field data of v and returns write size.
+// If error happen, err will be set.
+func (v *Header) MarshalBits(w bitio.BitWriter) (nBit int, err error) {
+	var u uint64
+
+	// Version
+	if err = bitio.Write(w, 3, bitio.LittleEndian, uint64(v.Version)); err != nil {
+		return
+	}
+	nBit += 3
+
+	// Flag
+	u = 0
+	if v.Flag {
+		u = 1
+	}
+	if err = bitio.Write(w, 1, bitio.LittleEndian, u); err != nil {
+		return
+	}
+	nBit += 1
+
+	// Kind
+	if err = bitio.Write(w, 4, bitio.LittleEndian, uint64(v.Kind)); err != nil {
+		return
+	}
+	nBit += 4
+
+	// Level
+	if err = bitio.Write(w, 12, bitio.BigEndian, int64(v.Level)); err != nil {
+		return
+	}
+	nBit += 12
+
+	// Offset
+	if err = bitio.Write(w, 64, bitio.LittleEndian, int64(v.Offset)); err != nil {
+		return
+	}
+	nBit += 64
+
+	// Count
+	v.Count = uint16(len(v.Names))
+	if err = bitio.Write(w, 2, bitio.LittleEndian, uint64(len(v.Names))); err != nil {
+		return
+	}
+	nBit += 2
+
+	// Names
+	{
+		n := len(v.Names)
+		if n < 1 {
+			err = fmt.Errorf("slice type needs positive length, set %d length", n)
+			return
+		}
+		for _, e := range v.Names {
+			{
+				b := make([]byte, 2)
+				copy(b, e)
+				if err = bitio.WriteSlice(w, 8, bitio.LittleEndian, b); err != nil {
+					return
+				}
+			}
+		}
+		nBit += 16 * n
+	}
+
+	// Codes
+	{
+		n := 4
+		for i := 0; i < n; i++ {
+			var e int8
+			if i < len(v.Codes) {
+				e = v.Codes[i]
+			}
+			if err = bitio.Write(w, 5, bitio.BigEndian, int64(e)); err != nil {
+				return
+			}
+		}
+		nBit += 5 * n
+	}
+
+	// Marks
+	{
+		n := 3
+		for i := 0; i < n; i++ {
+			var e bool
+			if i < len(v.Marks) {
+				e = v.Marks[i]
+			}
+			u = 0
+			if e {
+				u = 1
+			}
+			if err = bitio.Write(w, 1, bitio.LittleEndian, u); err != nil {
+				return
+			}
+		}
+		nBit += 1 * n
+	}
+
+	return
+}
@@ -0,0 +1,135 @@
+// Code generated by bitiogen; DO NOT EDIT.
+
+package example
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+func TestBitiogen_Container(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		src := &Container{}
+		src.Sign = make([]byte, 3)
+		for j := range src.Sign {
+			src.Sign[j] = byte(rnd.Uint64() & 0xf)
+		}
+		{
+			b := make([]byte, 8)
+			rnd.Read(b)
+			src.Name = string(b)
+		}
+		src.Data = make([]byte, 1+rnd.Intn(8))
+		for j := range src.Data {
+			src.Data[j] = byte(rnd.Uint64() & 0xff)
+		}
+		src.CRC = uint(rnd.Uint64() & 0xffffffff)
+
+		b1 := new(bytes.Buffer)
+		w1 := bitio.NewBitWriteBuffer(b1)
+		n1, err := src.MarshalBits(w1)
+		if err != nil {
+			t.Fatalf("MarshalBits error: %v", err)
+		}
+		w1.Flush()
+
+		b2 := new(bytes.Buffer)
+		w2 := bitio.NewBitFieldWriter(b2)
+		n2, err := w2.WriteStruct(src)
+		if err != nil {
+			t.Fatalf("WriteStruct error: %v", err)
+		}
+		w2.Flush()
+
+		if n1 != n2 || !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+			t.Fatalf("MarshalBits write %x (%d bit), WriteStruct write %x (%d bit)", b1.Bytes(), n1, b2.Bytes(), n2)
+		}
+
+		dst1 := &Container{}
+		n1, err = dst1.UnmarshalBits(bitio.NewBitReadBuffer(bytes.NewReader(b1.Bytes())))
+		if err != nil {
+			t.Fatalf("UnmarshalBits error: %v", err)
+		}
+
+		dst2 := &Container{}
+		n2, err = bitio.NewBitFieldReader(bytes.NewReader(b1.Bytes())).ReadStruct(dst2)
+		if err != nil {
+			t.Fatalf("ReadStruct error: %v", err)
+		}
+
+		if n1 != n2 || !reflect.DeepEqual(dst1, dst2) {
+			t.Fatalf("UnmarshalBits read %#v (%d bit), ReadStruct read %#v (%d bit)", dst1, n1, dst2, n2)
+		}
+	}
+}
+
+func TestBitiogen_Header(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		src := &Header{}
+		src.Version = uint8(rnd.Uint64() & 0x7)
+		src.Flag = rnd.Intn(2) == 1
+		src.Kind = Kind(rnd.Uint64() & 0xf)
+		src.Level = int16(rnd.Uint64() & 0xfff)
+		src.Offset = int64(rnd.Uint64() & 0xffffffffffffffff)
+		src.Names = make([]string, 1+rnd.Intn(3))
+		for j := range src.Names {
+			{
+				b := make([]byte, 2)
+				rnd.Read(b)
+				src.Names[j] = string(b)
+			}
+		}
+		src.Codes = make([]int8, 4)
+		for j := range src.Codes {
+			src.Codes[j] = int8(rnd.Uint64() & 0x1f)
+		}
+		src.Marks = make([]bool, 3)
+		for j := range src.Marks {
+			src.Marks[j] = rnd.Intn(2) == 1
+		}
+
+		b1 := new(bytes.Buffer)
+		w1 := bitio.NewBitWriteBuffer(b1)
+		n1, err := src.MarshalBits(w1)
+		if err != nil {
+			t.Fatalf("MarshalBits error: %v", err)
+		}
+		w1.Flush()
+
+		b2 := new(bytes.Buffer)
+		w2 := bitio.NewBitFieldWriter(b2)
+		n2, err := w2.WriteStruct(src)
+		if err != nil {
+			t.Fatalf("WriteStruct error: %v", err)
+		}
+		w2.Flush()
+
+		if n1 != n2 || !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+			t.Fatalf("MarshalBits write %x (%d bit), WriteStruct write %x (%d bit)", b1.Bytes(), n1, b2.Bytes(), n2)
+		}
+
+		dst1 := &Header{}
+		n1, err = dst1.UnmarshalBits(bitio.NewBitReadBuffer(bytes.NewReader(b1.Bytes())))
+		if err != nil {
+			t.Fatalf("UnmarshalBits error: %v", err)
+		}
+
+		dst2 := &Header{}
+		n2, err = bitio.NewBitFieldReader(bytes.NewReader(b1.Bytes())).ReadStruct(dst2)
+		if err != nil {
+			t.Fatalf("ReadStruct error: %v", err)
+		}
+
+		if n1 != n2 || !reflect.DeepEqual(dst1, dst2) {
+			t.Fatalf("UnmarshalBits read %#v (%d bit), ReadStruct read %#v (%d bit)", dst1, n1, dst2, n2)
+		}
+	}
+}
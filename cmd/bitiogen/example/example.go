@@ -0,0 +1,31 @@
+// Package example is a sample of bitiogen generated code.
+package example
+
+//go:generate go run github.com/hidez8891/bitio/cmd/bitiogen -type Container,Header -test
+
+// Kind is a sample of named integer type.
+type Kind uint8
+
+// Container is a sample of bit-field struct. (same as README)
+type Container struct {
+	Sign []byte `bit:"4" len:"3"`       // 4bit x 3
+	Size int    `bit:"4"`               // 4bit
+	Name string `byte:"8"`              // 8byte (8chars)
+	Data []byte `byte:"1" len:"Size"`   // 1byte x Size
+	CRC  uint   `bit:"32" endian:"big"` // 32bit (4byte), big endian
+}
+
+// Header is a sample of bit-field struct with various types.
+type Header struct {
+	Version uint8    `bit:"3"`
+	Flag    bool     `bit:"1"`
+	Kind    Kind     `bit:"4"`
+	Level   int16    `bit:"12" endian:"big"`
+	Offset  int64    `bit:"64"`
+	Count   uint16   `bit:"2"`
+	Names   []string `byte:"2" len:"Count"`
+	Codes   []int8   `bit:"5" len:"4" endian:"big"`
+	Marks   []bool   `bit:"1" len:"3"`
+
+	reserved int
+}
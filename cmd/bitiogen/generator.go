@@ -0,0 +1,605 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const bitioPath = "github.com/hidez8891/bitio"
+
+// kind is a bit-field value kind.
+type kind int
+
+const (
+	kindBool kind = iota
+	kindInt
+	kindUint
+	kindString
+)
+
+// builtinKinds maps predeclared type names to kind.
+var builtinKinds = map[string]kind{
+	"bool":   kindBool,
+	"int":    kindInt,
+	"int8":   kindInt,
+	"int16":  kindInt,
+	"int32":  kindInt,
+	"int64":  kindInt,
+	"rune":   kindInt,
+	"uint":   kindUint,
+	"uint8":  kindUint,
+	"uint16": kindUint,
+	"uint32": kindUint,
+	"uint64": kindUint,
+	"byte":   kindUint,
+	"string": kindString,
+}
+
+// field store bit-field configration of struct field.
+type field struct {
+	name   string
+	typ    string // value type name (element type for slice)
+	kind   kind
+	slice  bool
+	bits   int
+	len    int    // fixed length (slice only)
+	lenRef string // field name of length's variable
+	lenOf  string // field name of slice, which length is stored to this field
+	endian string
+}
+
+// Generator holds the parsed package and generated code.
+type Generator struct {
+	pkgName string
+	types   map[string]ast.Expr // type name -> type expression
+	buf     bytes.Buffer
+}
+
+// ParseDir parses non-test Go files in directory.
+func (g *Generator) ParseDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		file, err := parser.ParseFile(fset, name, src, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		if err := g.addFile(file); err != nil {
+			return err
+		}
+	}
+
+	if g.pkgName == "" {
+		return fmt.Errorf("no Go files in %s", dir)
+	}
+	return nil
+}
+
+// addFile registers type declarations of file.
+func (g *Generator) addFile(file *ast.File) error {
+	if g.pkgName == "" {
+		g.pkgName = file.Name.Name
+	} else if g.pkgName != file.Name.Name {
+		return fmt.Errorf("multiple packages %s and %s", g.pkgName, file.Name.Name)
+	}
+
+	if g.types == nil {
+		g.types = make(map[string]ast.Expr)
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			g.types[spec.Name.Name] = spec.Type
+		}
+		return true
+	})
+	return nil
+}
+
+// Generate returns formatted source of MarshalBits/UnmarshalBits methods.
+func (g *Generator) Generate(typeNames []string) ([]byte, error) {
+	structs := make([][]*field, len(typeNames))
+	needFmt := false
+	for i, name := range typeNames {
+		fields, err := g.parseStruct(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			needFmt = needFmt || f.lenRef != ""
+		}
+		structs[i] = fields
+	}
+
+	g.buf.Reset()
+	g.printf("// Code generated by bitiogen; DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", g.pkgName)
+	g.printf("import (\n")
+	if needFmt {
+		g.printf("\"fmt\"\n\n")
+	}
+	g.printf("%q\n", bitioPath)
+	g.printf(")\n")
+
+	for i, name := range typeNames {
+		g.generateUnmarshal(name, structs[i])
+		g.generateMarshal(name, structs[i])
+	}
+
+	return g.format()
+}
+
+// GenerateTest returns formatted source of test, which cross-checks
+// generated methods against bitio.BitFieldWriter/bitio.BitFieldReader.
+func (g *Generator) GenerateTest(typeNames []string) ([]byte, error) {
+	structs := make([][]*field, len(typeNames))
+	for i, name := range typeNames {
+		fields, err := g.parseStruct(name)
+		if err != nil {
+			return nil, err
+		}
+		structs[i] = fields
+	}
+
+	g.buf.Reset()
+	g.printf("// Code generated by bitiogen; DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", g.pkgName)
+	g.printf("import (\n")
+	g.printf("\"bytes\"\n\"math/rand\"\n\"reflect\"\n\"testing\"\n\n")
+	g.printf("%q\n", bitioPath)
+	g.printf(")\n")
+
+	for i, name := range typeNames {
+		g.generateTest(name, structs[i])
+	}
+
+	return g.format()
+}
+
+func (g *Generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *Generator) format() ([]byte, error) {
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("internal error: invalid generated code: %v", err)
+	}
+	return src, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// parseStruct returns bit-field configrations of struct type name.
+func (g *Generator) parseStruct(name string) ([]*field, error) {
+	expr, ok := g.types[name]
+	if !ok {
+		return nil, fmt.Errorf("type %s is not found", name)
+	}
+	st, ok := expr.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not struct", name)
+	}
+
+	var fields []*field
+	index := make(map[string]*field)
+	for _, astField := range st.Fields.List {
+		if len(astField.Names) == 0 {
+			return nil, fmt.Errorf("%s has unsupport embedded field", name)
+		}
+
+		var tag reflect.StructTag
+		if astField.Tag != nil {
+			s, err := strconv.Unquote(astField.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(s)
+		}
+
+		for _, ident := range astField.Names {
+			// skip unexport field
+			if !ident.IsExported() {
+				continue
+			}
+
+			f, err := g.parseField(ident.Name, astField.Type, tag, index)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%v", name, err)
+			}
+			fields = append(fields, f)
+			index[f.name] = f
+		}
+	}
+
+	return fields, nil
+}
+
+// parseField parses tags of struct field.
+// index holds the preceding fields.
+func (g *Generator) parseField(name string, typ ast.Expr, tag reflect.StructTag, index map[string]*field) (*field, error) {
+	var err error
+
+	f := &field{
+		name:   name,
+		endian: "bitio.LittleEndian",
+	}
+
+	// bit-field type
+	if at, ok := typ.(*ast.ArrayType); ok && at.Len == nil {
+		f.slice = true
+		typ = at.Elt
+	}
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("%s has unsupport bit-field type", name)
+	}
+	f.typ = ident.Name
+	if f.kind, err = g.resolveKind(ident.Name); err != nil {
+		return nil, fmt.Errorf("%s has %v", name, err)
+	}
+
+	// bit-field encoding
+	if v, ok := tag.Lookup("encoding"); ok {
+		return nil, fmt.Errorf("%s has unsupport encoding %q", name, v)
+	}
+	if v, ok := tag.Lookup("crc"); ok {
+		return nil, fmt.Errorf("%s has unsupport crc %q", name, v)
+	}
+
+	// bit-field size
+	if v, ok := tag.Lookup("byte"); ok {
+		if f.bits, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("%s has invalid size %q byte(s)", name, v)
+		}
+		f.bits *= 8
+	} else if v, ok := tag.Lookup("bit"); ok {
+		if f.bits, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("%s has invalid size %q bit(s)", name, v)
+		}
+	} else {
+		return nil, fmt.Errorf("%s need size hint", name)
+	}
+
+	if f.bits < 1 {
+		return nil, fmt.Errorf("%s has invalid bit-field size %d bit(s)", name, f.bits)
+	}
+	if f.kind == kindString {
+		if f.bits%8 != 0 {
+			return nil, fmt.Errorf("%s string type size needs to 8*n bits, set %d bits", name, f.bits)
+		}
+	} else if f.bits > 64 {
+		return nil, fmt.Errorf("%s bit-field size %d bit exceeds 64 bit", name, f.bits)
+	}
+
+	// bit-field block count
+	if f.slice {
+		v, ok := tag.Lookup("len")
+		if !ok {
+			return nil, fmt.Errorf("%s slice type needs length", name)
+		}
+
+		if f.len, err = strconv.Atoi(v); err == nil {
+			if f.len < 1 {
+				return nil, fmt.Errorf("%s slice type needs positive length, set %d length", name, f.len)
+			}
+		} else if ref, ok := index[v]; ok && !ref.slice && (ref.kind == kindInt || ref.kind == kindUint) {
+			// length's variable
+			f.lenRef = ref.name
+			ref.lenOf = name
+		} else {
+			return nil, fmt.Errorf("%s has invalid length %q", name, v)
+		}
+	}
+
+	// bit-field endian
+	if v, ok := tag.Lookup("endian"); ok {
+		switch v {
+		case "big":
+			f.endian = "bitio.BigEndian"
+		case "little":
+			f.endian = "bitio.LittleEndian"
+		default:
+			return nil, fmt.Errorf("%s has invalid endian %q", name, v)
+		}
+	}
+
+	return f, nil
+}
+
+// resolveKind returns kind of type name, following package local type definitions.
+func (g *Generator) resolveKind(name string) (kind, error) {
+	for depth := 0; depth < 16; depth++ {
+		if k, ok := builtinKinds[name]; ok {
+			return k, nil
+		}
+
+		ident, ok := g.types[name].(*ast.Ident)
+		if !ok {
+			break
+		}
+		name = ident.Name
+	}
+	return 0, fmt.Errorf("unsupport bit-field type %q", name)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (g *Generator) generateUnmarshal(name string, fields []*field) {
+	g.printf("\n// UnmarshalBits reads bit-field data to v and returns read size.\n")
+	g.printf("// If error happen, err will be set.\n")
+	g.printf("func (v *%s) UnmarshalBits(r bitio.BitReader) (nBit int, err error) {\n", name)
+
+	for _, f := range fields {
+		if f.kind != kindString {
+			g.printf("var u uint64\n\n")
+			break
+		}
+	}
+
+	for _, f := range fields {
+		g.printf("// %s\n", f.name)
+		if !f.slice {
+			g.readValue("v."+f.name, f)
+			g.printf("nBit += %d\n\n", f.bits)
+			continue
+		}
+
+		g.printf("{\n")
+		if f.lenRef != "" {
+			g.printf("n := int(v.%s)\n", f.lenRef)
+			g.printf("if n < 1 {\n")
+			g.printf("err = fmt.Errorf(\"slice type needs positive length, set %%d length\", n)\n")
+			g.printf("return\n")
+			g.printf("}\n")
+		} else {
+			g.printf("n := %d\n", f.len)
+		}
+		g.printf("if cap(v.%s) < n {\n", f.name)
+		g.printf("v.%s = make([]%s, n)\n", f.name, f.typ)
+		g.printf("} else {\n")
+		g.printf("v.%s = v.%s[:n]\n", f.name, f.name)
+		g.printf("}\n")
+		g.printf("for i := range v.%s {\n", f.name)
+		g.readValue("v."+f.name+"[i]", f)
+		g.printf("}\n")
+		g.printf("nBit += %d * n\n", f.bits)
+		g.printf("}\n\n")
+	}
+
+	g.printf("return\n")
+	g.printf("}\n")
+}
+
+// readValue prints code reading single bit-field value to dst.
+func (g *Generator) readValue(dst string, f *field) {
+	if f.kind == kindString {
+		g.printf("{\n")
+		g.printf("b := make([]byte, %d)\n", f.bits/8)
+		g.printf("if err = bitio.ReadSlice(r, 8, %s, b); err != nil {\n", f.endian)
+		g.printf("return\n")
+		g.printf("}\n")
+		g.printf("%s = %s(b)\n", dst, f.typ)
+		g.printf("}\n")
+		return
+	}
+
+	g.printf("if err = bitio.Read(r, %d, %s, &u); err != nil {\n", f.bits, f.endian)
+	g.printf("return\n")
+	g.printf("}\n")
+	if f.kind == kindBool {
+		g.printf("%s = u != 0\n", dst)
+	} else {
+		g.printf("%s = %s(u)\n", dst, f.typ)
+	}
+}
+
+func (g *Generator) generateMarshal(name string, fields []*field) {
+	g.printf("\n// MarshalBits writes bit-field data of v and returns write size.\n")
+	g.printf("// If error happen, err will be set.\n")
+	g.printf("func (v *%s) MarshalBits(w bitio.BitWriter) (nBit int, err error) {\n", name)
+
+	for _, f := range fields {
+		if f.kind == kindBool {
+			g.printf("var u uint64\n\n")
+			break
+		}
+	}
+
+	for _, f := range fields {
+		g.printf("// %s\n", f.name)
+		if f.lenOf != "" {
+			// update length's variable
+			g.printf("v.%s = %s(len(v.%s))\n", f.name, f.typ, f.lenOf)
+			g.printf("if err = bitio.Write(w, %d, %s, uint64(len(v.%s))); err != nil {\n", f.bits, f.endian, f.lenOf)
+			g.printf("return\n")
+			g.printf("}\n")
+			g.printf("nBit += %d\n\n", f.bits)
+			continue
+		}
+		if !f.slice {
+			g.writeValue("v."+f.name, f)
+			g.printf("nBit += %d\n\n", f.bits)
+			continue
+		}
+
+		g.printf("{\n")
+		if f.lenRef != "" {
+			g.printf("n := len(v.%s)\n", f.name)
+			g.printf("if n < 1 {\n")
+			g.printf("err = fmt.Errorf(\"slice type needs positive length, set %%d length\", n)\n")
+			g.printf("return\n")
+			g.printf("}\n")
+			g.printf("for _, e := range v.%s {\n", f.name)
+		} else {
+			// zero value for shortage
+			g.printf("n := %d\n", f.len)
+			g.printf("for i := 0; i < n; i++ {\n")
+			g.printf("var e %s\n", f.typ)
+			g.printf("if i < len(v.%s) {\n", f.name)
+			g.printf("e = v.%s[i]\n", f.name)
+			g.printf("}\n")
+		}
+		g.writeValue("e", f)
+		g.printf("}\n")
+		g.printf("nBit += %d * n\n", f.bits)
+		g.printf("}\n\n")
+	}
+
+	g.printf("return\n")
+	g.printf("}\n")
+}
+
+// writeValue prints code writing single bit-field value of src.
+func (g *Generator) writeValue(src string, f *field) {
+	var value string
+
+	switch f.kind {
+	case kindString:
+		g.printf("{\n")
+		g.printf("b := make([]byte, %d)\n", f.bits/8)
+		g.printf("copy(b, %s)\n", src)
+		g.printf("if err = bitio.WriteSlice(w, 8, %s, b); err != nil {\n", f.endian)
+		g.printf("return\n")
+		g.printf("}\n")
+		g.printf("}\n")
+		return
+
+	case kindBool:
+		g.printf("u = 0\n")
+		g.printf("if %s {\n", src)
+		g.printf("u = 1\n")
+		g.printf("}\n")
+		value = "u"
+	case kindInt:
+		value = fmt.Sprintf("int64(%s)", src)
+	case kindUint:
+		value = fmt.Sprintf("uint64(%s)", src)
+	}
+
+	g.printf("if err = bitio.Write(w, %d, %s, %s); err != nil {\n", f.bits, f.endian, value)
+	g.printf("return\n")
+	g.printf("}\n")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (g *Generator) generateTest(name string, fields []*field) {
+	g.printf("\nfunc TestBitiogen_%s(t *testing.T) {\n", name)
+	g.printf("rnd := rand.New(rand.NewSource(1))\n\n")
+	g.printf("for i := 0; i < 100; i++ {\n")
+	g.printf("src := &%s{}\n", name)
+
+	for _, f := range fields {
+		if f.lenOf != "" {
+			// set by length of slice
+			continue
+		}
+		if !f.slice {
+			g.randomValue("src."+f.name, f)
+			continue
+		}
+
+		if f.lenRef != "" {
+			max := 8
+			if ref := g.findField(fields, f.lenRef); ref.bits < 4 {
+				max = 1<<ref.bits - 1
+			}
+			g.printf("src.%s = make([]%s, 1+rnd.Intn(%d))\n", f.name, f.typ, max)
+		} else {
+			g.printf("src.%s = make([]%s, %d)\n", f.name, f.typ, f.len)
+		}
+		g.printf("for j := range src.%s {\n", f.name)
+		g.randomValue("src."+f.name+"[j]", f)
+		g.printf("}\n")
+	}
+
+	g.printf(`
+		b1 := new(bytes.Buffer)
+		w1 := bitio.NewBitWriteBuffer(b1)
+		n1, err := src.MarshalBits(w1)
+		if err != nil {
+			t.Fatalf("MarshalBits error: %%v", err)
+		}
+		w1.Flush()
+
+		b2 := new(bytes.Buffer)
+		w2 := bitio.NewBitFieldWriter(b2)
+		n2, err := w2.WriteStruct(src)
+		if err != nil {
+			t.Fatalf("WriteStruct error: %%v", err)
+		}
+		w2.Flush()
+
+		if n1 != n2 || !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+			t.Fatalf("MarshalBits write %%x (%%d bit), WriteStruct write %%x (%%d bit)", b1.Bytes(), n1, b2.Bytes(), n2)
+		}
+
+		dst1 := &%s{}
+		n1, err = dst1.UnmarshalBits(bitio.NewBitReadBuffer(bytes.NewReader(b1.Bytes())))
+		if err != nil {
+			t.Fatalf("UnmarshalBits error: %%v", err)
+		}
+
+		dst2 := &%s{}
+		n2, err = bitio.NewBitFieldReader(bytes.NewReader(b1.Bytes())).ReadStruct(dst2)
+		if err != nil {
+			t.Fatalf("ReadStruct error: %%v", err)
+		}
+
+		if n1 != n2 || !reflect.DeepEqual(dst1, dst2) {
+			t.Fatalf("UnmarshalBits read %%#v (%%d bit), ReadStruct read %%#v (%%d bit)", dst1, n1, dst2, n2)
+		}
+	}
+}
+`, name, name)
+}
+
+// randomValue prints code setting random value to dst.
+func (g *Generator) randomValue(dst string, f *field) {
+	switch f.kind {
+	case kindBool:
+		g.printf("%s = rnd.Intn(2) == 1\n", dst)
+	case kindString:
+		g.printf("{\n")
+		g.printf("b := make([]byte, %d)\n", f.bits/8)
+		g.printf("rnd.Read(b)\n")
+		g.printf("%s = %s(b)\n", dst, f.typ)
+		g.printf("}\n")
+	default:
+		mask := ^uint64(0)
+		if f.bits < 64 {
+			mask = 1<<f.bits - 1
+		}
+		g.printf("%s = %s(rnd.Uint64() & %#x)\n", dst, f.typ, mask)
+	}
+}
+
+func (g *Generator) findField(fields []*field, name string) *field {
+	for _, f := range fields {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+)
+
+func TestGenerator_Golden(t *testing.T) {
+	g := &Generator{}
+	if err := g.ParseDir("example"); err != nil {
+		t.Fatalf("ParseDir error: %v", err)
+	}
+	types := []string{"Container", "Header"}
+
+	src, err := g.Generate(types)
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	exp, err := os.ReadFile("example/container_bitio.go")
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if bytes.Equal(src, exp) == false {
+		t.Fatalf("Generate output differs from example/container_bitio.go, run go generate")
+	}
+
+	src, err = g.GenerateTest(types)
+	if err != nil {
+		t.Fatalf("GenerateTest error: %v", err)
+	}
+	exp, err = os.ReadFile("example/container_bitio_test.go")
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if bytes.Equal(src, exp) == false {
+		t.Fatalf("GenerateTest output differs from example/container_bitio_test.go, run go generate")
+	}
+}
+
+func TestGenerator_InvalidStruct(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"no size hint", "type T struct { Val int }"},
+		{"invalid bit size", "type T struct { Val int `bit:\"x\"` }"},
+		{"zero bit size", "type T struct { Val int `bit:\"0\"` }"},
+		{"over 64 bit size", "type T struct { Val int `byte:\"9\"` }"},
+		{"string bit size", "type T struct { Val string `bit:\"12\"` }"},
+		{"invalid endian", "type T struct { Val int `bit:\"4\" endian:\"middle\"` }"},
+		{"slice without length", "type T struct { Val []int `bit:\"4\"` }"},
+		{"unknown length's variable", "type T struct { Val []int `bit:\"4\" len:\"Size\"`; Size int `bit:\"4\"` }"},
+		{"unsupport type", "type T struct { Val float32 `bit:\"32\"` }"},
+		{"unsupport embedded", "type E int; type T struct { E `bit:\"4\"` }"},
+		{"not struct", "type T int"},
+		{"unsupport encoding", "type T struct { Val uint `encoding:\"ue\"` }"},
+		{"unsupport crc", "type T struct { A uint `bit:\"8\"`; C uint32 `crc:\"crc32-mpeg2\"` }"},
+	}
+
+	for _, tt := range tests {
+		file, err := parser.ParseFile(token.NewFileSet(), "src.go", "package p\n"+tt.src, 0)
+		if err != nil {
+			t.Fatalf("%q parse error: %v", tt.name, err)
+		}
+
+		g := &Generator{}
+		if err = g.addFile(file); err != nil {
+			t.Fatalf("%q addFile error: %v", tt.name, err)
+		}
+		if _, err = g.Generate([]string{"T"}); err == nil {
+			t.Fatalf("Generate %q wants error", tt.name)
+		}
+	}
+}
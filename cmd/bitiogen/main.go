@@ -0,0 +1,86 @@
+// Bitiogen generates reflection-free bit-field reader/writer methods.
+//
+// For each named struct type, bitiogen reads `bit`, `byte`, `len` and `endian`
+// tags and generates MarshalBits/UnmarshalBits methods, which write/read the
+// same bits as bitio.BitFieldWriter.WriteStruct/bitio.BitFieldReader.ReadStruct.
+//
+//	func (v *T) MarshalBits(w bitio.BitWriter) (nBit int, err error)
+//	func (v *T) UnmarshalBits(r bitio.BitReader) (nBit int, err error)
+//
+// Usage:
+//
+//	//go:generate bitiogen -type Container,Header
+//
+// With -test flag, bitiogen also generates a test file that cross-checks
+// generated methods against the reflective path.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of type names; must be set")
+	output    = flag.String("output", "", "output file name; default srcdir/<type>_bitio.go")
+	withTest  = flag.Bool("test", false, "generate cross-check test file <output>_test.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of bitiogen:\n")
+	fmt.Fprintf(os.Stderr, "\tbitiogen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("bitiogen: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if len(*typeNames) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	types := strings.Split(*typeNames, ",")
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	g := &Generator{}
+	if err := g.ParseDir(dir); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := g.Generate(types)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	outputName := *output
+	if outputName == "" {
+		outputName = filepath.Join(dir, strings.ToLower(types[0])+"_bitio.go")
+	}
+	if err := os.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+
+	if *withTest {
+		src, err := g.GenerateTest(types)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		testName := strings.TrimSuffix(outputName, ".go") + "_test.go"
+		if err := os.WriteFile(testName, src, 0644); err != nil {
+			log.Fatalf("writing output: %s", err)
+		}
+	}
+}
@@ -0,0 +1,632 @@
+package bitio
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// Codec is a compiled bit-field reader/writer of struct type T.
+// Codec is safe for concurrent use by multiple goroutines.
+type Codec[T any] struct {
+	plan *structPlan
+}
+
+// Compile returns Codec of struct type T.
+// Struct tags are parsed and validated only once, the result is cached by type.
+// Returns error if T is not struct or has invalid tags.
+func Compile[T any]() (*Codec[T], error) {
+	plan, err := compilePlan(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return &Codec[T]{plan: plan}, nil
+}
+
+// Read reads bit-field data to v and returns read size.
+// If error happen, err will be set.
+func (c *Codec[T]) Read(r BitReader, v *T) (nBit int, err error) {
+	return c.plan.read(r, reflect.ValueOf(v).Elem())
+}
+
+// Write writes bit-field data of v and returns write size.
+// Computed value of `crc` field is set to v.
+// If value does not fit in bit-field size, it is handled by OverflowPolicy option. (default: OverflowError)
+// If error happen, err will be set.
+func (c *Codec[T]) Write(w BitWriter, v *T, opts ...WriteOption) (nBit int, err error) {
+	return c.plan.write(w, reflect.ValueOf(v).Elem(), newWriteState(opts))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// planCache stores compiled structPlan. (map[reflect.Type]*structPlan)
+var planCache sync.Map
+
+// structPlan store compiled bit-field configration of struct.
+type structPlan struct {
+	typ    reflect.Type
+	fields []fieldPlan
+	bits   int   // static bit size (-1: depends on value)
+	checks []int // plan.fields index of crc fields
+}
+
+// fieldPlan store compiled bit-field configration of struct field.
+type fieldPlan struct {
+	name   string
+	tag    reflect.StructTag
+	index  int          // struct field index
+	kind   reflect.Kind // field kind
+	elem   reflect.Kind // element kind (slice only)
+	sub    *structPlan  // struct plan (struct or slice of struct only)
+	bits   int
+	len    int // fixed length (slice only)
+	lenRef int // struct field index of length's variable (-1: fixed length)
+	lenOf  int // struct field index of slice, which length is stored to this field (-1: none)
+	endian ByteOrder
+	enc    valueEncoding // variable length encoding (nil: fixed size)
+	block  *fieldBlock   // block encoding of integer slice (nil: element by element)
+	check  *fieldCheck   // checksum of preceding fields (nil: not checksum)
+}
+
+// compilePlan returns compiled structPlan of struct type rt.
+func compilePlan(rt reflect.Type) (*structPlan, error) {
+	return compilePlanVisit(rt, make(map[reflect.Type]bool))
+}
+
+// compilePlanVisit returns compiled structPlan of struct type rt.
+// visiting holds the struct types under compiling, to detect recursive type.
+func compilePlanVisit(rt reflect.Type, visiting map[reflect.Type]bool) (*structPlan, error) {
+	if plan, ok := planCache.Load(rt); ok {
+		return plan.(*structPlan), nil
+	}
+
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%v is not struct", rt)
+	}
+	if visiting[rt] {
+		return nil, fmt.Errorf("%v is recursive type", rt)
+	}
+	visiting[rt] = true
+	defer delete(visiting, rt)
+
+	plan := &structPlan{typ: rt}
+	fieldIndex := make(map[string]int) // field name -> plan.fields index
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+
+		// skip unexport field
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fp, err := compileField(field, plan, fieldIndex, visiting)
+		if err != nil {
+			return nil, plan.compileError(field.Name, field.Tag, err)
+		}
+
+		if fp.check != nil {
+			plan.checks = append(plan.checks, len(plan.fields))
+		}
+		fieldIndex[field.Name] = len(plan.fields)
+		plan.fields = append(plan.fields, *fp)
+	}
+
+	for i := range plan.fields {
+		bits := plan.fields[i].staticBits()
+		if bits < 0 {
+			plan.bits = -1
+			break
+		}
+		plan.bits += bits
+	}
+
+	actual, _ := planCache.LoadOrStore(rt, plan)
+	return actual.(*structPlan), nil
+}
+
+// compileError returns FieldError of invalid field.
+func (p *structPlan) compileError(name string, tag reflect.StructTag, err error) error {
+	if fe, ok := err.(*FieldError); ok {
+		// nested struct
+		name += "." + fe.Field
+		tag = fe.Tag
+		err = fe.Err
+	}
+	return &FieldError{Struct: p.typ, Field: name, Offset: -1, Tag: tag, Err: err}
+}
+
+// compileField parses tags of struct field.
+// plan and fieldIndex hold the preceding fields.
+func compileField(field reflect.StructField, plan *structPlan, fieldIndex map[string]int, visiting map[reflect.Type]bool) (*fieldPlan, error) {
+	var err error
+
+	fp := &fieldPlan{
+		name:   field.Name,
+		tag:    field.Tag,
+		index:  field.Index[0],
+		kind:   field.Type.Kind(),
+		lenRef: -1,
+		lenOf:  -1,
+		endian: LittleEndian,
+	}
+
+	// bit-field type
+	typ := field.Type
+	if fp.kind == reflect.Slice {
+		typ = typ.Elem()
+		fp.elem = typ.Kind()
+	}
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	case reflect.Struct:
+		if fp.sub, err = compilePlanVisit(typ, visiting); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupport bit-field type %q", field.Type.String())
+	}
+
+	// checksum of preceding fields
+	if err = compileChecksum(field, fp, plan, fieldIndex); err != nil {
+		return nil, err
+	}
+	if fp.check != nil {
+		return fp, nil
+	}
+
+	// bit-field encoding
+	precedingInt := func(name string) (int, bool) {
+		j, ok := fieldIndex[name]
+		if !ok || !isIntegerKind(plan.fields[j].kind) {
+			return 0, false
+		}
+		return plan.fields[j].index, true
+	}
+	if fp.block, err = compileBlock(field.Tag, fp.kind, fp.elem); err != nil {
+		return nil, err
+	}
+	if fp.block != nil {
+		// block is self-describing
+		return fp, nil
+	}
+	if fp.enc, err = compileEncoding(field.Tag, typ.Kind(), precedingInt); err != nil {
+		return nil, err
+	}
+
+	// bit-field size
+	if fp.sub != nil || fp.enc != nil {
+		// struct size is sum of fields, encoded size depends on value
+	} else if v, ok := field.Tag.Lookup("byte"); ok {
+		if fp.bits, err = strconv.Atoi(v); err != nil {
+			return nil, invalidTag("size %q byte(s)", v)
+		}
+		fp.bits *= 8
+	} else if v, ok := field.Tag.Lookup("bit"); ok {
+		if fp.bits, err = strconv.Atoi(v); err != nil {
+			return nil, invalidTag("size %q bit(s)", v)
+		}
+	} else {
+		return nil, invalidTag("need size hint")
+	}
+
+	if fp.sub != nil || fp.enc != nil {
+		// nothing to check
+	} else if fp.bits < 1 {
+		return nil, invalidTag("bit-field size %d bit(s)", fp.bits)
+	} else if typ.Kind() == reflect.String {
+		if fp.bits%8 != 0 {
+			return nil, invalidTag("string type size needs to 8*n bits, set %d bits", fp.bits)
+		}
+	} else if fp.bits > 64 {
+		return nil, invalidTag("bit-field size %d bit exceeds 64 bit", fp.bits)
+	}
+
+	// bit-field block count
+	if fp.kind == reflect.Slice {
+		v, ok := field.Tag.Lookup("len")
+		if !ok {
+			return nil, invalidTag("slice type needs length")
+		}
+
+		if fp.len, err = strconv.Atoi(v); err == nil {
+			if fp.len < 1 {
+				return nil, invalidTag("slice type needs positive length, set %d length", fp.len)
+			}
+		} else if j, ok := fieldIndex[v]; ok && isIntegerKind(plan.fields[j].kind) {
+			// length's variable
+			fp.lenRef = plan.fields[j].index
+			plan.fields[j].lenOf = fp.index
+		} else {
+			return nil, invalidTag("length %q", v)
+		}
+	}
+
+	// bit-field endian
+	if v, ok := field.Tag.Lookup("endian"); ok {
+		switch v {
+		case "big":
+			fp.endian = BigEndian
+		case "little":
+			fp.endian = LittleEndian
+		default:
+			return nil, invalidTag("endian %q", v)
+		}
+	}
+
+	return fp, nil
+}
+
+// indexError is an error of slice element.
+type indexError struct {
+	index int
+	err   error
+}
+
+func (e *indexError) Error() string {
+	return fmt.Sprintf("[%d]: %v", e.index, e.err)
+}
+
+// fieldError returns FieldError of field at offset.
+// If err is error of slice element or nested struct, path and offset are joined.
+func (p *structPlan) fieldError(name string, tag reflect.StructTag, offset int, err error) error {
+	path := name
+	if ie, ok := err.(*indexError); ok {
+		path = fmt.Sprintf("%s[%d]", path, ie.index)
+		err = ie.err
+	}
+	if fe, ok := err.(*FieldError); ok {
+		path += "." + fe.Field
+		offset += fe.Offset
+		tag = fe.Tag
+		err = fe.Err
+	}
+
+	// EOF in the middle of struct is unexpected
+	if offset > 0 && err == io.EOF {
+		err = ErrUnexpectedEOF
+	}
+
+	return &FieldError{
+		Struct: p.typ,
+		Field:  path,
+		Offset: offset,
+		Tag:    tag,
+		Err:    err,
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// read reads bit-field data to struct value rv.
+func (p *structPlan) read(r BitReader, rv reflect.Value) (nBit int, err error) {
+	buf := make([]byte, 8)
+
+	var cs *checksumState
+	if len(p.checks) > 0 {
+		cs = newChecksumState(p)
+	}
+
+	for i := range p.fields {
+		fp := &p.fields[i]
+		ptr := rv.Field(fp.index)
+
+		src := r
+		if cs != nil {
+			src = cs.reader(r, i)
+		}
+
+		var n int
+		if n, err = fp.read(src, rv, ptr, buf); err == nil && fp.check != nil {
+			err = cs.verify(i, ptr)
+		}
+		if err != nil {
+			return nBit + n, p.fieldError(fp.name, fp.tag, nBit+n, err)
+		}
+		nBit += n
+	}
+
+	return
+}
+
+// writeState holds work space and options of writing struct.
+type writeState struct {
+	writeConfig
+	buf []byte
+}
+
+func newWriteState(opts []WriteOption) *writeState {
+	return &writeState{
+		writeConfig: newWriteConfig(opts),
+		buf:         make([]byte, 8),
+	}
+}
+
+// write writes bit-field data of struct value rv.
+func (p *structPlan) write(w BitWriter, rv reflect.Value, st *writeState) (nBit int, err error) {
+	var cs *checksumState
+	if len(p.checks) > 0 {
+		cs = newChecksumState(p)
+	}
+
+	for i := range p.fields {
+		fp := &p.fields[i]
+		ptr := rv.Field(fp.index)
+
+		dst := w
+		if cs != nil {
+			dst = cs.writer(w, i)
+		}
+
+		var n int
+		if fp.check != nil {
+			n, err = cs.write(dst, i, ptr, st.buf)
+		} else {
+			n, err = fp.write(dst, rv, ptr, st)
+		}
+		if err != nil {
+			return nBit + n, p.fieldError(fp.name, fp.tag, nBit+n, err)
+		}
+		nBit += n
+	}
+
+	return
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// read reads bit-field data to field value ptr.
+// If error happen, returns read size until failed value.
+func (fp *fieldPlan) read(r BitReader, rv, ptr reflect.Value, buf []byte) (n int, err error) {
+	if fp.block != nil {
+		return fp.block.read(r, ptr, buf)
+	}
+	if fp.kind != reflect.Slice {
+		if n, err = fp.readValue(r, rv, ptr, buf); err != nil {
+			return 0, err
+		}
+		return
+	}
+
+	length := fp.len
+	if fp.lenRef >= 0 {
+		length = int(intValue(rv.Field(fp.lenRef)))
+	}
+	if length < 1 {
+		return 0, fmt.Errorf("slice type needs positive length, set %d length", length)
+	}
+
+	// (re-)allocate slice space
+	if ptr.Cap() < length {
+		ptr.Set(reflect.MakeSlice(ptr.Type(), length, length))
+	} else {
+		ptr.SetLen(length)
+	}
+
+	// read slice elements
+	for i := 0; i < length; i++ {
+		var m int
+		if m, err = fp.readValue(r, rv, ptr.Index(i), buf); err != nil {
+			return n, &indexError{index: i, err: err}
+		}
+		n += m
+	}
+
+	return
+}
+
+// readValue reads single bit-field value to ptr.
+// rv is struct value which has the field.
+func (fp *fieldPlan) readValue(r BitReader, rv, ptr reflect.Value, buf []byte) (int, error) {
+	if fp.enc != nil {
+		v, n, err := fp.enc.read(r, rv, buf)
+		if err != nil {
+			return 0, err
+		}
+		if err = setEncodedValue(ptr, v); err != nil {
+			return 0, err
+		}
+		return n, nil
+	}
+
+	switch ptr.Kind() {
+	case reflect.Struct:
+		return fp.sub.read(r, ptr)
+
+	case reflect.String:
+		v := make([]byte, fp.bits/8)
+		if n, err := r.ReadBits(v, fp.bits); err != nil {
+			return 0, err
+		} else if n != fp.bits {
+			return 0, fmt.Errorf("insufficient size of read, want %d bit, read %d bit: %w", fp.bits, n, ErrUnexpectedEOF)
+		}
+		ptr.SetString(string(v))
+		return fp.bits, nil
+	}
+
+	v, err := readUint64(r, fp.bits, fp.endian, buf)
+	if err != nil {
+		return 0, err
+	}
+
+	switch ptr.Kind() {
+	case reflect.Bool:
+		ptr.SetBool(v != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ptr.SetInt(int64(v))
+	default:
+		ptr.SetUint(v)
+	}
+	return fp.bits, nil
+}
+
+// write writes bit-field data of field value ptr.
+// If error happen, returns write size until failed value.
+func (fp *fieldPlan) write(w BitWriter, rv, ptr reflect.Value, st *writeState) (n int, err error) {
+	if fp.block != nil {
+		return fp.block.write(w, ptr, st.buf)
+	}
+	if fp.kind != reflect.Slice {
+		if fp.lenOf >= 0 {
+			// update length's variable
+			length := rv.Field(fp.lenOf).Len()
+			if fp.enc != nil {
+				if ptr.CanSet() {
+					setIntValue(ptr, int64(length))
+				}
+				return fp.enc.write(w, rv, uint64(length), st.buf)
+			}
+			if fp.bits < 64 && uint64(length) >= 1<<fp.bits {
+				return 0, fmt.Errorf("length %d exceeds %d bit: %w", length, fp.bits, ErrValueOverflow)
+			}
+			if ptr.CanSet() {
+				setIntValue(ptr, int64(length))
+			}
+			if err = writeUint64(w, fp.bits, fp.endian, uint64(length), st.buf); err != nil {
+				return 0, err
+			}
+			return fp.bits, nil
+		}
+
+		if n, err = fp.writeValue(w, rv, ptr, st); err != nil {
+			return 0, err
+		}
+		return
+	}
+
+	length := fp.len
+	if fp.lenRef >= 0 {
+		length = ptr.Len()
+	}
+	if length < 1 {
+		return 0, fmt.Errorf("slice type needs positive length, set %d length", length)
+	}
+
+	// write slice elements (zero value for shortage)
+	zero := reflect.New(ptr.Type().Elem()).Elem()
+	for i := 0; i < length; i++ {
+		elem := zero
+		if i < ptr.Len() {
+			elem = ptr.Index(i)
+		}
+
+		var m int
+		if m, err = fp.writeValue(w, rv, elem, st); err != nil {
+			return n, &indexError{index: i, err: err}
+		}
+		n += m
+	}
+
+	return
+}
+
+// writeValue writes single bit-field value of ptr.
+// rv is struct value which has the field.
+func (fp *fieldPlan) writeValue(w BitWriter, rv, ptr reflect.Value, st *writeState) (int, error) {
+	var v uint64
+	var err error
+
+	if fp.enc != nil {
+		return fp.enc.write(w, rv, encodedValue(ptr), st.buf)
+	}
+
+	switch ptr.Kind() {
+	case reflect.Struct:
+		return fp.sub.write(w, ptr, st)
+
+	case reflect.String:
+		size := fp.bits / 8
+		b := []byte(ptr.String())
+		if len(b) < size {
+			b = append(b, make([]byte, size-len(b))...)
+		}
+		if n, err := w.WriteBits(b[:size], fp.bits); err != nil {
+			return 0, err
+		} else if n != fp.bits {
+			return 0, fmt.Errorf("insufficient size of write, want %d bit, write %d bit", fp.bits, n)
+		}
+		return fp.bits, nil
+
+	case reflect.Bool:
+		if ptr.Bool() {
+			v = 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err = fitInt(ptr.Int(), fp.bits, st.overflow); err != nil {
+			return 0, err
+		}
+	default:
+		if v, err = fitUint(ptr.Uint(), fp.bits, st.overflow); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = writeUint64(w, fp.bits, fp.endian, v, st.buf); err != nil {
+		return 0, err
+	}
+	return fp.bits, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// intValue returns integer value of ptr as int64.
+func intValue(ptr reflect.Value) int64 {
+	switch ptr.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return ptr.Int()
+	default:
+		return int64(ptr.Uint())
+	}
+}
+
+// encodedValue returns integer value of ptr as uint64 bits.
+func encodedValue(ptr reflect.Value) uint64 {
+	switch ptr.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(ptr.Int())
+	default:
+		return ptr.Uint()
+	}
+}
+
+// setEncodedValue sets decoded integer value v (uint64 bits) to ptr.
+// Returns error if v does not fit in ptr type.
+func setEncodedValue(ptr reflect.Value, v uint64) error {
+	switch ptr.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if ptr.OverflowInt(int64(v)) {
+			return fmt.Errorf("value %d exceeds %v: %w", int64(v), ptr.Type(), ErrValueOverflow)
+		}
+		ptr.SetInt(int64(v))
+	default:
+		if ptr.OverflowUint(v) {
+			return fmt.Errorf("value %d exceeds %v: %w", v, ptr.Type(), ErrValueOverflow)
+		}
+		ptr.SetUint(v)
+	}
+	return nil
+}
+
+// setIntValue sets integer value to ptr.
+func setIntValue(ptr reflect.Value, v int64) {
+	switch ptr.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ptr.SetInt(v)
+	default:
+		ptr.SetUint(uint64(v))
+	}
+}
@@ -0,0 +1,297 @@
+package bitio_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+type codecRecord struct {
+	Flag  bool   `bit:"1"`
+	Kind  uint8  `bit:"3"`
+	Count uint8  `bit:"4"`
+	Value int32  `bit:"20" endian:"big"`
+	Ext   uint16 `bit:"12" endian:"little"`
+	Name  string `byte:"2"`
+	Data  []byte `byte:"1" len:"Count"`
+	Pad   []bool `bit:"1" len:"8"`
+
+	internal int
+}
+
+var codecRecordRaw = []byte{
+	0x94, 0x12, 0x34, 0x56, 0x78, 0x61, 0x62, 0x01,
+	0x02, 0x03, 0x04, 0xa5,
+}
+
+var codecRecordValue = codecRecord{
+	Flag:  true,
+	Kind:  1,
+	Count: 4,
+	Value: 0x12345,
+	Ext:   0x867,
+	Name:  "ab",
+	Data:  []byte{0x01, 0x02, 0x03, 0x04},
+	Pad:   []bool{true, false, true, false, false, true, false, true},
+}
+
+func TestCodec_Read(t *testing.T) {
+	c, err := bitio.Compile[codecRecord]()
+	if err != nil {
+		t.Fatalf("Compile error: %v", err)
+	}
+
+	r := bitio.NewBitReadBuffer(bytes.NewReader(codecRecordRaw))
+	v := codecRecord{}
+
+	n, err := c.Read(r, &v)
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if n != len(codecRecordRaw)*8 {
+		t.Fatalf("Read read size %d, want %d", n, len(codecRecordRaw)*8)
+	}
+	if reflect.DeepEqual(v, codecRecordValue) == false {
+		t.Fatalf("Read read %#v, want %#v", v, codecRecordValue)
+	}
+}
+
+func TestCodec_Write(t *testing.T) {
+	c, err := bitio.Compile[codecRecord]()
+	if err != nil {
+		t.Fatalf("Compile error: %v", err)
+	}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	v := codecRecordValue
+	v.Count = 0 // update by len(Data)
+
+	n, err := c.Write(w, &v)
+	if err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if err = w.Flush(); err != nil {
+		t.Fatalf("Write flush happen error %v", err)
+	}
+	if n != len(codecRecordRaw)*8 {
+		t.Fatalf("Write write size %d, want %d", n, len(codecRecordRaw)*8)
+	}
+	if reflect.DeepEqual(b.Bytes(), codecRecordRaw) == false {
+		t.Fatalf("Write write %#v, want %#v", b.Bytes(), codecRecordRaw)
+	}
+	if v.Count != 4 {
+		t.Fatalf("Write length's variable %d, want %d", v.Count, 4)
+	}
+}
+
+func TestCodec_SameAsBitField(t *testing.T) {
+	c, err := bitio.Compile[codecRecord]()
+	if err != nil {
+		t.Fatalf("Compile error: %v", err)
+	}
+
+	b1 := new(bytes.Buffer)
+	w1 := bitio.NewBitWriteBuffer(b1)
+	v := codecRecordValue
+	if _, err = c.Write(w1, &v); err != nil {
+		t.Fatalf("Codec write error: %v", err)
+	}
+	w1.Flush()
+
+	b2 := new(bytes.Buffer)
+	w2 := bitio.NewBitFieldWriter(b2)
+	if _, err = w2.WriteStruct(&v); err != nil {
+		t.Fatalf("WriteStruct error: %v", err)
+	}
+	w2.Flush()
+
+	if reflect.DeepEqual(b1.Bytes(), b2.Bytes()) == false {
+		t.Fatalf("Codec write %#v, WriteStruct write %#v", b1.Bytes(), b2.Bytes())
+	}
+}
+
+func TestCompile_InvalidTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		compile func() error
+	}{
+		{
+			name: "no size hint",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val int
+				}]()
+				return err
+			},
+		},
+		{
+			name: "invalid bit size",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val int `bit:"x"`
+				}]()
+				return err
+			},
+		},
+		{
+			name: "zero bit size",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val int `bit:"0"`
+				}]()
+				return err
+			},
+		},
+		{
+			name: "over 64 bit size",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val int `byte:"9"`
+				}]()
+				return err
+			},
+		},
+		{
+			name: "string bit size",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val string `bit:"12"`
+				}]()
+				return err
+			},
+		},
+		{
+			name: "invalid endian",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val int `bit:"4" endian:"middle"`
+				}]()
+				return err
+			},
+		},
+		{
+			name: "slice without length",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val []int `bit:"4"`
+				}]()
+				return err
+			},
+		},
+		{
+			name: "unknown length's variable",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val  []int `bit:"4" len:"Size"`
+					Size int   `bit:"4"`
+				}]()
+				return err
+			},
+		},
+		{
+			name: "unsupport type",
+			compile: func() error {
+				_, err := bitio.Compile[struct {
+					Val float32 `bit:"32"`
+				}]()
+				return err
+			},
+		},
+		{
+			name: "not struct",
+			compile: func() error {
+				_, err := bitio.Compile[int]()
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if err := tt.compile(); err == nil {
+			t.Fatalf("Compile %q wants error", tt.name)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Uncached benchmarks compile tags on every call, like ReadStruct/WriteStruct before plans were cached.
+
+func BenchmarkBitFieldReader_ReadStruct_Uncached(b *testing.B) {
+	raw := bytes.Repeat(codecRecordRaw, b.N)
+	r := bitio.NewBitFieldReader(bytes.NewReader(raw))
+	v := &codecRecord{}
+	rt := reflect.TypeOf(v).Elem()
+
+	b.SetBytes(int64(len(codecRecordRaw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bitio.ForgetPlan(rt)
+		r.ReadStruct(v)
+	}
+}
+
+func BenchmarkBitFieldWriter_WriteStruct_Uncached(b *testing.B) {
+	w := bitio.NewBitFieldWriter(io.Discard)
+	v := codecRecordValue
+	rt := reflect.TypeOf(v)
+
+	b.SetBytes(int64(len(codecRecordRaw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bitio.ForgetPlan(rt)
+		w.WriteStruct(&v)
+	}
+}
+
+func BenchmarkBitFieldReader_ReadStruct(b *testing.B) {
+	raw := bytes.Repeat(codecRecordRaw, b.N)
+	r := bitio.NewBitFieldReader(bytes.NewReader(raw))
+	v := &codecRecord{}
+
+	b.SetBytes(int64(len(codecRecordRaw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.ReadStruct(v)
+	}
+}
+
+func BenchmarkCodec_Read(b *testing.B) {
+	c, _ := bitio.Compile[codecRecord]()
+	raw := bytes.Repeat(codecRecordRaw, b.N)
+	r := bitio.NewBitReadBuffer(bytes.NewReader(raw))
+	v := &codecRecord{}
+
+	b.SetBytes(int64(len(codecRecordRaw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Read(r, v)
+	}
+}
+
+func BenchmarkBitFieldWriter_WriteStruct(b *testing.B) {
+	w := bitio.NewBitFieldWriter(io.Discard)
+	v := codecRecordValue
+
+	b.SetBytes(int64(len(codecRecordRaw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.WriteStruct(&v)
+	}
+}
+
+func BenchmarkCodec_Write(b *testing.B) {
+	c, _ := bitio.Compile[codecRecord]()
+	w := bitio.NewBitWriteBuffer(io.Discard)
+	v := codecRecordValue
+
+	b.SetBytes(int64(len(codecRecordRaw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Write(w, &v)
+	}
+}
@@ -0,0 +1,192 @@
+// Package crc implements cyclic redundancy checks of any width (1..64) on the Rocksoft model.
+//
+// A CRC is described by Params (width, polynomial, init, reflect-in/out and xor-out).
+// Digest consumes bytes and also individual bits, so a CRC can be computed over
+// a bit range that is not byte aligned.
+// Digest has the same methods as bitio.BitWriter, and it implements hash.Hash64.
+package crc
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrInvalidParams is returned when Params are invalid.
+var ErrInvalidParams = errors.New("crc: invalid parameters")
+
+// Params is parameters of CRC on the Rocksoft model.
+type Params struct {
+	Name   string // name of CRC catalogue (ex: CRC-32/ISO-HDLC)
+	Width  int    // bit size of CRC (1..64)
+	Poly   uint64 // polynomial without the top bit
+	Init   uint64 // initial register value (not reflected)
+	RefIn  bool   // bits of each input byte are processed LSB first
+	RefOut bool   // register is reflected before XorOut
+	XorOut uint64 // value xored to the final register
+	Check  uint64 // CRC of "123456789"
+}
+
+// mask returns mask of Width bits.
+func (p *Params) mask() uint64 {
+	return ^uint64(0) >> uint(64-p.Width)
+}
+
+// validate checks Width and values of p.
+func (p *Params) validate() error {
+	if p.Width < 1 || p.Width > 64 {
+		return fmt.Errorf("%w: width %d is out of range [1, 64]", ErrInvalidParams, p.Width)
+	}
+	m := p.mask()
+	if p.Poly&^m != 0 || p.Init&^m != 0 || p.XorOut&^m != 0 {
+		return fmt.Errorf("%w: value exceeds %d bit", ErrInvalidParams, p.Width)
+	}
+	return nil
+}
+
+// Checksum returns CRC of data.
+// Return error if p is invalid.
+func Checksum(p *Params, data []byte) (uint64, error) {
+	d, err := New(p)
+	if err != nil {
+		return 0, err
+	}
+	d.Write(data)
+	return d.Sum64(), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// New returns Digest of p.
+// Return error if p is invalid.
+func New(p *Params) (*Digest, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	d := &Digest{
+		params: *p,
+		shift:  uint(64 - p.Width),
+	}
+	d.poly = p.Poly << d.shift
+	for i := range d.table {
+		reg := uint64(i) << 56
+		for k := 0; k < 8; k++ {
+			reg = d.step(reg, reg>>63)
+		}
+		d.table[i] = reg
+	}
+	d.Reset()
+	return d, nil
+}
+
+// Digest computes CRC of written bits.
+// Register is kept left justified in 64 bit, so that all widths share the same algorithm.
+type Digest struct {
+	params Params
+	shift  uint
+	poly   uint64 // left justified polynomial
+	reg    uint64 // left justified register
+	table  [256]uint64
+}
+
+// step shifts reg by 1 bit of input xored with the top bit.
+func (d *Digest) step(reg, top uint64) uint64 {
+	reg <<= 1
+	if top&1 != 0 {
+		reg ^= d.poly
+	}
+	return reg
+}
+
+// Params returns parameters of d.
+func (d *Digest) Params() Params {
+	return d.params
+}
+
+// Reset resets register to Init.
+func (d *Digest) Reset() {
+	d.reg = d.params.Init << d.shift
+}
+
+// Size returns byte size of CRC.
+func (d *Digest) Size() int {
+	return (d.params.Width + 7) / 8
+}
+
+// BlockSize returns 1.
+func (d *Digest) BlockSize() int {
+	return 1
+}
+
+// Write writes bytes. Bits of each byte are processed LSB first if RefIn is set.
+// It never returns error.
+func (d *Digest) Write(p []byte) (nByte int, err error) {
+	for _, b := range p {
+		if d.params.RefIn {
+			b = bits.Reverse8(b)
+		}
+		d.reg = d.table[byte(d.reg>>56)^b] ^ d.reg<<8
+	}
+	return len(p), nil
+}
+
+// WriteBit writes bitSize bits (right justified) of p in MSB first order and returns write size.
+// RefIn is not applied to bits, they are processed in written order.
+// Return error if bitSize is out of range [0, 8].
+func (d *Digest) WriteBit(p byte, bitSize int) (nBit int, err error) {
+	if bitSize < 0 || bitSize > 8 {
+		return 0, fmt.Errorf("crc: bitSize %d is out of range [0, 8]", bitSize)
+	}
+	d.writeBits(uint64(p), bitSize)
+	return bitSize, nil
+}
+
+// WriteBits writes bitSize bits (right justified. 12bit = 0x0f 0xff) of p in MSB first order and returns write size.
+// RefIn is not applied to bits, they are processed in written order.
+// Return error if p is shorter than bitSize.
+func (d *Digest) WriteBits(p []byte, bitSize int) (nBit int, err error) {
+	if bitSize < 0 || len(p)*8 < bitSize {
+		return 0, fmt.Errorf("crc: argument p[] is %d bits, want %d bits", len(p)*8, bitSize)
+	}
+
+	p = p[len(p)-(bitSize+7)/8:]
+	if n := bitSize % 8; n > 0 {
+		d.writeBits(uint64(p[0]), n)
+		p = p[1:]
+	}
+	for _, b := range p {
+		d.reg = d.table[byte(d.reg>>56)^b] ^ d.reg<<8
+	}
+	return bitSize, nil
+}
+
+// writeBits writes n bits (right justified) of v.
+func (d *Digest) writeBits(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		d.reg = d.step(d.reg, d.reg>>63^v>>uint(i))
+	}
+}
+
+// Flush does nothing. (for bitio.BitWriter)
+func (d *Digest) Flush() error {
+	return nil
+}
+
+// Sum64 returns CRC of written bits.
+func (d *Digest) Sum64() uint64 {
+	v := d.reg >> d.shift
+	if d.params.RefOut {
+		v = bits.Reverse64(v) >> d.shift
+	}
+	return v ^ d.params.XorOut
+}
+
+// Sum appends CRC (big endian of Size bytes) to b and returns it.
+func (d *Digest) Sum(b []byte) []byte {
+	v := d.Sum64()
+	for i := d.Size() - 1; i >= 0; i-- {
+		b = append(b, byte(v>>uint(8*i)))
+	}
+	return b
+}
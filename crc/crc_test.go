@@ -0,0 +1,167 @@
+package crc_test
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"math/rand"
+	"testing"
+
+	"github.com/hidez8891/bitio/crc"
+)
+
+var check = []byte("123456789")
+
+func TestCatalogue(t *testing.T) {
+	for _, p := range crc.Catalogue {
+		v, err := crc.Checksum(p, check)
+		if err != nil {
+			t.Fatalf("%s error: %v", p.Name, err)
+		}
+		if v != p.Check {
+			t.Fatalf("%s returns %#x, want %#x", p.Name, v, p.Check)
+		}
+
+		// bit by bit (RefIn is applied by caller)
+		d, _ := crc.New(p)
+		for _, b := range check {
+			for i := 0; i < 8; i++ {
+				if p.RefIn {
+					d.WriteBit(b>>uint(i), 1)
+				} else {
+					d.WriteBit(b>>uint(7-i), 1)
+				}
+			}
+		}
+		if v := d.Sum64(); v != p.Check {
+			t.Fatalf("%s bit by bit returns %#x, want %#x", p.Name, v, p.Check)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if p := crc.Lookup("crc-32/mpeg-2"); p != crc.CRC32MPEG2 {
+		t.Fatalf("Lookup(crc-32/mpeg-2) returns %v", p)
+	}
+	if p := crc.Lookup("crc32_iso_hdlc"); p != crc.CRC32ISOHDLC {
+		t.Fatalf("Lookup(crc32_iso_hdlc) returns %v", p)
+	}
+	if p := crc.Lookup("CRC-32"); p != nil {
+		t.Fatalf("Lookup(CRC-32) returns %v, want nil", p)
+	}
+}
+
+func TestDigest_Stdlib(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	data := make([]byte, 1000)
+	rnd.Read(data)
+
+	tests := []struct {
+		p   *crc.Params
+		std hash.Hash64
+	}{
+		{crc.CRC64GOISO, crc64.New(crc64.MakeTable(crc64.ISO))},
+		{crc.CRC64XZ, crc64.New(crc64.MakeTable(crc64.ECMA))},
+	}
+	for _, tt := range tests {
+		d, _ := crc.New(tt.p)
+		d.Write(data)
+		tt.std.Write(data)
+		if d.Sum64() != tt.std.Sum64() {
+			t.Fatalf("%s returns %#x, want %#x", tt.p.Name, d.Sum64(), tt.std.Sum64())
+		}
+		if !bytes.Equal(d.Sum(nil), tt.std.Sum(nil)) {
+			t.Fatalf("%s Sum returns %x, want %x", tt.p.Name, d.Sum(nil), tt.std.Sum(nil))
+		}
+	}
+
+	if v, _ := crc.Checksum(crc.CRC32ISCSI, data); v != uint64(crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))) {
+		t.Fatalf("CRC-32/ISCSI returns %#x", v)
+	}
+}
+
+func TestDigest_Bits(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	data := make([]byte, 64)
+	rnd.Read(data)
+
+	// WriteBits of whole bytes equals Write without RefIn
+	exp, _ := crc.Checksum(crc.CRC32MPEG2, data)
+	d, _ := crc.New(crc.CRC32MPEG2)
+	if n, err := d.WriteBits(data, 8*len(data)); err != nil || n != 8*len(data) {
+		t.Fatalf("WriteBits returns %d, %v", n, err)
+	}
+	if d.Sum64() != exp {
+		t.Fatalf("WriteBits returns %#x, want %#x", d.Sum64(), exp)
+	}
+
+	// split at any bit position
+	for n := 0; n <= 8*len(data); n += 13 {
+		d.Reset()
+		for i := 0; i < n; i++ {
+			d.WriteBit(data[i/8]>>uint(7-i%8), 1)
+		}
+		bit := bitsOf(data, n)
+		d.WriteBits(bit, 8*len(data)-n)
+		if d.Sum64() != exp {
+			t.Fatalf("split at %d returns %#x, want %#x", n, d.Sum64(), exp)
+		}
+	}
+
+	// 12 bits is right justified
+	d.Reset()
+	d.WriteBit(0xa, 4)
+	d.WriteBit(0xbc, 8)
+	exp = d.Sum64()
+	d.Reset()
+	d.WriteBits([]byte{0xff, 0x0a, 0xbc}, 12)
+	if d.Sum64() != exp {
+		t.Fatalf("WriteBits(0abc, 12) returns %#x, want %#x", d.Sum64(), exp)
+	}
+}
+
+// bitsOf returns right justified bits of data after n bits.
+func bitsOf(data []byte, n int) []byte {
+	size := 8*len(data) - n
+	dst := make([]byte, (size+7)/8)
+	for i := 0; i < size; i++ {
+		k := n + i
+		bit := data[k/8] >> uint(7-k%8) & 1
+		j := i + 8*len(dst) - size
+		dst[j/8] |= bit << uint(7-j%8)
+	}
+	return dst
+}
+
+func TestDigest_Error(t *testing.T) {
+	invalid := []*crc.Params{
+		{Width: 0},
+		{Width: 65},
+		{Width: 5, Poly: 0x25},
+		{Width: 8, Poly: 0x07, Init: 0x100},
+	}
+	for _, p := range invalid {
+		if _, err := crc.New(p); !errors.Is(err, crc.ErrInvalidParams) {
+			t.Fatalf("New(%+v) error %v, want %v", p, err, crc.ErrInvalidParams)
+		}
+	}
+
+	d, _ := crc.New(crc.CRC8SMBUS)
+	if _, err := d.WriteBit(0, 9); err == nil {
+		t.Fatalf("WriteBit(9) must be error")
+	}
+	if _, err := d.WriteBits([]byte{0}, 9); err == nil {
+		t.Fatalf("WriteBits(9) of 1 byte must be error")
+	}
+}
+
+func BenchmarkDigest(b *testing.B) {
+	data := make([]byte, 4096)
+	d, _ := crc.New(crc.CRC32MPEG2)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		d.Write(data)
+	}
+}
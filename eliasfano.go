@@ -0,0 +1,202 @@
+package bitio
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// EliasFano is Elias-Fano representation of non-decreasing uint64 sequence.
+// Values are split into low bits of fixed width and high bits in unary,
+// and can be accessed without decoding the whole sequence.
+//
+// Serialized form is:
+//
+//	count      64 bit
+//	low width  7 bit (0..63)
+//	high size  64 bit (count + (last >> low width) + 1)
+//	low bits   count x low width bits
+//	high bits  high size bits (bit i is 1 if (value >> low width) + index = i)
+type EliasFano struct {
+	n        int
+	lowBits  int
+	low      bitWords
+	high     *BitVector
+	highSize int
+	last     uint64
+}
+
+// NewEliasFano returns EliasFano of values, which must be non-decreasing.
+func NewEliasFano(values []uint64) (*EliasFano, error) {
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1] {
+			return nil, fmt.Errorf("bitio: element %d (%d) is less than previous value %d", i, values[i], values[i-1])
+		}
+	}
+
+	ef := &EliasFano{n: len(values)}
+	if ef.n > 0 {
+		ef.last = values[ef.n-1]
+		if ratio := ef.last / uint64(ef.n); ratio > 0 {
+			ef.lowBits = bits.Len64(ratio) - 1
+		}
+	}
+	ef.highSize = ef.n + int(ef.last>>uint(ef.lowBits)) + 1
+
+	ef.low = newBitWords(ef.n * ef.lowBits)
+	ef.high = NewBitVector(ef.highSize)
+	mask := uint64(1)<<uint(ef.lowBits) - 1
+	for i, v := range values {
+		ef.low.setBits(i*ef.lowBits, ef.lowBits, v&mask)
+		ef.high.words.set(int(v>>uint(ef.lowBits)) + i)
+	}
+	ef.high.BuildIndex()
+	return ef, nil
+}
+
+// Len returns number of values.
+func (ef *EliasFano) Len() int {
+	return ef.n
+}
+
+// BitLen returns bit size of serialized form.
+func (ef *EliasFano) BitLen() int {
+	return 64 + 7 + 64 + ef.n*ef.lowBits + ef.highSize
+}
+
+// Access returns the i-th value.
+func (ef *EliasFano) Access(i int) (uint64, error) {
+	if i < 0 || i >= ef.n {
+		return 0, fmt.Errorf("bitio: index %d is out of range [0, %d)", i, ef.n)
+	}
+	return ef.value(i, ef.high.Select1(i)), nil
+}
+
+// NextGEQ returns index and value of the first value which is greater than or equal to x.
+// If there is no such value, ok is false.
+func (ef *EliasFano) NextGEQ(x uint64) (i int, v uint64, ok bool) {
+	if ef.n == 0 || x > ef.last {
+		return ef.n, 0, false
+	}
+
+	// values of high part hx start after the hx-th 0
+	hx := int(x >> uint(ef.lowBits))
+	pos := 0
+	if hx > 0 {
+		pos = ef.high.Select0(hx-1) + 1
+	}
+	i = pos - hx
+
+	for pos = ef.high.nextOne(pos); ; pos = ef.high.nextOne(pos + 1) {
+		if v = ef.value(i, pos); v >= x {
+			return i, v, true
+		}
+		i++
+	}
+}
+
+// value returns the i-th value, whose high bit is at pos.
+func (ef *EliasFano) value(i, pos int) uint64 {
+	return uint64(pos-i)<<uint(ef.lowBits) | ef.low.getBits(i*ef.lowBits, ef.lowBits)
+}
+
+// Values returns all values.
+func (ef *EliasFano) Values() []uint64 {
+	values := make([]uint64, ef.n)
+	pos := -1
+	for i := range values {
+		pos = ef.high.nextOne(pos + 1)
+		values[i] = ef.value(i, pos)
+	}
+	return values
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// WriteEliasFano writes serialized form of EliasFano.
+// Return error if writing to writer fails.
+func WriteEliasFano(bw BitWriter, ef *EliasFano) error {
+	buf := make([]byte, 8)
+	if err := writeUint64(bw, 64, BigEndian, uint64(ef.n), buf); err != nil {
+		return err
+	}
+	if err := writeUint64(bw, 7, BigEndian, uint64(ef.lowBits), buf); err != nil {
+		return err
+	}
+	if err := writeUint64(bw, 64, BigEndian, uint64(ef.highSize), buf); err != nil {
+		return err
+	}
+
+	if ef.lowBits > 0 {
+		low := make([]uint64, ef.n)
+		for i := range low {
+			low[i] = ef.low.getBits(i*ef.lowBits, ef.lowBits)
+		}
+		if err := WritePackedUints(bw, ef.lowBits, low); err != nil {
+			return err
+		}
+	}
+
+	return writeBitWords(bw, ef.high.words, ef.highSize, buf)
+}
+
+// ReadEliasFano reads serialized form of EliasFano.
+// Return error if reading from reader fails or data is invalid.
+func ReadEliasFano(br BitReader) (*EliasFano, error) {
+	buf := make([]byte, 8)
+	n, err := readUint64(br, 64, BigEndian, buf)
+	if err != nil {
+		return nil, err
+	}
+	lowBits, err := readUint64(br, 7, BigEndian, buf)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	highSize, err := readUint64(br, 64, BigEndian, buf)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	// high bits have n ones and at least 1 zero
+	if lowBits > 63 || highSize == 0 || highSize > 1<<40 || n > highSize-1 {
+		return nil, fmt.Errorf("bitio: invalid Elias-Fano header, count %d, low width %d, high size %d", n, lowBits, highSize)
+	}
+
+	// bit arrays grow while reading, not to allocate by corrupted header
+	ef := &EliasFano{n: int(n), lowBits: int(lowBits), highSize: int(highSize)}
+
+	if ef.lowBits > 0 {
+		low := make([]uint64, min(ef.n, readChunk))
+		for i := 0; i < ef.n; i += len(low) {
+			chunk := low[:min(len(low), ef.n-i)]
+			if err := ReadPackedUints(br, ef.lowBits, chunk); err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if size := len(newBitWords((i + len(chunk)) * ef.lowBits)); size > len(ef.low) {
+				ef.low = append(ef.low, make(bitWords, size-len(ef.low))...)
+			}
+			for j, v := range chunk {
+				ef.low.setBits((i+j)*ef.lowBits, ef.lowBits, v)
+			}
+		}
+	}
+
+	words, err := readBitWords(br, ef.highSize, buf)
+	if err != nil {
+		return nil, err
+	}
+	ef.high = &BitVector{words: words, n: ef.highSize}
+	ef.high.BuildIndex()
+
+	if ones := ef.high.Rank1(ef.highSize); ones != ef.n {
+		return nil, fmt.Errorf("bitio: Elias-Fano high bits have %d ones, want %d", ones, ef.n)
+	}
+	if ef.n > 0 {
+		// the last high bit must be the last value
+		ef.last = ef.value(ef.n-1, ef.high.Select1(ef.n-1))
+		if ef.highSize != ef.n+int(ef.last>>uint(ef.lowBits))+1 {
+			return nil, errors.New("bitio: Elias-Fano high bits are not terminated by the last value")
+		}
+	}
+	return ef, nil
+}
@@ -0,0 +1,167 @@
+package bitio_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+func randomSorted(rnd *rand.Rand, n int, max uint64) []uint64 {
+	values := make([]uint64, n)
+	for i := range values {
+		values[i] = rnd.Uint64() % max
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+func testEliasFano(t *testing.T, values []uint64) {
+	ef, err := bitio.NewEliasFano(values)
+	if err != nil {
+		t.Fatalf("NewEliasFano(%d values) error: %v", len(values), err)
+	}
+	if ef.Len() != len(values) {
+		t.Fatalf("Len() returns %d, want %d", ef.Len(), len(values))
+	}
+
+	for i, exp := range values {
+		if v, err := ef.Access(i); err != nil || v != exp {
+			t.Fatalf("Access(%d) returns %d, %v, want %d", i, v, err, exp)
+		}
+	}
+	if got := ef.Values(); len(values) > 0 && !reflect.DeepEqual(got, values) {
+		t.Fatalf("Values() returns %v, want %v", got, values)
+	}
+
+	// NextGEQ of values, neighbors and beyond the last value
+	queries := []uint64{0, math.MaxUint64}
+	for _, v := range values {
+		queries = append(queries, v, v+1, v-1)
+	}
+	for _, x := range queries {
+		exp := sort.Search(len(values), func(i int) bool { return values[i] >= x })
+		i, v, ok := ef.NextGEQ(x)
+		if ok != (exp < len(values)) || (ok && (i != exp || v != values[exp])) {
+			t.Fatalf("NextGEQ(%d) returns %d, %d, %v, want index %d", x, i, v, ok, exp)
+		}
+	}
+
+	// serialization
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	w.WriteBit(0x1, 3)
+	if err := bitio.WriteEliasFano(w, ef); err != nil {
+		t.Fatalf("WriteEliasFano() error: %v", err)
+	}
+	w.Flush()
+	if exp := (3 + ef.BitLen() + 7) / 8; b.Len() != exp {
+		t.Fatalf("WriteEliasFano() writes %d bytes, want %d", b.Len(), exp)
+	}
+
+	r := bitio.NewBitReadBuffer(b)
+	var head byte
+	r.ReadBit(&head, 3)
+	got, err := bitio.ReadEliasFano(r)
+	if err != nil {
+		t.Fatalf("ReadEliasFano() error: %v", err)
+	}
+	if len(values) > 0 && !reflect.DeepEqual(got.Values(), values) {
+		t.Fatalf("ReadEliasFano() reads %v, want %v", got.Values(), values)
+	}
+	if _, _, ok := got.NextGEQ(0); ok != (len(values) > 0) {
+		t.Fatalf("NextGEQ(0) of read EliasFano returns %v", ok)
+	}
+}
+
+func TestEliasFano(t *testing.T) {
+	testEliasFano(t, nil)
+	testEliasFano(t, []uint64{0})
+	testEliasFano(t, []uint64{5})
+	testEliasFano(t, []uint64{0, 0, 0, 0})
+	testEliasFano(t, []uint64{2, 3, 5, 7, 11, 13, 24})
+	testEliasFano(t, []uint64{0, math.MaxUint64})
+	testEliasFano(t, []uint64{math.MaxUint64 - 1, math.MaxUint64, math.MaxUint64})
+
+	rnd := rand.New(rand.NewSource(1))
+	testEliasFano(t, randomSorted(rnd, 3000, 1<<20))
+	testEliasFano(t, randomSorted(rnd, 3000, 3000))
+	testEliasFano(t, randomSorted(rnd, 3000, 100))
+}
+
+func TestEliasFano_Size(t *testing.T) {
+	// about 2 + log2(u/n) bits per value
+	rnd := rand.New(rand.NewSource(1))
+	ef, _ := bitio.NewEliasFano(randomSorted(rnd, 100000, 1<<30))
+	if bits := ef.BitLen(); bits > 100000*(2+14)+200 {
+		t.Fatalf("BitLen() returns %d bits", bits)
+	}
+}
+
+func TestEliasFano_Error(t *testing.T) {
+	if _, err := bitio.NewEliasFano([]uint64{1, 3, 2}); err == nil {
+		t.Fatalf("NewEliasFano() of decreasing values must be error")
+	}
+
+	ef, _ := bitio.NewEliasFano([]uint64{2, 3, 5, 7, 11})
+	if _, err := ef.Access(5); err == nil {
+		t.Fatalf("Access(5) must be error")
+	}
+	if _, err := ef.Access(-1); err == nil {
+		t.Fatalf("Access(-1) must be error")
+	}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	bitio.WriteEliasFano(w, ef)
+	w.Flush()
+	data := b.Bytes()
+
+	if _, err := bitio.ReadEliasFano(bitio.NewBitReadBuffer(bytes.NewReader(data[:len(data)-2]))); err != io.ErrUnexpectedEOF {
+		t.Fatalf("ReadEliasFano() of truncated data error %v, want ErrUnexpectedEOF", err)
+	}
+
+	// count is larger than ones of high bits
+	broken := append([]byte{}, data...)
+	broken[7]++
+	if _, err := bitio.ReadEliasFano(bitio.NewBitReadBuffer(bytes.NewReader(broken))); err == nil {
+		t.Fatalf("ReadEliasFano() of broken data must be error")
+	}
+
+	// huge header
+	huge := bytes.Repeat([]byte{0xff}, 32)
+	if _, err := bitio.ReadEliasFano(bitio.NewBitReadBuffer(bytes.NewReader(huge))); err == nil {
+		t.Fatalf("ReadEliasFano() of huge header must be error")
+	}
+}
+
+func benchmarkEliasFano(b *testing.B) (*bitio.EliasFano, []uint64) {
+	rnd := rand.New(rand.NewSource(1))
+	values := randomSorted(rnd, 1<<20, 1<<32)
+	ef, _ := bitio.NewEliasFano(values)
+	queries := make([]uint64, 4096)
+	for i := range queries {
+		queries[i] = rnd.Uint64() % (1 << 32)
+	}
+	b.ResetTimer()
+	return ef, queries
+}
+
+func BenchmarkEliasFano_Access(b *testing.B) {
+	ef, queries := benchmarkEliasFano(b)
+	for i := 0; i < b.N; i++ {
+		ef.Access(int(queries[i%len(queries)] % uint64(ef.Len())))
+	}
+}
+
+func BenchmarkEliasFano_NextGEQ(b *testing.B) {
+	ef, queries := benchmarkEliasFano(b)
+	for i := 0; i < b.N; i++ {
+		ef.NextGEQ(queries[i%len(queries)])
+	}
+}
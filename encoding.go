@@ -0,0 +1,100 @@
+package bitio
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// valueEncoding is variable length encoding of integer bit-field value.
+// Signed value is passed as uint64 bits of int64.
+type valueEncoding interface {
+	// read reads encoded value and returns value and read size.
+	// rv is struct value which has the field.
+	read(r BitReader, rv reflect.Value, buf []byte) (v uint64, nBit int, err error)
+	// write writes encoded value and returns write size.
+	write(w BitWriter, rv reflect.Value, v uint64, buf []byte) (nBit int, err error)
+	// bitLen returns encoded bit size of value.
+	bitLen(rv reflect.Value, v uint64) int
+}
+
+// encodingContext is the information of field to build valueEncoding.
+type encodingContext struct {
+	name   string            // encoding name (ex: "egk" of "egk:3")
+	param  string            // encoding parameter (ex: "3" of "egk:3")
+	tag    reflect.StructTag // field tag
+	signed bool              // field is signed integer
+	field  func(name string) (int, bool)
+}
+
+// valueEncodings maps encoding name of tag to constructor of valueEncoding.
+var valueEncodings = map[string]func(ctx *encodingContext) (valueEncoding, error){
+	"ue":     newExpGolombEncoding,
+	"se":     newExpGolombEncoding,
+	"egk":    newExpGolombEncoding,
+	"rice":   newRiceEncoding,
+	"golomb": newRiceEncoding,
+
+	"uleb128": newVarintEncoding,
+	"sleb128": newVarintEncoding,
+	"zigzag":  newVarintEncoding,
+	"vlq":     newVarintEncoding,
+
+	"quicvar": newPrefixIntEncoding,
+	"hpack":   newPrefixIntEncoding,
+}
+
+// compileEncoding returns valueEncoding of `encoding` tag.
+// field returns struct field index of preceding integer field by name.
+func compileEncoding(tag reflect.StructTag, kind reflect.Kind, field func(name string) (int, bool)) (valueEncoding, error) {
+	v, ok := tag.Lookup("encoding")
+	if !ok {
+		return nil, nil
+	}
+
+	if !isIntegerKind(kind) {
+		return nil, invalidTag("encoding %q needs integer type", v)
+	}
+	if _, ok := tag.Lookup("bit"); ok {
+		return nil, invalidTag("encoding %q does not need size", v)
+	}
+	if _, ok := tag.Lookup("byte"); ok {
+		return nil, invalidTag("encoding %q does not need size", v)
+	}
+
+	name, param, _ := strings.Cut(v, ":")
+	build, ok := valueEncodings[name]
+	if !ok {
+		return nil, invalidTag("encoding %q", v)
+	}
+
+	ctx := &encodingContext{
+		name:   name,
+		param:  param,
+		tag:    tag,
+		signed: isSignedKind(kind),
+		field:  field,
+	}
+	enc, err := build(ctx)
+	if err != nil {
+		return nil, invalidTag("encoding %q, %v", v, err)
+	}
+	return enc, nil
+}
+
+// unsignedValue checks signed value is not negative.
+func unsignedValue(v uint64, signed bool) (uint64, error) {
+	if signed && int64(v) < 0 {
+		return 0, fmt.Errorf("negative value %d: %w", int64(v), ErrValueOverflow)
+	}
+	return v, nil
+}
+
+func isSignedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
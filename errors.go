@@ -0,0 +1,73 @@
+package bitio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+var (
+	// ErrUnexpectedEOF means that EOF was encountered in the middle of reading bit-field.
+	// It is the same as io.ErrUnexpectedEOF.
+	ErrUnexpectedEOF = io.ErrUnexpectedEOF
+
+	// ErrValueOverflow means that value does not fit in bit-field size.
+	ErrValueOverflow = errors.New("value overflow")
+
+	// ErrInvalidTag means that struct tag is invalid.
+	ErrInvalidTag = errors.New("invalid tag")
+
+	// ErrUnresolvedPlaceholder means that placeholder of BufferedBitWriter is not filled.
+	ErrUnresolvedPlaceholder = errors.New("unresolved placeholder")
+)
+
+// FieldError describes an error of reading/writing struct field.
+type FieldError struct {
+	Struct reflect.Type      // top-level struct type
+	Field  string            // dotted field path (ex: "Header.Entries[2].Size")
+	Offset int               // bit offset from start of struct (-1: not reading/writing)
+	Tag    reflect.StructTag // tag of the field
+	Err    error             // cause
+}
+
+func (e *FieldError) Error() string {
+	name := "struct"
+	if e.Struct != nil && e.Struct.Name() != "" {
+		name = e.Struct.String()
+	}
+
+	if e.Offset < 0 {
+		return fmt.Sprintf("bitio: %s.%s: %v", name, e.Field, e.Err)
+	}
+	return fmt.Sprintf("bitio: %s.%s (bit offset %d): %v", name, e.Field, e.Offset, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ChecksumError means that checksum of read data does not match the computed checksum.
+type ChecksumError struct {
+	Name     string // CRC name (ex: "CRC-32/MPEG-2")
+	Read     uint64 // checksum in data
+	Computed uint64 // checksum computed over the range of fields
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch, read %#x, computed %#x", e.Name, e.Read, e.Computed)
+}
+
+// invalidTag returns error wrapping ErrInvalidTag.
+func invalidTag(format string, args ...interface{}) error {
+	return fmt.Errorf("%w, "+format, append([]interface{}{ErrInvalidTag}, args...)...)
+}
+
+// unexpectedEOF converts io.EOF to ErrUnexpectedEOF.
+// It is used when EOF is encountered in the middle of value.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,146 @@
+package bitio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+type errorEntry struct {
+	Kind uint8  `bit:"4"`
+	Size uint16 `bit:"12" endian:"big"`
+}
+
+type errorRecord struct {
+	Count   uint8        `bit:"8"`
+	Entries []errorEntry `len:"Count"`
+}
+
+func TestFieldError_Read(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    []byte
+		field  string
+		offset int
+		cause  error
+	}{
+		{
+			name:   "EOF at start",
+			raw:    []byte{},
+			field:  "Count",
+			offset: 0,
+			cause:  io.EOF,
+		},
+		{
+			name:   "EOF at slice element",
+			raw:    []byte{0x03, 0x11, 0x23},
+			field:  "Entries[1].Kind",
+			offset: 24,
+			cause:  bitio.ErrUnexpectedEOF,
+		},
+		{
+			name:   "EOF in the middle of value",
+			raw:    []byte{0x03, 0x11, 0x23, 0x45},
+			field:  "Entries[1].Size",
+			offset: 28,
+			cause:  bitio.ErrUnexpectedEOF,
+		},
+		{
+			name:   "invalid length",
+			raw:    []byte{0x00},
+			field:  "Entries",
+			offset: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		r := bitio.NewBitFieldReader(bytes.NewReader(tt.raw))
+		_, err := r.ReadStruct(&errorRecord{})
+
+		var fe *bitio.FieldError
+		if errors.As(err, &fe) == false {
+			t.Fatalf("%q error %v, want FieldError", tt.name, err)
+		}
+		if fe.Struct != reflect.TypeOf(errorRecord{}) {
+			t.Fatalf("%q error struct %v, want %v", tt.name, fe.Struct, reflect.TypeOf(errorRecord{}))
+		}
+		if fe.Field != tt.field {
+			t.Fatalf("%q error field %q, want %q", tt.name, fe.Field, tt.field)
+		}
+		if fe.Offset != tt.offset {
+			t.Fatalf("%q error offset %d, want %d", tt.name, fe.Offset, tt.offset)
+		}
+		if tt.cause != nil && errors.Is(err, tt.cause) == false {
+			t.Fatalf("%q error %v, want %v", tt.name, err, tt.cause)
+		}
+	}
+}
+
+func TestFieldError_Write(t *testing.T) {
+	v := &struct {
+		Count uint8   `bit:"2"`
+		Data  []uint8 `bit:"4" len:"Count"`
+	}{
+		Data: []uint8{1, 2, 3, 4},
+	}
+
+	w := bitio.NewBitFieldWriter(io.Discard)
+	_, err := w.WriteStruct(v)
+
+	var fe *bitio.FieldError
+	if errors.As(err, &fe) == false {
+		t.Fatalf("WriteStruct error %v, want FieldError", err)
+	}
+	if fe.Field != "Count" || fe.Offset != 0 || fe.Tag != `bit:"2"` {
+		t.Fatalf("WriteStruct error %#v, want Count field at 0", fe)
+	}
+	if errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteStruct error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+}
+
+func TestFieldError_InvalidTag(t *testing.T) {
+	type inner struct {
+		Val int `bit:"x"`
+	}
+	type outer struct {
+		Head  int     `bit:"4"`
+		Inner []inner `len:"2"`
+	}
+
+	_, err := bitio.Compile[outer]()
+
+	var fe *bitio.FieldError
+	if errors.As(err, &fe) == false {
+		t.Fatalf("Compile error %v, want FieldError", err)
+	}
+	if fe.Field != "Inner.Val" || fe.Offset != -1 || fe.Tag != `bit:"x"` {
+		t.Fatalf("Compile error %#v, want Inner.Val field", fe)
+	}
+	if errors.Is(err, bitio.ErrInvalidTag) == false {
+		t.Fatalf("Compile error %v, want %v", err, bitio.ErrInvalidTag)
+	}
+
+	exp := `bitio: bitio_test.outer.Inner.Val: invalid tag, size "x" bit(s)`
+	if err.Error() != exp {
+		t.Fatalf("Compile error message %q, want %q", err.Error(), exp)
+	}
+}
+
+func TestFieldError_Error(t *testing.T) {
+	err := &bitio.FieldError{
+		Struct: reflect.TypeOf(errorRecord{}),
+		Field:  "Entries[1].Size",
+		Offset: 28,
+		Err:    bitio.ErrUnexpectedEOF,
+	}
+
+	exp := "bitio: bitio_test.errorRecord.Entries[1].Size (bit offset 28): unexpected EOF"
+	if err.Error() != exp {
+		t.Fatalf("Error message %q, want %q", err.Error(), exp)
+	}
+}
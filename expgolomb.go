@@ -0,0 +1,233 @@
+package bitio
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strconv"
+)
+
+// ReadUE reads unsigned Exp-Golomb code ue(v).
+func ReadUE(r BitReader) (uint64, error) {
+	return ReadExpGolomb(r, 0)
+}
+
+// ReadSE reads signed Exp-Golomb code se(v).
+func ReadSE(r BitReader) (int64, error) {
+	v, err := ReadExpGolomb(r, 0)
+	if err != nil {
+		return 0, err
+	}
+	return unmapSE(v), nil
+}
+
+// WriteUE writes v as unsigned Exp-Golomb code ue(v).
+func WriteUE(w BitWriter, v uint64) error {
+	return WriteExpGolomb(w, 0, v)
+}
+
+// WriteSE writes v as signed Exp-Golomb code se(v).
+func WriteSE(w BitWriter, v int64) error {
+	u, err := mapSE(v)
+	if err != nil {
+		return err
+	}
+	return WriteExpGolomb(w, 0, u)
+}
+
+// ReadExpGolomb reads k-th order Exp-Golomb code.
+// Returns error if the code exceeds 64 bit value.
+func ReadExpGolomb(r BitReader, k int) (uint64, error) {
+	_, v, err := readExpGolomb(r, k, make([]byte, 8))
+	return v, err
+}
+
+// WriteExpGolomb writes v as k-th order Exp-Golomb code.
+// Returns error if the code exceeds 64 bit value.
+func WriteExpGolomb(w BitWriter, k int, v uint64) error {
+	_, err := writeExpGolomb(w, k, v, make([]byte, 8))
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// readExpGolomb reads k-th order Exp-Golomb code and returns read size and value.
+// buf is a work space of 8 bytes.
+func readExpGolomb(r BitReader, k int, buf []byte) (int, uint64, error) {
+	if k < 0 || k > 63 {
+		return 0, 0, fmt.Errorf("bitio: exp-golomb order %d is out of range", k)
+	}
+
+	zeros, err := readZeros(r, 63-k, buf)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// x = 1 (prefix end) + (zeros + k) bits
+	n := zeros + k
+	x := uint64(1) << uint(n)
+	if n > 0 {
+		low, err := readUint64(r, n, BigEndian, buf)
+		if err != nil {
+			return 0, 0, unexpectedEOF(err)
+		}
+		x |= low
+	}
+
+	return zeros + 1 + n, x - 1<<uint(k), nil
+}
+
+// writeExpGolomb writes v as k-th order Exp-Golomb code and returns write size.
+// buf is a work space of 8 bytes.
+func writeExpGolomb(w BitWriter, k int, v uint64, buf []byte) (int, error) {
+	if k < 0 || k > 63 {
+		return 0, fmt.Errorf("bitio: exp-golomb order %d is out of range", k)
+	}
+
+	x, carry := bits.Add64(v, 1<<uint(k), 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("exp-golomb value %d exceeds 64 bit: %w", v, ErrValueOverflow)
+	}
+
+	n := bits.Len64(x)
+	zeros := n - k - 1
+	if zeros > 0 {
+		if err := writeUint64(w, zeros, BigEndian, 0, buf); err != nil {
+			return 0, err
+		}
+	}
+	if err := writeUint64(w, n, BigEndian, x, buf); err != nil {
+		return 0, err
+	}
+
+	return zeros + n, nil
+}
+
+// expGolombLen returns bit size of v as k-th order Exp-Golomb code.
+func expGolombLen(k int, v uint64) int {
+	// x = v + 2^k, code = (n-k-1) zeros + x (n bits)
+	x, carry := bits.Add64(v, 1<<uint(k), 0)
+	n := bits.Len64(x)
+	if carry != 0 {
+		n = 65
+	}
+	return 2*n - k - 1
+}
+
+// mapSE maps signed value to se(v) code number.
+// (0, 1, -1, 2, -2, ...) -> (0, 1, 2, 3, 4, ...)
+func mapSE(v int64) (uint64, error) {
+	if v > 0 {
+		return uint64(v)*2 - 1, nil
+	}
+	if v == -1<<63 {
+		return 0, fmt.Errorf("exp-golomb value %d exceeds 64 bit: %w", v, ErrValueOverflow)
+	}
+	return uint64(-v) * 2, nil
+}
+
+// unmapSE maps se(v) code number to signed value.
+func unmapSE(u uint64) int64 {
+	if u%2 == 1 {
+		return int64(u/2) + 1
+	}
+	return -int64(u / 2)
+}
+
+// zeroScanner is implemented by BitReader which can scan zero bits fast.
+type zeroScanner interface {
+	scanZeros(max int) (int, error)
+}
+
+// readZeros reads zero bits until 1 bit, and returns the number of zero bits.
+// The terminating 1 bit is consumed.
+// Returns error if the number of zero bits exceeds max.
+func readZeros(r BitReader, max int, buf []byte) (int, error) {
+	if s, ok := r.(zeroScanner); ok {
+		return s.scanZeros(max)
+	}
+
+	for zeros := 0; zeros <= max; zeros++ {
+		if n, err := r.ReadBits(buf[:1], 1); err != nil {
+			if zeros > 0 {
+				err = unexpectedEOF(err)
+			}
+			return 0, err
+		} else if n != 1 {
+			return 0, fmt.Errorf("insufficient size of read, want 1 bit, read %d bit: %w", n, ErrUnexpectedEOF)
+		}
+		if buf[0]&1 == 1 {
+			return zeros, nil
+		}
+	}
+	return 0, fmt.Errorf("unary prefix exceeds %d bit: %w", max, ErrValueOverflow)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// expGolombEncoding is valueEncoding of `encoding:"ue"`, `encoding:"se"` and `encoding:"egk:N"`.
+type expGolombEncoding struct {
+	k      int
+	se     bool // signed mapping se(v)
+	signed bool // field is signed integer
+}
+
+func newExpGolombEncoding(ctx *encodingContext) (valueEncoding, error) {
+	enc := &expGolombEncoding{signed: ctx.signed}
+
+	switch ctx.name {
+	case "ue", "se":
+		if ctx.param != "" {
+			return nil, fmt.Errorf("unexpected parameter %q", ctx.param)
+		}
+		if ctx.name == "se" {
+			if !ctx.signed {
+				return nil, fmt.Errorf("se needs signed integer type")
+			}
+			enc.se = true
+		}
+	default:
+		k, err := strconv.Atoi(ctx.param)
+		if err != nil || k < 0 || k > 63 {
+			return nil, fmt.Errorf("order %q is out of range [0, 63]", ctx.param)
+		}
+		enc.k = k
+	}
+
+	return enc, nil
+}
+
+func (enc *expGolombEncoding) read(r BitReader, rv reflect.Value, buf []byte) (uint64, int, error) {
+	n, u, err := readExpGolomb(r, enc.k, buf)
+	if err != nil {
+		return 0, 0, err
+	}
+	if enc.se {
+		return uint64(unmapSE(u)), n, nil
+	}
+	if enc.signed && int64(u) < 0 {
+		return 0, 0, fmt.Errorf("exp-golomb value %d exceeds int64: %w", u, ErrValueOverflow)
+	}
+	return u, n, nil
+}
+
+func (enc *expGolombEncoding) write(w BitWriter, rv reflect.Value, v uint64, buf []byte) (int, error) {
+	u, err := enc.codeNum(v)
+	if err != nil {
+		return 0, err
+	}
+	return writeExpGolomb(w, enc.k, u, buf)
+}
+
+func (enc *expGolombEncoding) bitLen(rv reflect.Value, v uint64) int {
+	u, _ := enc.codeNum(v)
+	return expGolombLen(enc.k, u)
+}
+
+// codeNum returns code number of value v.
+func (enc *expGolombEncoding) codeNum(v uint64) (uint64, error) {
+	if enc.se {
+		return mapSE(int64(v))
+	}
+	return unsignedValue(v, enc.signed)
+}
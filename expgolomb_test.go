@@ -0,0 +1,210 @@
+package bitio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+var expGolombTests = []struct {
+	k   int
+	v   uint64
+	raw string
+}{
+	{0, 0, "1"},
+	{0, 1, "010"},
+	{0, 2, "011"},
+	{0, 3, "00100"},
+	{0, 4, "00101"},
+	{0, 7, "0001000"},
+	{1, 0, "10"},
+	{1, 1, "11"},
+	{1, 2, "0100"},
+	{1, 5, "0111"},
+	{3, 0, "1000"},
+	{3, 8, "0_10000"},
+	{0, 1<<64 - 2, "0000000000000000000000000000000000000000000000000000000000000001_111111111111111111111111111111111111111111111111111111111111111"},
+}
+
+func TestWriteExpGolomb(t *testing.T) {
+	for _, tt := range expGolombTests {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		if err := bitio.WriteExpGolomb(w, tt.k, tt.v); err != nil {
+			t.Fatalf("WriteExpGolomb(%d, %d) error: %v", tt.k, tt.v, err)
+		}
+		w.Flush()
+
+		if exp := binaryToByteArray(tt.raw); reflect.DeepEqual(b.Bytes(), exp) == false {
+			t.Fatalf("WriteExpGolomb(%d, %d) write %#v, want %#v", tt.k, tt.v, b.Bytes(), exp)
+		}
+	}
+}
+
+func TestReadExpGolomb(t *testing.T) {
+	for _, tt := range expGolombTests {
+		r := bitio.NewBitReadBuffer(bytes.NewReader(binaryToByteArray(tt.raw)))
+		v, err := bitio.ReadExpGolomb(r, tt.k)
+		if err != nil {
+			t.Fatalf("ReadExpGolomb(%d) %q error: %v", tt.k, tt.raw, err)
+		}
+		if v != tt.v {
+			t.Fatalf("ReadExpGolomb(%d) %q read %d, want %d", tt.k, tt.raw, v, tt.v)
+		}
+	}
+}
+
+func TestExpGolomb_UESE(t *testing.T) {
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	ue := []uint64{0, 1, 2, 3}
+	se := []int64{0, 1, -1, 2, -2, -1<<63 + 1}
+	for _, v := range ue {
+		if err := bitio.WriteUE(w, v); err != nil {
+			t.Fatalf("WriteUE(%d) error: %v", v, err)
+		}
+	}
+	for _, v := range se {
+		if err := bitio.WriteSE(w, v); err != nil {
+			t.Fatalf("WriteSE(%d) error: %v", v, err)
+		}
+	}
+	w.Flush()
+
+	// ue: 1 010 011 00100, se: 1 010 011 00100 00101
+	if exp := binaryToByteArray("1010011_00100_1010011_00100_00101"); bytes.HasPrefix(b.Bytes(), exp[:2]) == false {
+		t.Fatalf("WriteUE/WriteSE write %#v, want prefix %#v", b.Bytes(), exp[:2])
+	}
+
+	r := bitio.NewBitReadBuffer(b)
+	for _, exp := range ue {
+		if v, err := bitio.ReadUE(r); err != nil || v != exp {
+			t.Fatalf("ReadUE read %d (%v), want %d", v, err, exp)
+		}
+	}
+	for _, exp := range se {
+		if v, err := bitio.ReadSE(r); err != nil || v != exp {
+			t.Fatalf("ReadSE read %d (%v), want %d", v, err, exp)
+		}
+	}
+}
+
+func TestExpGolomb_Error(t *testing.T) {
+	w := bitio.NewBitWriteBuffer(io.Discard)
+	if err := bitio.WriteExpGolomb(w, 1, 1<<64-1); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteExpGolomb error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	if err := bitio.WriteSE(w, -1<<63); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteSE error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	tests := []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{"empty", []byte{}, io.EOF},
+		{"EOF in prefix", []byte{0x00}, bitio.ErrUnexpectedEOF},
+		{"EOF in suffix", []byte{0x01}, bitio.ErrUnexpectedEOF},
+		{"too long prefix", make([]byte, 9), bitio.ErrValueOverflow},
+	}
+	for _, tt := range tests {
+		r := bitio.NewBitReadBuffer(bytes.NewReader(tt.raw))
+		if _, err := bitio.ReadUE(r); errors.Is(err, tt.err) == false {
+			t.Fatalf("ReadUE %q error %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+type expGolombRecord struct {
+	Type  uint8  `bit:"4"`
+	Count uint   `encoding:"ue"`
+	Delta []int  `encoding:"se" len:"Count"`
+	Size  uint32 `encoding:"egk:3"`
+}
+
+func TestExpGolomb_Struct(t *testing.T) {
+	v := &expGolombRecord{Type: 0x5, Delta: []int{0, 1, -1}, Size: 8}
+	raw := binaryToByteArray("0101_00100_1_010_011_010000")
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitFieldWriter(b)
+	n, err := w.WriteStruct(v)
+	if err != nil {
+		t.Fatalf("WriteStruct error: %v", err)
+	}
+	w.Flush()
+	if n != 22 || reflect.DeepEqual(b.Bytes(), raw) == false {
+		t.Fatalf("WriteStruct write %#v (%d bit), want %#v (22 bit)", b.Bytes(), n, raw)
+	}
+	if v.Count != 3 {
+		t.Fatalf("WriteStruct set length %d, want 3", v.Count)
+	}
+
+	if bits, err := bitio.SizeOf(v); err != nil || bits != 22 {
+		t.Fatalf("SizeOf returns %d (%v), want 22", bits, err)
+	}
+
+	got := &expGolombRecord{}
+	r := bitio.NewBitFieldReader(bytes.NewReader(raw))
+	if n, err = r.ReadStruct(got); err != nil || n != 22 {
+		t.Fatalf("ReadStruct read %d bit (%v), want 22 bit", n, err)
+	}
+	if reflect.DeepEqual(got, v) == false {
+		t.Fatalf("ReadStruct read %+v, want %+v", got, v)
+	}
+
+	layout, err := bitio.Layout(reflect.TypeOf(v))
+	if err != nil {
+		t.Fatalf("Layout error: %v", err)
+	}
+	if l := layout[1]; l.Offset != 4 || l.Bits != -1 || l.Dynamic == false {
+		t.Fatalf("Layout returns %+v, want dynamic field at 4", l)
+	}
+}
+
+func TestExpGolomb_Struct_Error(t *testing.T) {
+	v := &struct {
+		Val int8 `encoding:"ue"`
+	}{Val: -1}
+	w := bitio.NewBitFieldWriter(io.Discard)
+	if _, err := w.WriteStruct(v); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteStruct error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	// ue 255 exceeds int8
+	r := bitio.NewBitFieldReader(bytes.NewReader(binaryToByteArray("00000000_1_00000000")))
+	if _, err := r.ReadStruct(v); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("ReadStruct error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	invalid := []interface{}{
+		&struct {
+			Val uint `encoding:"se"`
+		}{},
+		&struct {
+			Val uint `encoding:"ue" bit:"8"`
+		}{},
+		&struct {
+			Val uint `encoding:"egk:64"`
+		}{},
+		&struct {
+			Val uint `encoding:"ue:1"`
+		}{},
+		&struct {
+			Val string `encoding:"ue"`
+		}{},
+		&struct {
+			Val uint `encoding:"unknown"`
+		}{},
+	}
+	for _, ptr := range invalid {
+		if _, err := bitio.SizeOf(ptr); errors.Is(err, bitio.ErrInvalidTag) == false {
+			t.Fatalf("%T error %v, want %v", ptr, err, bitio.ErrInvalidTag)
+		}
+	}
+}
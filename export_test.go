@@ -0,0 +1,9 @@
+package bitio
+
+import "reflect"
+
+// ForgetPlan removes compiled plan of struct type rt from cache.
+// It is used by benchmarks of parsing tags per call.
+func ForgetPlan(rt reflect.Type) {
+	planCache.Delete(rt)
+}
@@ -0,0 +1,258 @@
+// Package gcs implements Golomb-coded sets (compact filters of BIP158) on bitio.BitReader and bitio.BitWriter.
+//
+// Items are hashed by SipHash-2-4 to range [0, N*M), sorted, and the deltas are written as Golomb-Rice codes
+// (quotient in unary of ones terminated by 0, and P bits remainder).
+// False positive rate of matching is about 1/M.
+package gcs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"slices"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/univ"
+)
+
+// ErrInvalidParams is returned when Params are invalid.
+var ErrInvalidParams = errors.New("gcs: invalid parameters")
+
+// Params is parameters of Golomb-coded set.
+type Params struct {
+	P   int      // bit size of Golomb-Rice remainder (1..32)
+	M   uint64   // inverse of false positive rate
+	Key [16]byte // SipHash key (k0, k1 in little endian)
+}
+
+// BIP158 returns parameters of BIP158 basic filter.
+// key is the first 16 bytes of block hash.
+func BIP158(key [16]byte) Params {
+	return Params{P: 19, M: 784931, Key: key}
+}
+
+// check checks parameters for n items, and returns hash range N*M.
+func (p *Params) check(n int) (uint64, error) {
+	if p.P < 1 || p.P > 32 {
+		return 0, fmt.Errorf("%w: P %d is out of range [1, 32]", ErrInvalidParams, p.P)
+	}
+	if p.M == 0 {
+		return 0, fmt.Errorf("%w: M is 0", ErrInvalidParams)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%w: number of items %d is negative", ErrInvalidParams, n)
+	}
+	hi, f := bits.Mul64(uint64(n), p.M)
+	if hi != 0 {
+		return 0, fmt.Errorf("%w: N*M (%d*%d) overflows", ErrInvalidParams, n, p.M)
+	}
+	return f, nil
+}
+
+// hash returns hashed value of item in [0, f).
+func (p *Params) hash(item []byte, f uint64) uint64 {
+	k0 := binary.LittleEndian.Uint64(p.Key[0:])
+	k1 := binary.LittleEndian.Uint64(p.Key[8:])
+	hi, _ := bits.Mul64(SipHash(k0, k1, item), f)
+	return hi
+}
+
+// hashes returns sorted hashed values of items.
+func (p *Params) hashes(items [][]byte, f uint64) []uint64 {
+	values := make([]uint64, len(items))
+	for i, item := range items {
+		values[i] = p.hash(item, f)
+	}
+	slices.Sort(values)
+	return values
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Write writes Golomb-coded set of items. Number of items is not written.
+// If error happen, err will be set.
+func Write(w bitio.BitWriter, p Params, items [][]byte) error {
+	f, err := p.check(len(items))
+	if err != nil {
+		return err
+	}
+
+	last := uint64(0)
+	for _, v := range p.hashes(items, f) {
+		delta := v - last
+		last = v
+		if err := univ.Unary0.Write(w, delta>>uint(p.P)); err != nil {
+			return err
+		}
+		if err := bitio.Write(w, p.P, bitio.BigEndian, delta&(1<<uint(p.P)-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match reports whether item may be in the set of n items.
+// The set is decoded from r until the hashed value of item.
+func Match(r bitio.BitReader, p Params, n int, item []byte) (bool, error) {
+	return MatchAny(r, p, n, [][]byte{item})
+}
+
+// MatchAny reports whether any of items may be in the set of n items.
+// The set is decoded from r until a match is found.
+func MatchAny(r bitio.BitReader, p Params, n int, items [][]byte) (bool, error) {
+	f, err := p.check(n)
+	if err != nil {
+		return false, err
+	}
+	if n == 0 || len(items) == 0 {
+		return false, nil
+	}
+
+	d := &decoder{r: r, p: p.P, n: n}
+	targets := p.hashes(items, f)
+	v, err := d.next()
+	for err == nil {
+		// merge sorted values and targets
+		for len(targets) > 0 && targets[0] < v {
+			targets = targets[1:]
+		}
+		if len(targets) == 0 {
+			return false, nil
+		}
+		if targets[0] == v {
+			return true, nil
+		}
+		v, err = d.next()
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	return false, err
+}
+
+// decoder decodes hashed values of set.
+type decoder struct {
+	r    bitio.BitReader
+	p    int
+	n    int // number of values left
+	last uint64
+}
+
+// next returns the next value, or io.EOF after n values.
+func (d *decoder) next() (uint64, error) {
+	if d.n == 0 {
+		return 0, io.EOF
+	}
+	q, err := univ.Unary0.Read(d.r)
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	var rem uint64
+	if err := bitio.Read(d.r, d.p, bitio.BigEndian, &rem); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	d.n--
+	d.last += q<<uint(d.p) | rem
+	return d.last, nil
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Filter is Golomb-coded set in serialized form of BIP158.
+// The form is number of items (CompactSize) and byte aligned set.
+type Filter struct {
+	params Params
+	n      int
+	data   []byte // set without number of items
+}
+
+// NewFilter returns Filter of items.
+func NewFilter(p Params, items [][]byte) (*Filter, error) {
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	if err := Write(w, p, items); err != nil {
+		return nil, err
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return &Filter{params: p, n: len(items), data: b.Bytes()}, nil
+}
+
+// ParseFilter returns Filter of serialized form b.
+func ParseFilter(p Params, b []byte) (*Filter, error) {
+	n, size, err := readCompactSize(b)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(b))*8 {
+		return nil, fmt.Errorf("gcs: number of items %d exceeds data size", n)
+	}
+	if _, err := p.check(int(n)); err != nil {
+		return nil, err
+	}
+	return &Filter{params: p, n: int(n), data: b[size:]}, nil
+}
+
+// N returns number of items.
+func (f *Filter) N() int {
+	return f.n
+}
+
+// Bytes returns serialized form of Filter.
+func (f *Filter) Bytes() []byte {
+	return append(appendCompactSize(nil, uint64(f.n)), f.data...)
+}
+
+// Match reports whether item may be in Filter.
+func (f *Filter) Match(item []byte) (bool, error) {
+	return Match(bitio.NewBitReadBuffer(bytes.NewReader(f.data)), f.params, f.n, item)
+}
+
+// MatchAny reports whether any of items may be in Filter.
+func (f *Filter) MatchAny(items [][]byte) (bool, error) {
+	return MatchAny(bitio.NewBitReadBuffer(bytes.NewReader(f.data)), f.params, f.n, items)
+}
+
+// appendCompactSize appends v as CompactSize of Bitcoin.
+func appendCompactSize(b []byte, v uint64) []byte {
+	switch {
+	case v < 0xfd:
+		return append(b, byte(v))
+	case v <= 0xffff:
+		return binary.LittleEndian.AppendUint16(append(b, 0xfd), uint16(v))
+	case v <= 0xffffffff:
+		return binary.LittleEndian.AppendUint32(append(b, 0xfe), uint32(v))
+	default:
+		return binary.LittleEndian.AppendUint64(append(b, 0xff), v)
+	}
+}
+
+// readCompactSize reads CompactSize of Bitcoin, and returns value and read size.
+func readCompactSize(b []byte) (uint64, int, error) {
+	if len(b) == 0 {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	size := map[byte]int{0xfd: 3, 0xfe: 5, 0xff: 9}[b[0]]
+	if size == 0 {
+		return uint64(b[0]), 1, nil
+	}
+	if len(b) < size {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+
+	var buf [8]byte
+	copy(buf[:], b[1:size])
+	return binary.LittleEndian.Uint64(buf[:]), size, nil
+}
@@ -0,0 +1,163 @@
+package gcs_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/gcs"
+)
+
+func TestSipHash(t *testing.T) {
+	// vectors of SipHash paper (key 00..0f, message 00..(n-1))
+	k0, k1 := uint64(0x0706050403020100), uint64(0x0f0e0d0c0b0a0908)
+	msg := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+	tests := []struct {
+		n   int
+		exp uint64
+	}{
+		{0, 0x726fdb47dd0e0e31},
+		{15, 0xa129ca6149be45e5},
+	}
+	for _, tt := range tests {
+		if h := gcs.SipHash(k0, k1, msg[:tt.n]); h != tt.exp {
+			t.Fatalf("SipHash(%d bytes) returns %x, want %x", tt.n, h, tt.exp)
+		}
+	}
+}
+
+// blockKey returns the first 16 bytes of block hash (hex is displayed in reversed order).
+func blockKey(s string) [16]byte {
+	h, _ := hex.DecodeString(s)
+	var key [16]byte
+	for i := range key {
+		key[i] = h[len(h)-1-i]
+	}
+	return key
+}
+
+func TestFilter_BIP158(t *testing.T) {
+	// testnet genesis block of BIP158 test vectors
+	key := blockKey("000000000933ea01ad0ee984209779baaec3ced90fa3f408719526f8d77f4943")
+	script, _ := hex.DecodeString("4104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac")
+
+	f, err := gcs.NewFilter(gcs.BIP158(key), [][]byte{script})
+	if err != nil {
+		t.Fatalf("NewFilter() error: %v", err)
+	}
+	if got := hex.EncodeToString(f.Bytes()); got != "019dfca8" {
+		t.Fatalf("NewFilter() returns %s, want 019dfca8", got)
+	}
+
+	f, err = gcs.ParseFilter(gcs.BIP158(key), []byte{0x01, 0x9d, 0xfc, 0xa8})
+	if err != nil {
+		t.Fatalf("ParseFilter() error: %v", err)
+	}
+	if ok, err := f.Match(script); !ok || err != nil {
+		t.Fatalf("Match() returns %v, %v, want true", ok, err)
+	}
+	if ok, err := f.Match([]byte("not in filter")); ok || err != nil {
+		t.Fatalf("Match() returns %v, %v, want false", ok, err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	p := gcs.Params{P: 10, M: 1 << 10, Key: [16]byte{1, 2, 3}}
+
+	var items [][]byte
+	for i := 0; i < 2000; i++ {
+		items = append(items, []byte(fmt.Sprintf("item-%d", i)))
+	}
+	f, err := gcs.NewFilter(p, items)
+	if err != nil {
+		t.Fatalf("NewFilter() error: %v", err)
+	}
+
+	// about P+1.5 bits per item
+	if size := len(f.Bytes()); size > 2000*13/8 {
+		t.Fatalf("filter of 2000 items is %d bytes", size)
+	}
+
+	for _, item := range items {
+		if ok, err := f.Match(item); !ok || err != nil {
+			t.Fatalf("Match(%s) returns %v, %v, want true", item, ok, err)
+		}
+	}
+
+	// false positive rate is about 1/M
+	positive := 0
+	var others [][]byte
+	for i := 0; i < 5000; i++ {
+		other := []byte(fmt.Sprintf("other-%d", i))
+		others = append(others, other)
+		if ok, _ := f.Match(other); ok {
+			positive++
+		}
+	}
+	if positive > 5000*3/1024 {
+		t.Fatalf("false positive %d / 5000", positive)
+	}
+
+	if ok, err := f.MatchAny(append(others[:100:100], items[1234])); !ok || err != nil {
+		t.Fatalf("MatchAny() returns %v, %v, want true", ok, err)
+	}
+	if ok, err := f.MatchAny(nil); ok || err != nil {
+		t.Fatalf("MatchAny(nil) returns %v, %v, want false", ok, err)
+	}
+
+	// stream decoding on bit reader, the set is placed after 3 bits
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	w.WriteBit(0x5, 3)
+	if err := gcs.Write(w, p, items); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	w.Flush()
+
+	r := bitio.NewBitReadBuffer(b)
+	var head byte
+	r.ReadBit(&head, 3)
+	if ok, err := gcs.MatchAny(r, p, len(items), [][]byte{items[0], items[1999]}); !ok || err != nil {
+		t.Fatalf("MatchAny() on stream returns %v, %v, want true", ok, err)
+	}
+}
+
+func TestFilter_Empty(t *testing.T) {
+	f, err := gcs.NewFilter(gcs.BIP158([16]byte{}), nil)
+	if err != nil {
+		t.Fatalf("NewFilter(nil) error: %v", err)
+	}
+	if !bytes.Equal(f.Bytes(), []byte{0x00}) {
+		t.Fatalf("NewFilter(nil) returns %x, want 00", f.Bytes())
+	}
+	if ok, err := f.Match([]byte("x")); ok || err != nil {
+		t.Fatalf("Match() of empty filter returns %v, %v", ok, err)
+	}
+}
+
+func TestError(t *testing.T) {
+	items := [][]byte{[]byte("a")}
+	for _, p := range []gcs.Params{{P: 0, M: 1}, {P: 33, M: 1}, {P: 19, M: 0}} {
+		if _, err := gcs.NewFilter(p, items); !errors.Is(err, gcs.ErrInvalidParams) {
+			t.Fatalf("NewFilter(%+v) error %v, want ErrInvalidParams", p, err)
+		}
+	}
+
+	p := gcs.BIP158([16]byte{})
+	for _, b := range [][]byte{{}, {0xfd, 0x01}, {0xff, 1, 1, 1, 1, 1, 1, 1, 1}} {
+		if _, err := gcs.ParseFilter(p, b); err == nil {
+			t.Fatalf("ParseFilter(%x) must be error", b)
+		}
+	}
+
+	// truncated set
+	f, _ := gcs.ParseFilter(p, []byte{0x03, 0x9d})
+	if _, err := f.Match([]byte("x")); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Match() of truncated filter error %v, want ErrUnexpectedEOF", err)
+	}
+}
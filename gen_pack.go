@@ -0,0 +1,130 @@
+//go:build ignore
+
+// gen_pack.go generates pack_kernels.go.
+// Each kernel packs/unpacks 8 values of fixed width, which are exactly width bytes.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+)
+
+func main() {
+	b := new(bytes.Buffer)
+	fmt.Fprintf(b, "// Code generated by gen_pack.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "package bitio\n\n")
+	fmt.Fprintf(b, "import \"encoding/binary\"\n\n")
+
+	fmt.Fprintf(b, "// packKernels packs 8 values of width (index) bits to width bytes.\n")
+	fmt.Fprintf(b, "var packKernels = [65]func(dst []byte, src *[8]uint64){\n")
+	for w := 1; w <= 64; w++ {
+		fmt.Fprintf(b, "%d: pack8x%d,\n", w, w)
+	}
+	fmt.Fprintf(b, "}\n\n")
+
+	fmt.Fprintf(b, "// unpackKernels unpacks 8 values of width (index) bits from width bytes.\n")
+	fmt.Fprintf(b, "var unpackKernels = [65]func(dst *[8]uint64, src []byte){\n")
+	for w := 1; w <= 64; w++ {
+		fmt.Fprintf(b, "%d: unpack8x%d,\n", w, w)
+	}
+	fmt.Fprintf(b, "}\n\n")
+
+	for w := 1; w <= 64; w++ {
+		genPack(b, w)
+		genUnpack(b, w)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("pack_kernels.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// genPack generates kernel packing 8 values of width w.
+func genPack(b *bytes.Buffer, w int) {
+	fmt.Fprintf(b, "func pack8x%d(dst []byte, src *[8]uint64) {\n", w)
+	fmt.Fprintf(b, "_ = dst[%d]\n", w-1)
+
+	nWord := (8*w + 63) / 64
+	for k := 0; k < nWord; k++ {
+		var terms []string
+		for i := 0; i < 8; i++ {
+			// value bit range in word (from the most significant bit)
+			p := i*w - 64*k
+			if p+w <= 0 || p >= 64 {
+				continue
+			}
+			switch shift := 64 - w - p; {
+			case shift > 0:
+				terms = append(terms, fmt.Sprintf("src[%d]<<%d", i, shift))
+			case shift < 0:
+				terms = append(terms, fmt.Sprintf("src[%d]>>%d", i, -shift))
+			default:
+				terms = append(terms, fmt.Sprintf("src[%d]", i))
+			}
+		}
+		fmt.Fprintf(b, "w%d := ", k)
+		for i, t := range terms {
+			if i > 0 {
+				fmt.Fprintf(b, " | ")
+			}
+			fmt.Fprintf(b, "%s", t)
+		}
+		fmt.Fprintf(b, "\n")
+
+		if 8*k+8 <= w {
+			fmt.Fprintf(b, "binary.BigEndian.PutUint64(dst[%d:], w%d)\n", 8*k, k)
+			continue
+		}
+		for j := 0; 8*k+j < w; j++ {
+			fmt.Fprintf(b, "dst[%d] = byte(w%d >> %d)\n", 8*k+j, k, 56-8*j)
+		}
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+// genUnpack generates kernel unpacking 8 values of width w.
+func genUnpack(b *bytes.Buffer, w int) {
+	fmt.Fprintf(b, "func unpack8x%d(dst *[8]uint64, src []byte) {\n", w)
+	fmt.Fprintf(b, "_ = src[%d]\n", w-1)
+
+	nWord := (8*w + 63) / 64
+	for k := 0; k < nWord; k++ {
+		if 8*k+8 <= w {
+			fmt.Fprintf(b, "w%d := binary.BigEndian.Uint64(src[%d:])\n", k, 8*k)
+			continue
+		}
+		fmt.Fprintf(b, "w%d := ", k)
+		for j := 0; 8*k+j < w; j++ {
+			if j > 0 {
+				fmt.Fprintf(b, " | ")
+			}
+			fmt.Fprintf(b, "uint64(src[%d])<<%d", 8*k+j, 56-8*j)
+		}
+		fmt.Fprintf(b, "\n")
+	}
+
+	for i := 0; i < 8; i++ {
+		k := i * w / 64
+		p := i*w - 64*k
+
+		expr := fmt.Sprintf("w%d", k)
+		if p > 0 {
+			expr = fmt.Sprintf("w%d<<%d", k, p)
+		}
+		if w < 64 {
+			expr = fmt.Sprintf("(%s)>>%d", expr, 64-w)
+		}
+		if p+w > 64 {
+			expr += fmt.Sprintf(" | w%d>>%d", k+1, 128-p-w)
+		}
+		fmt.Fprintf(b, "dst[%d] = %s\n", i, expr)
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
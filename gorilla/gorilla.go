@@ -0,0 +1,284 @@
+// Package gorilla implements time series compression of Facebook Gorilla on bitio.BitReader and bitio.BitWriter.
+//
+// Timestamps are delta-of-delta coded, and values are XOR coded with the previous value:
+//
+//	delta-of-delta  0                  '0'
+//	                [-63, 64]          '10'   + 7 bits
+//	                [-255, 256]        '110'  + 9 bits
+//	                [-2047, 2048]      '1110' + 12 bits
+//	                others             '1111' + 64 bits
+//	XOR             0                  '0'
+//	                in previous window '10'   + meaningful bits
+//	                others             '11'   + 5 bits leading zeros + 6 bits length (64: 0) + meaningful bits
+//
+// Unlike the paper, the first point is coded against timestamp 0 and value 0 (no block header),
+// and the block ends with '1111' + 64 zero bits, which the encoder never writes as a point.
+package gorilla
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/hidez8891/bitio"
+)
+
+var (
+	// ErrClosed is returned when a point is appended to closed Encoder.
+	ErrClosed = errors.New("gorilla: encoder is closed")
+
+	// ErrCorrupted is returned when encoded data is invalid.
+	ErrCorrupted = errors.New("gorilla: corrupted data")
+)
+
+// dodBuckets are bit sizes of delta-of-delta with control bits '10', '110' and '1110'.
+var dodBuckets = [...]int{7, 9, 12}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// NewEncoder returns Encoder
+func NewEncoder(w bitio.BitWriter) *Encoder {
+	return &Encoder{w: w, leading: -1}
+}
+
+// Encoder is time series block encoder.
+// Close must be called after the last point.
+type Encoder struct {
+	w        bitio.BitWriter
+	t        int64  // previous timestamp
+	delta    int64  // previous delta
+	v        uint64 // previous value bits
+	leading  int    // leading zeros of previous window (-1: no window)
+	trailing int    // trailing zeros of previous window
+	closed   bool
+	buf      [8]byte
+}
+
+// Append writes a point of timestamp t and value v.
+// Timestamps can be irregular, and all float64 bits (NaN payload) are kept.
+// If error happen, err will be set.
+func (e *Encoder) Append(t int64, v float64) error {
+	if e.closed {
+		return ErrClosed
+	}
+
+	// wrapping arithmetic, decoder restores the same timestamp
+	delta := t - e.t
+	if err := e.writeDoD(delta - e.delta); err != nil {
+		return err
+	}
+	e.t, e.delta = t, delta
+
+	vb := math.Float64bits(v)
+	err := e.writeXOR(vb ^ e.v)
+	e.v = vb
+	return err
+}
+
+// Close writes the end of block. It does not flush BitWriter.
+// If error happen, err will be set.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	if err := e.write(0xf, 4); err != nil {
+		return err
+	}
+	return e.write(0, 64)
+}
+
+// writeDoD writes delta-of-delta.
+func (e *Encoder) writeDoD(dod int64) error {
+	if dod == 0 {
+		return e.write(0, 1)
+	}
+	for i, n := range dodBuckets {
+		if -(1<<(n-1))+1 <= dod && dod <= 1<<(n-1) {
+			// control bits: i+1 ones and a zero
+			if err := e.write(1<<uint(i+2)-2, i+2); err != nil {
+				return err
+			}
+			return e.write(uint64(dod)&(1<<uint(n)-1), n)
+		}
+	}
+	if err := e.write(0xf, 4); err != nil {
+		return err
+	}
+	return e.write(uint64(dod), 64)
+}
+
+// writeXOR writes XOR of value bits.
+func (e *Encoder) writeXOR(x uint64) error {
+	if x == 0 {
+		return e.write(0, 1)
+	}
+
+	leading := min(bits.LeadingZeros64(x), 31)
+	trailing := bits.TrailingZeros64(x)
+	if e.leading >= 0 && leading >= e.leading && trailing >= e.trailing {
+		if err := e.write(0x2, 2); err != nil {
+			return err
+		}
+		return e.write(x>>uint(e.trailing), 64-e.leading-e.trailing)
+	}
+
+	e.leading, e.trailing = leading, trailing
+	n := 64 - leading - trailing
+	if err := e.write(0x3, 2); err != nil {
+		return err
+	}
+	if err := e.write(uint64(leading)<<6|uint64(n&0x3f), 11); err != nil {
+		return err
+	}
+	return e.write(x>>uint(trailing), n)
+}
+
+// write writes n bits of v.
+func (e *Encoder) write(v uint64, n int) error {
+	if n == 0 {
+		return nil
+	}
+	binary.BigEndian.PutUint64(e.buf[:], v)
+	if m, err := e.w.WriteBits(e.buf[8-(n+7)/8:], n); err != nil {
+		return err
+	} else if m != n {
+		return fmt.Errorf("gorilla: insufficient size of write, want %d bit, write %d bit", n, m)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// NewIterator returns Iterator
+func NewIterator(r bitio.BitReader) *Iterator {
+	return &Iterator{r: r, leading: -1}
+}
+
+// Iterator decodes points of time series block.
+type Iterator struct {
+	r        bitio.BitReader
+	t        int64
+	delta    int64
+	v        uint64
+	leading  int
+	trailing int
+	done     bool
+	err      error
+	buf      [8]byte
+}
+
+// Next decodes the next point, and returns false at the end of block or error.
+func (it *Iterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if err := it.next(); err != nil {
+		it.done = true
+		if err != io.EOF {
+			it.err = err
+		}
+		return false
+	}
+	return true
+}
+
+// At returns the current point.
+func (it *Iterator) At() (t int64, v float64) {
+	return it.t, math.Float64frombits(it.v)
+}
+
+// Err returns the error of decoding, or nil at the end of block.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// next decodes a point, and returns io.EOF at the end of block.
+func (it *Iterator) next() error {
+	// control bits of delta-of-delta
+	ctrl := 0
+	for ; ctrl < 4; ctrl++ {
+		b, err := it.read(1)
+		if err != nil {
+			return err
+		}
+		if b == 0 {
+			break
+		}
+	}
+
+	var dod int64
+	switch {
+	case ctrl == 0:
+	case ctrl < 4:
+		n := dodBuckets[ctrl-1]
+		u, err := it.read(n)
+		if err != nil {
+			return err
+		}
+		// values are in [-(2^(n-1)-1), 2^(n-1)]
+		dod = int64(u)
+		if u > 1<<(n-1) {
+			dod -= 1 << n
+		}
+	default:
+		u, err := it.read(64)
+		if err != nil {
+			return err
+		}
+		if u == 0 {
+			return io.EOF
+		}
+		dod = int64(u)
+	}
+	it.delta += dod
+	it.t += it.delta
+
+	// value
+	b, err := it.read(1)
+	if err != nil || b == 0 {
+		return err
+	}
+	if b, err = it.read(1); err != nil {
+		return err
+	}
+	if b == 1 {
+		header, err := it.read(11)
+		if err != nil {
+			return err
+		}
+		it.leading = int(header >> 6)
+		n := int(header & 0x3f)
+		if n == 0 {
+			n = 64
+		}
+		if it.leading+n > 64 {
+			return fmt.Errorf("%w: XOR window of leading %d bit and length %d bit", ErrCorrupted, it.leading, n)
+		}
+		it.trailing = 64 - it.leading - n
+	} else if it.leading < 0 {
+		return fmt.Errorf("%w: previous XOR window is not found", ErrCorrupted)
+	}
+
+	x, err := it.read(64 - it.leading - it.trailing)
+	if err != nil {
+		return err
+	}
+	it.v ^= x << uint(it.trailing)
+	return nil
+}
+
+// read reads n bits. EOF is unexpected because block ends with marker.
+func (it *Iterator) read(n int) (uint64, error) {
+	it.buf = [8]byte{}
+	if m, err := it.r.ReadBits(it.buf[8-(n+7)/8:], n); err != nil || m != n {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(it.buf[:]), nil
+}
@@ -0,0 +1,168 @@
+package gorilla_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/gorilla"
+)
+
+type point struct {
+	t int64
+	v float64
+}
+
+func encode(t *testing.T, points []point) []byte {
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	e := gorilla.NewEncoder(w)
+	for _, p := range points {
+		if err := e.Append(p.t, p.v); err != nil {
+			t.Fatalf("Append(%d, %v) error: %v", p.t, p.v, err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	w.Flush()
+	return b.Bytes()
+}
+
+func decode(data []byte) ([]point, error) {
+	it := gorilla.NewIterator(bitio.NewBitReadBuffer(bytes.NewReader(data)))
+	var points []point
+	for it.Next() {
+		t, v := it.At()
+		points = append(points, point{t, v})
+	}
+	return points, it.Err()
+}
+
+func testRoundTrip(t *testing.T, points []point) []byte {
+	data := encode(t, points)
+	got, err := decode(data)
+	if err != nil {
+		t.Fatalf("decode %d points error: %v", len(points), err)
+	}
+	if len(got) != len(points) {
+		t.Fatalf("decode %d points, want %d", len(got), len(points))
+	}
+	for i := range points {
+		// compare bits (NaN)
+		if got[i].t != points[i].t || math.Float64bits(got[i].v) != math.Float64bits(points[i].v) {
+			t.Fatalf("point %d is %v, want %v", i, got[i], points[i])
+		}
+	}
+	return data
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		points []point
+		exp    string
+	}{
+		{nil, "f00000000000000000"},
+		// '0' '0' end
+		{[]point{{0, 0}}, "3c0000000000000000"},
+		// '10' 0000001, '11' 00010 001010 1111111111, end
+		{[]point{{1, 1}}, "80e22bfff00000000000000000"},
+	}
+
+	for _, tt := range tests {
+		if got := hex.EncodeToString(encode(t, tt.points)); got != tt.exp {
+			t.Fatalf("encode %v writes %s, want %s", tt.points, got, tt.exp)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	nan := math.Float64frombits(0x7ff8_0000_dead_beef)
+	testRoundTrip(t, nil)
+	testRoundTrip(t, []point{
+		{1700000000, math.NaN()},
+		{1700000060, math.Inf(1)},
+		{1700000120, math.Inf(-1)},
+		{1700000180, math.Copysign(0, -1)},
+		{1700000240, nan},
+		{1700000241, nan},
+		{1700000241, 1.5},
+		{1699999000, math.MaxFloat64},
+		{1700003000, math.SmallestNonzeroFloat64},
+		{math.MaxInt64, -1},
+		{math.MinInt64, 1},
+		{0, 0},
+	})
+
+	// delta-of-delta bucket boundaries
+	var points []point
+	ts := int64(1000)
+	for _, dod := range []int64{0, 1, -63, 64, -64, 65, -255, 256, -256, 257, -2047, 2048, -2048, 2049, 1 << 40, -(1 << 40)} {
+		ts += 100 + dod
+		points = append(points, point{ts, float64(dod)})
+	}
+	testRoundTrip(t, points)
+
+	// irregular intervals
+	rnd := rand.New(rand.NewSource(1))
+	points = points[:0]
+	ts = 1700000000000
+	v := 100.0
+	for i := 0; i < 10000; i++ {
+		ts += 1000 + rnd.Int63n(50) - 25
+		if rnd.Intn(100) == 0 {
+			ts += rnd.Int63n(1 << 30)
+		}
+		v += float64(rnd.Intn(11) - 5)
+		points = append(points, point{ts, v})
+	}
+	data := testRoundTrip(t, points)
+
+	// about 1 byte/point for regular series (paper: 1.37 byte/point)
+	if len(data) > len(points)*3 {
+		t.Fatalf("encoded %d points to %d bytes", len(points), len(data))
+	}
+}
+
+func TestEncoder_Closed(t *testing.T) {
+	e := gorilla.NewEncoder(bitio.NewBitWriteBuffer(new(bytes.Buffer)))
+	e.Close()
+	if err := e.Append(0, 0); !errors.Is(err, gorilla.ErrClosed) {
+		t.Fatalf("Append() after Close() error %v, want ErrClosed", err)
+	}
+}
+
+func TestIterator_Error(t *testing.T) {
+	data := encode(t, []point{{1, 1}, {2, 2}, {3, 3}})
+
+	tests := []struct {
+		data []byte
+		exp  error
+	}{
+		{[]byte{}, io.ErrUnexpectedEOF},
+		{data[:len(data)-3], io.ErrUnexpectedEOF},
+		// '0' '10' without previous window
+		{[]byte{0x40}, gorilla.ErrCorrupted},
+		// '0' '11' leading 31 length 40
+		{[]byte{0x7f, 0xa0}, gorilla.ErrCorrupted},
+	}
+
+	for _, tt := range tests {
+		if _, err := decode(tt.data); !errors.Is(err, tt.exp) {
+			t.Fatalf("decode %x error %v, want %v", tt.data, err, tt.exp)
+		}
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	e := gorilla.NewEncoder(bitio.NewBitWriteBuffer(io.Discard))
+	for i := 0; i < b.N; i++ {
+		e.Append(int64(i)*1000, float64(i%100))
+	}
+	e.Close()
+}
@@ -0,0 +1,136 @@
+package huffman
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// FromFrequencies returns Table from frequencies of symbols.
+// Symbol of frequency 0 has no code.
+// Code lengths are limited to maxLen bits. (same as JPEG Annex K.3)
+func FromFrequencies(freqs []uint64, maxLen int, order BitOrder) (*Table, error) {
+	lengths, err := codeLengths(freqs, maxLen)
+	if err != nil {
+		return nil, err
+	}
+	return New(lengths, order)
+}
+
+// codeLengths returns length limited Huffman code lengths of frequencies.
+func codeLengths(freqs []uint64, maxLen int) ([]uint8, error) {
+	if maxLen < 1 || maxLen > MaxCodeLen {
+		return nil, fmt.Errorf("huffman: max code length %d is out of range [1, %d]", maxLen, MaxCodeLen)
+	}
+
+	// used symbols (frequency descending order)
+	var syms []int
+	for sym, f := range freqs {
+		if f > 0 {
+			syms = append(syms, sym)
+		}
+	}
+	if len(syms) == 0 {
+		return nil, fmt.Errorf("huffman: no code")
+	}
+	if uint64(len(syms)) > 1<<uint(maxLen) {
+		return nil, fmt.Errorf("huffman: %d symbols exceed max code length %d", len(syms), maxLen)
+	}
+	sort.SliceStable(syms, func(i, j int) bool {
+		return freqs[syms[i]] > freqs[syms[j]]
+	})
+
+	lengths := make([]uint8, len(freqs))
+	if len(syms) == 1 {
+		lengths[syms[0]] = 1
+		return lengths, nil
+	}
+
+	// number of codes of each length
+	count := huffmanCount(freqs, syms, maxLen)
+	limitCount(count, maxLen)
+
+	// shorter code for frequent symbol
+	i := 0
+	for l := 1; l <= maxLen; l++ {
+		for n := 0; n < count[l]; n++ {
+			lengths[syms[i]] = uint8(l)
+			i++
+		}
+	}
+	return lengths, nil
+}
+
+// huffmanCount returns number of codes of each length by Huffman algorithm.
+// The result has maxLen+1 lengths at least.
+func huffmanCount(freqs []uint64, syms []int, maxLen int) []int {
+	// tree nodes: leaves are 0..len(syms)-1
+	parent := make([]int, 2*len(syms)-1)
+	h := make(nodeHeap, len(syms))
+	for i, sym := range syms {
+		h[i] = node{freq: freqs[sym], id: i}
+	}
+	heap.Init(&h)
+
+	for id := len(syms); h.Len() > 1; id++ {
+		a := heap.Pop(&h).(node)
+		b := heap.Pop(&h).(node)
+		parent[a.id] = id
+		parent[b.id] = id
+		heap.Push(&h, node{freq: a.freq + b.freq, id: id})
+	}
+
+	// depth of leaves (parent id is greater than child id)
+	root := len(parent) - 1
+	depth := make([]int, len(parent))
+	count := make([]int, len(syms)+maxLen)
+	for id := root - 1; id >= 0; id-- {
+		depth[id] = depth[parent[id]] + 1
+		if id < len(syms) {
+			count[depth[id]]++
+		}
+	}
+	return count
+}
+
+// limitCount moves codes longer than maxLen to shorter length, keeping the code complete.
+func limitCount(count []int, maxLen int) {
+	for l := len(count) - 1; l > maxLen; l-- {
+		for count[l] > 0 {
+			// find a shorter leaf to be a parent of 2 leaves
+			j := l - 2
+			for count[j] == 0 {
+				j--
+			}
+			count[l] -= 2
+			count[l-1]++
+			count[j+1] += 2
+			count[j]--
+		}
+	}
+}
+
+// node is Huffman tree node.
+type node struct {
+	freq uint64
+	id   int
+}
+
+// nodeHeap is min-heap of nodes. (ties are broken by id)
+type nodeHeap []node
+
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].freq != h[j].freq {
+		return h[i].freq < h[j].freq
+	}
+	return h[i].id < h[j].id
+}
+func (h nodeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x interface{}) { *h = append(*h, x.(node)) }
+func (h *nodeHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,91 @@
+package huffman_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/huffman"
+)
+
+func TestFromFrequencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		freqs   []uint64
+		maxLen  int
+		lengths []uint8
+	}{
+		{
+			name:    "optimal",
+			freqs:   []uint64{45, 13, 12, 16, 9, 5},
+			maxLen:  16,
+			lengths: []uint8{1, 3, 3, 3, 4, 4},
+		},
+		{
+			name:    "unused symbol",
+			freqs:   []uint64{0, 3, 0, 1, 1},
+			maxLen:  16,
+			lengths: []uint8{0, 1, 0, 2, 2},
+		},
+		{
+			name:    "single symbol",
+			freqs:   []uint64{0, 7},
+			maxLen:  16,
+			lengths: []uint8{0, 1},
+		},
+		{
+			name:    "length limited",
+			freqs:   []uint64{1, 1, 2, 3, 5, 8, 13, 21, 34, 55},
+			maxLen:  5,
+			lengths: []uint8{5, 5, 5, 5, 5, 5, 5, 5, 2, 1},
+		},
+		{
+			name:    "length limited to full tree",
+			freqs:   []uint64{1, 1, 2, 3, 5, 8, 13, 21},
+			maxLen:  3,
+			lengths: []uint8{3, 3, 3, 3, 3, 3, 3, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		table, err := huffman.FromFrequencies(tt.freqs, tt.maxLen, huffman.MSBFirst)
+		if err != nil {
+			t.Fatalf("%s error: %v", tt.name, err)
+		}
+		if reflect.DeepEqual(table.Lengths(), tt.lengths) == false {
+			t.Fatalf("%s lengths %v, want %v", tt.name, table.Lengths(), tt.lengths)
+		}
+
+		// round trip
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		for sym, f := range tt.freqs {
+			if f > 0 {
+				table.Encode(w, sym)
+			}
+		}
+		w.Flush()
+		r := bitio.NewBitReadBuffer(b)
+		for sym, f := range tt.freqs {
+			if f == 0 {
+				continue
+			}
+			if v, err := table.Decode(r); err != nil || v != sym {
+				t.Fatalf("%s decode %d (%v), want %d", tt.name, v, err, sym)
+			}
+		}
+	}
+}
+
+func TestFromFrequencies_Error(t *testing.T) {
+	if _, err := huffman.FromFrequencies([]uint64{0, 0}, 16, huffman.MSBFirst); err == nil {
+		t.Fatalf("no symbol wants error")
+	}
+	if _, err := huffman.FromFrequencies([]uint64{1, 1, 1}, 1, huffman.MSBFirst); err == nil {
+		t.Fatalf("too many symbols wants error")
+	}
+	if _, err := huffman.FromFrequencies([]uint64{1, 1}, 33, huffman.MSBFirst); err == nil {
+		t.Fatalf("too long max length wants error")
+	}
+}
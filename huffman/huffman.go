@@ -0,0 +1,383 @@
+// Package huffman implements canonical Huffman codes on bitio.BitReader and bitio.BitWriter.
+//
+// Tables are built from code lengths (ex: JPEG DHT, DEFLATE), symbol frequencies or arbitrary prefix codes.
+// Decoding uses multi-level lookup tables if the reader implements bitio.BitPeeker,
+// otherwise codes are decoded bit by bit.
+package huffman
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+
+	"github.com/hidez8891/bitio"
+)
+
+// MaxCodeLen is the maximum bit length of code.
+const MaxCodeLen = 32
+
+// tableBits is the index bit size of each lookup table level.
+const tableBits = 9
+
+var (
+	// ErrInvalidCode is returned when read bits are not assigned to any symbol.
+	ErrInvalidCode = errors.New("huffman: invalid code")
+	// ErrInvalidSymbol is returned when symbol has no code.
+	ErrInvalidSymbol = errors.New("huffman: invalid symbol")
+	// ErrAmbiguousCode is returned when a code is prefix of another code.
+	ErrAmbiguousCode = errors.New("ambiguous code")
+)
+
+// BitOrder is the packing order of code bits.
+type BitOrder int
+
+const (
+	// MSBFirst is for MSB-first bit stream. (ex: JPEG, bitio.BitReadBuffer)
+	// Peeked bits have the first bit at the most significant bit.
+	MSBFirst BitOrder = iota
+	// LSBFirst is for LSB-first bit stream. (ex: DEFLATE, bitio.LSBReadBuffer)
+	// Peeked bits have the first bit at the least significant bit.
+	LSBFirst
+)
+
+// Table is canonical Huffman code table.
+// Table is safe for concurrent use by multiple goroutines.
+type Table struct {
+	order   BitOrder
+	lengths []uint8
+	codes   []uint32 // canonical code of symbol (first bit is the most significant bit)
+
+	// bit by bit decoding
+	maxLen int
+	tree   []int32 // binary tree, children of node i are tree[2i] and tree[2i+1] (>0: node, <0: -(symbol+1), 0: invalid)
+
+	// lookup table decoding
+	rootBits int
+	entries  []entry
+}
+
+// entry is lookup table entry.
+type entry struct {
+	value uint32 // symbol, or offset of sub table
+	len   uint8  // code length in this level (0: sub table or invalid code)
+	bits  uint8  // index bit size of sub table (0: invalid code)
+}
+
+// Codeword is code of symbol.
+type Codeword struct {
+	Symbol int
+	Code   uint32 // the first bit is the most significant bit
+	Len    int    // bit length of code
+}
+
+// New returns Table from code lengths of symbols.
+// Symbol of length 0 has no code.
+// Returns error if lengths are over-subscribed. Incomplete code is allowed.
+func New(lengths []uint8, order BitOrder) (*Table, error) {
+	var count [MaxCodeLen + 1]int
+	var symbols []int
+	for sym, l := range lengths {
+		if l > MaxCodeLen {
+			return nil, fmt.Errorf("huffman: code length %d of symbol %d exceeds %d", l, sym, MaxCodeLen)
+		}
+		if l > 0 {
+			count[l]++
+			symbols = append(symbols, sym)
+		}
+	}
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("huffman: no code")
+	}
+
+	// Kraft inequality
+	left := int64(1)
+	for l := 1; l <= MaxCodeLen; l++ {
+		left = left<<1 - int64(count[l])
+		if left < 0 {
+			return nil, fmt.Errorf("huffman: code lengths are over-subscribed")
+		}
+	}
+
+	// canonical code
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return lengths[symbols[i]] < lengths[symbols[j]]
+	})
+	words := make([]Codeword, len(symbols))
+	code, prevLen := uint32(0), 0
+	for i, sym := range symbols {
+		l := int(lengths[sym])
+		if i > 0 {
+			code = (code + 1) << uint(l-prevLen)
+		}
+		prevLen = l
+		words[i] = Codeword{Symbol: sym, Code: code, Len: l}
+	}
+
+	// canonical codes are sorted by left justified value
+	return newTable(words, len(lengths), order), nil
+}
+
+// FromCodewords returns Table from arbitrary prefix codes. (ex: MPEG VLC tables)
+// Returns error if a code is prefix of another code, or symbol has multiple codes.
+// Incomplete code is allowed.
+func FromCodewords(words []Codeword, order BitOrder) (*Table, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("huffman: no code")
+	}
+
+	nSym := 0
+	seen := make(map[int]bool)
+	for _, w := range words {
+		if w.Symbol < 0 {
+			return nil, fmt.Errorf("huffman: symbol %d is negative", w.Symbol)
+		}
+		if w.Len < 1 || w.Len > MaxCodeLen {
+			return nil, fmt.Errorf("huffman: code length %d of symbol %d is out of range [1, %d]", w.Len, w.Symbol, MaxCodeLen)
+		}
+		if uint64(w.Code) > mask(w.Len) {
+			return nil, fmt.Errorf("huffman: code %#x of symbol %d exceeds %d bit", w.Code, w.Symbol, w.Len)
+		}
+		if seen[w.Symbol] {
+			return nil, fmt.Errorf("huffman: symbol %d has multiple codes", w.Symbol)
+		}
+		seen[w.Symbol] = true
+		if w.Symbol >= nSym {
+			nSym = w.Symbol + 1
+		}
+	}
+
+	words = append([]Codeword(nil), words...)
+	sort.SliceStable(words, func(i, j int) bool {
+		a, b := leftJustify(words[i]), leftJustify(words[j])
+		if a != b {
+			return a < b
+		}
+		return words[i].Len < words[j].Len
+	})
+
+	// prefix code (a code and its prefix are adjacent in sorted codes)
+	for i := 1; i < len(words); i++ {
+		a, b := words[i-1], words[i]
+		if b.Code>>uint(b.Len-a.Len) == a.Code {
+			return nil, fmt.Errorf("huffman: %w: code %s of symbol %d is prefix of code %s of symbol %d",
+				ErrAmbiguousCode, a, a.Symbol, b, b.Symbol)
+		}
+	}
+
+	return newTable(words, nSym, order), nil
+}
+
+// String returns code as bit pattern. (ex: "0010")
+func (w Codeword) String() string {
+	return fmt.Sprintf("%0*b", w.Len, w.Code)
+}
+
+// leftJustify returns code aligned to MaxCodeLen bits.
+func leftJustify(w Codeword) uint64 {
+	return uint64(w.Code) << uint(MaxCodeLen-w.Len)
+}
+
+// newTable returns Table of prefix codes, which are sorted by left justified value.
+func newTable(words []Codeword, nSym int, order BitOrder) *Table {
+	t := &Table{
+		order:   order,
+		lengths: make([]uint8, nSym),
+		codes:   make([]uint32, nSym),
+		tree:    make([]int32, 2),
+	}
+
+	for _, w := range words {
+		t.lengths[w.Symbol] = uint8(w.Len)
+		t.codes[w.Symbol] = w.Code
+		if w.Len > t.maxLen {
+			t.maxLen = w.Len
+		}
+
+		// binary tree
+		node := 0
+		for i := w.Len - 1; i > 0; i-- {
+			bit := int(w.Code>>uint(i)) & 1
+			if t.tree[2*node+bit] == 0 {
+				t.tree[2*node+bit] = int32(len(t.tree) / 2)
+				t.tree = append(t.tree, 0, 0)
+			}
+			node = int(t.tree[2*node+bit])
+		}
+		t.tree[2*node+int(w.Code&1)] = -int32(w.Symbol + 1)
+	}
+
+	// lookup table
+	t.rootBits = t.maxLen
+	if t.rootBits > tableBits {
+		t.rootBits = tableBits
+	}
+	t.build(words, 0, t.rootBits)
+
+	return t
+}
+
+// build builds lookup table of codes, which have the same prefix of prefixLen bits.
+// Returns offset of table.
+func (t *Table) build(codes []Codeword, prefixLen, nBit int) uint32 {
+	offset := len(t.entries)
+	t.entries = append(t.entries, make([]entry, 1<<uint(nBit))...)
+
+	for i := 0; i < len(codes); {
+		c := codes[i]
+		rest := c.Len - prefixLen
+		if rest <= nBit {
+			// fill all indexes which start with code
+			base := uint32(uint64(c.Code)&mask(rest)) << uint(nBit-rest)
+			for k := uint32(0); k < 1<<uint(nBit-rest); k++ {
+				t.entries[offset+t.index(base|k, nBit)] = entry{value: uint32(c.Symbol), len: uint8(rest)}
+			}
+			i++
+			continue
+		}
+
+		// codes which have the same index make sub table
+		idx := subIndex(c, prefixLen, nBit)
+		j, maxRest := i+1, rest
+		for ; j < len(codes) && codes[j].Len-prefixLen > nBit && subIndex(codes[j], prefixLen, nBit) == idx; j++ {
+			if r := codes[j].Len - prefixLen; r > maxRest {
+				maxRest = r
+			}
+		}
+		subBits := maxRest - nBit
+		if subBits > tableBits {
+			subBits = tableBits
+		}
+
+		sub := t.build(codes[i:j], prefixLen+nBit, subBits)
+		t.entries[offset+t.index(idx, nBit)] = entry{value: sub, bits: uint8(subBits)}
+		i = j
+	}
+
+	return uint32(offset)
+}
+
+// index returns table index of MSB-first index v.
+func (t *Table) index(v uint32, nBit int) int {
+	if t.order == LSBFirst {
+		v = bits.Reverse32(v) >> uint(32-nBit)
+	}
+	return int(v)
+}
+
+// subIndex returns nBit bits of code after prefixLen bits.
+func subIndex(c Codeword, prefixLen, nBit int) uint32 {
+	return uint32(uint64(c.Code)>>uint(c.Len-prefixLen-nBit)) & uint32(mask(nBit))
+}
+
+func mask(n int) uint64 {
+	return 1<<uint(n) - 1
+}
+
+// Lengths returns code lengths of symbols.
+func (t *Table) Lengths() []uint8 {
+	return append([]uint8(nil), t.lengths...)
+}
+
+// Code returns code and its bit length of symbol.
+// The first bit of code is the most significant bit.
+// Returns 0 length if symbol has no code.
+func (t *Table) Code(sym int) (code uint32, nBit int) {
+	if sym < 0 || sym >= len(t.lengths) {
+		return 0, 0
+	}
+	return t.codes[sym], int(t.lengths[sym])
+}
+
+// Encode writes code of symbol.
+// If error happen, err will be set.
+func (t *Table) Encode(w bitio.BitWriter, sym int) error {
+	code, n := t.Code(sym)
+	if n == 0 {
+		return fmt.Errorf("huffman: symbol %d: %w", sym, ErrInvalidSymbol)
+	}
+
+	v := uint64(code)
+	if t.order == LSBFirst {
+		v = bits.Reverse64(v) >> uint(64-n)
+	}
+	return bitio.Write(w, n, bitio.BigEndian, v)
+}
+
+// Decode reads code and returns its symbol.
+// Returns io.EOF if no bits are read, io.ErrUnexpectedEOF if code is incomplete.
+func (t *Table) Decode(r bitio.BitReader) (int, error) {
+	if p, ok := r.(bitio.BitPeeker); ok {
+		return t.decodeTable(p)
+	}
+	return t.decodeBits(r)
+}
+
+// decodeTable decodes code by lookup tables.
+func (t *Table) decodeTable(p bitio.BitPeeker) (int, error) {
+	offset, nBit := uint32(0), t.rootBits
+	for depth := 0; ; depth++ {
+		v, n, err := p.PeekBits(nBit)
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 {
+			if depth == 0 {
+				return 0, io.EOF
+			}
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		e := t.entries[offset+uint32(v)]
+		switch {
+		case e.len > 0:
+			if int(e.len) > n {
+				return 0, io.ErrUnexpectedEOF
+			}
+			if _, err = p.SkipBits(int(e.len)); err != nil {
+				return 0, err
+			}
+			return int(e.value), nil
+
+		case e.bits > 0:
+			if n < nBit {
+				return 0, io.ErrUnexpectedEOF
+			}
+			if _, err = p.SkipBits(nBit); err != nil {
+				return 0, err
+			}
+			offset, nBit = e.value, int(e.bits)
+
+		default:
+			if n < nBit {
+				return 0, io.ErrUnexpectedEOF
+			}
+			return 0, ErrInvalidCode
+		}
+	}
+}
+
+// decodeBits decodes code bit by bit.
+func (t *Table) decodeBits(r bitio.BitReader) (int, error) {
+	node := int32(0)
+	for l := 1; ; l++ {
+		var b byte
+		if n, err := r.ReadBit(&b, 1); err != nil {
+			if l > 1 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		} else if n != 1 {
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		node = t.tree[2*node+int32(b)]
+		switch {
+		case node < 0:
+			return int(-node - 1), nil
+		case node == 0:
+			return 0, ErrInvalidCode
+		}
+	}
+}
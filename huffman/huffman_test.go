@@ -0,0 +1,317 @@
+package huffman_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/huffman"
+)
+
+// bitReaderOnly hides bitio.BitPeeker of reader.
+type bitReaderOnly struct {
+	bitio.BitReader
+}
+
+// fixedLengths returns code lengths of DEFLATE fixed literal/length table.
+func fixedLengths() []uint8 {
+	lengths := make([]uint8, 288)
+	for i := range lengths {
+		switch {
+		case i < 144:
+			lengths[i] = 8
+		case i < 256:
+			lengths[i] = 9
+		case i < 280:
+			lengths[i] = 7
+		default:
+			lengths[i] = 8
+		}
+	}
+	return lengths
+}
+
+// jpegDCLengths is code lengths of JPEG luminance DC table.
+var jpegDCLengths = []uint8{2, 3, 3, 3, 3, 3, 4, 5, 6, 7, 8, 9}
+
+func TestTable_Code(t *testing.T) {
+	fixed, err := huffman.New(fixedLengths(), huffman.LSBFirst)
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	jpeg, err := huffman.New(jpegDCLengths, huffman.MSBFirst)
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		table *huffman.Table
+		sym   int
+		code  uint32
+		nBit  int
+	}{
+		{"deflate 0", fixed, 0, 0x30, 8},
+		{"deflate 143", fixed, 143, 0xbf, 8},
+		{"deflate 144", fixed, 144, 0x190, 9},
+		{"deflate 256", fixed, 256, 0x00, 7},
+		{"deflate 280", fixed, 280, 0xc0, 8},
+		{"jpeg 0", jpeg, 0, 0x0, 2},
+		{"jpeg 1", jpeg, 1, 0x2, 3},
+		{"jpeg 6", jpeg, 6, 0xe, 4},
+		{"jpeg 11", jpeg, 11, 0x1fe, 9},
+		{"out of range", jpeg, 12, 0, 0},
+	}
+	for _, tt := range tests {
+		if code, n := tt.table.Code(tt.sym); code != tt.code || n != tt.nBit {
+			t.Fatalf("%s code %#x (%d bit), want %#x (%d bit)", tt.name, code, n, tt.code, tt.nBit)
+		}
+	}
+
+	if reflect.DeepEqual(jpeg.Lengths(), jpegDCLengths) == false {
+		t.Fatalf("Lengths %v, want %v", jpeg.Lengths(), jpegDCLengths)
+	}
+}
+
+func TestTable_Deflate(t *testing.T) {
+	fixed, _ := huffman.New(fixedLengths(), huffman.LSBFirst)
+
+	// raw DEFLATE fixed block of "a"
+	raw := []byte{0x4b, 0x04, 0x00}
+
+	for _, r := range []bitio.BitReader{
+		bitio.NewLSBReadBuffer(bytes.NewReader(raw)),
+		bitReaderOnly{bitio.NewLSBReadBuffer(bytes.NewReader(raw))},
+	} {
+		var header uint8
+		if err := bitio.Read(r, 3, bitio.BigEndian, &header); err != nil || header != 0x3 {
+			t.Fatalf("read header %#x (%v), want BFINAL=1 BTYPE=01", header, err)
+		}
+		for _, exp := range []int{'a', 256} {
+			if sym, err := fixed.Decode(r); err != nil || sym != exp {
+				t.Fatalf("%T decode %d (%v), want %d", r, sym, err, exp)
+			}
+		}
+	}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewLSBWriteBuffer(b)
+	bitio.Write(w, 3, bitio.BigEndian, 0x3)
+	fixed.Encode(w, 'a')
+	fixed.Encode(w, 256)
+	w.Flush()
+	if exp := raw; reflect.DeepEqual(b.Bytes(), exp) == false {
+		t.Fatalf("encode %#v, want %#v", b.Bytes(), exp)
+	}
+}
+
+func TestTable_RoundTrip(t *testing.T) {
+	// deep codes use sub tables
+	deep := make([]uint8, 33)
+	for i := range deep {
+		deep[i] = uint8(i + 1)
+	}
+	deep[32] = 32
+
+	tables := map[string][]uint8{
+		"deflate": fixedLengths(),
+		"jpeg":    jpegDCLengths,
+		"deep":    deep,
+		"single":  {0, 0, 1},
+	}
+
+	for name, lengths := range tables {
+		for _, order := range []huffman.BitOrder{huffman.MSBFirst, huffman.LSBFirst} {
+			table, err := huffman.New(lengths, order)
+			if err != nil {
+				t.Fatalf("%s New error: %v", name, err)
+			}
+
+			var syms []int
+			for sym, l := range lengths {
+				if l > 0 {
+					syms = append(syms, sym, sym)
+				}
+			}
+			rand.New(rand.NewSource(1)).Shuffle(len(syms), func(i, j int) { syms[i], syms[j] = syms[j], syms[i] })
+
+			b := new(bytes.Buffer)
+			var w bitio.BitWriter = bitio.NewBitWriteBuffer(b)
+			newReader := func() bitio.BitReader { return bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes())) }
+			if order == huffman.LSBFirst {
+				w = bitio.NewLSBWriteBuffer(b)
+				newReader = func() bitio.BitReader { return bitio.NewLSBReadBuffer(bytes.NewReader(b.Bytes())) }
+			}
+			for _, sym := range syms {
+				if err := table.Encode(w, sym); err != nil {
+					t.Fatalf("%s encode %d error: %v", name, sym, err)
+				}
+			}
+			w.Flush()
+
+			for _, r := range []bitio.BitReader{newReader(), bitReaderOnly{newReader()}} {
+				for i, exp := range syms {
+					if sym, err := table.Decode(r); err != nil || sym != exp {
+						t.Fatalf("%s order %d %T decode #%d %d (%v), want %d", name, order, r, i, sym, err, exp)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTable_Error(t *testing.T) {
+	if _, err := huffman.New([]uint8{1, 1, 1}, huffman.MSBFirst); err == nil {
+		t.Fatalf("New over-subscribed wants error")
+	}
+	if _, err := huffman.New([]uint8{0, 0}, huffman.MSBFirst); err == nil {
+		t.Fatalf("New no code wants error")
+	}
+	if _, err := huffman.New([]uint8{33, 1}, huffman.MSBFirst); err == nil {
+		t.Fatalf("New too long code wants error")
+	}
+
+	// incomplete code: 0 = "0", 1 = "10"
+	table, _ := huffman.New([]uint8{1, 2}, huffman.MSBFirst)
+	if err := table.Encode(bitio.NewBitWriteBuffer(io.Discard), 2); errors.Is(err, huffman.ErrInvalidSymbol) == false {
+		t.Fatalf("Encode error %v, want %v", err, huffman.ErrInvalidSymbol)
+	}
+
+	tests := []struct {
+		name string
+		raw  []byte
+		skip int
+		err  error
+	}{
+		{"empty", []byte{}, 0, io.EOF},
+		{"unassigned code", []byte{0xc0}, 0, huffman.ErrInvalidCode},
+		{"incomplete code", []byte{0xff}, 7, io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		for _, r := range []bitio.BitReader{
+			bitio.NewBitReadBuffer(bytes.NewReader(tt.raw)),
+			bitReaderOnly{bitio.NewBitReadBuffer(bytes.NewReader(tt.raw))},
+		} {
+			var b byte
+			r.ReadBit(&b, tt.skip)
+			if _, err := table.Decode(r); errors.Is(err, tt.err) == false {
+				t.Fatalf("%s %T decode error %v, want %v", tt.name, r, err, tt.err)
+			}
+		}
+	}
+}
+
+func TestFromCodewords(t *testing.T) {
+	// non canonical code
+	words := []huffman.Codeword{
+		{Symbol: 0, Code: 0x3, Len: 2},  // 11
+		{Symbol: 1, Code: 0x0, Len: 1},  // 0
+		{Symbol: 2, Code: 0x5, Len: 3},  // 101
+		{Symbol: 5, Code: 0x9, Len: 4},  // 1001
+		{Symbol: 7, Code: 0x10, Len: 5}, // 10000
+	}
+	for _, order := range []huffman.BitOrder{huffman.MSBFirst, huffman.LSBFirst} {
+		table, err := huffman.FromCodewords(words, order)
+		if err != nil {
+			t.Fatalf("FromCodewords error: %v", err)
+		}
+		for _, w := range words {
+			if code, n := table.Code(w.Symbol); code != w.Code || n != w.Len {
+				t.Fatalf("Code(%d) returns %b (%d bit), want %s", w.Symbol, code, n, w)
+			}
+		}
+		if exp := []uint8{2, 1, 3, 0, 0, 4, 0, 5}; reflect.DeepEqual(table.Lengths(), exp) == false {
+			t.Fatalf("Lengths returns %v, want %v", table.Lengths(), exp)
+		}
+
+		syms := []int{7, 0, 1, 2, 5, 1, 7}
+		newWriter := func(b *bytes.Buffer) bitio.BitWriter { return bitio.NewBitWriteBuffer(b) }
+		newReader := func(b []byte) bitio.BitReader { return bitio.NewBitReadBuffer(bytes.NewReader(b)) }
+		if order == huffman.LSBFirst {
+			newWriter = func(b *bytes.Buffer) bitio.BitWriter { return bitio.NewLSBWriteBuffer(b) }
+			newReader = func(b []byte) bitio.BitReader { return bitio.NewLSBReadBuffer(bytes.NewReader(b)) }
+		}
+
+		buf := new(bytes.Buffer)
+		w := newWriter(buf)
+		for _, sym := range syms {
+			table.Encode(w, sym)
+		}
+		w.Flush()
+
+		for _, r := range []bitio.BitReader{newReader(buf.Bytes()), bitReaderOnly{newReader(buf.Bytes())}} {
+			for i, exp := range syms {
+				if sym, err := table.Decode(r); err != nil || sym != exp {
+					t.Fatalf("order %d %T decode #%d %d (%v), want %d", order, r, i, sym, err, exp)
+				}
+			}
+		}
+	}
+
+	// unassigned code 1000_1
+	table, _ := huffman.FromCodewords(words, huffman.MSBFirst)
+	for _, r := range []bitio.BitReader{
+		bitio.NewBitReadBuffer(bytes.NewReader([]byte{0x88})),
+		bitReaderOnly{bitio.NewBitReadBuffer(bytes.NewReader([]byte{0x88}))},
+	} {
+		if _, err := table.Decode(r); errors.Is(err, huffman.ErrInvalidCode) == false {
+			t.Fatalf("%T decode error %v, want %v", r, err, huffman.ErrInvalidCode)
+		}
+	}
+}
+
+func TestFromCodewords_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []huffman.Codeword
+		err   error
+	}{
+		{"no code", nil, nil},
+		{"prefix", []huffman.Codeword{{0, 0x1, 2}, {1, 0x2, 3}}, huffman.ErrAmbiguousCode},
+		{"same code", []huffman.Codeword{{0, 0x1, 2}, {1, 0x1, 2}}, huffman.ErrAmbiguousCode},
+		{"same symbol", []huffman.Codeword{{0, 0x0, 1}, {0, 0x1, 1}}, nil},
+		{"negative symbol", []huffman.Codeword{{-1, 0x0, 1}}, nil},
+		{"zero length", []huffman.Codeword{{0, 0x0, 0}}, nil},
+		{"too long code", []huffman.Codeword{{0, 0x0, 33}}, nil},
+		{"code exceeds length", []huffman.Codeword{{0, 0x4, 2}}, nil},
+	}
+	for _, tt := range tests {
+		_, err := huffman.FromCodewords(tt.words, huffman.MSBFirst)
+		if err == nil || (tt.err != nil && errors.Is(err, tt.err) == false) {
+			t.Fatalf("%s error %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func BenchmarkTable_Decode(b *testing.B) {
+	benchmarkDecode(b, func(r bitio.BitReader) bitio.BitReader { return r })
+}
+
+func BenchmarkTable_Decode_BitByBit(b *testing.B) {
+	benchmarkDecode(b, func(r bitio.BitReader) bitio.BitReader { return bitReaderOnly{r} })
+}
+
+func benchmarkDecode(b *testing.B, wrap func(bitio.BitReader) bitio.BitReader) {
+	table, _ := huffman.New(fixedLengths(), huffman.MSBFirst)
+
+	buf := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(buf)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1024; i++ {
+		table.Encode(w, rnd.Intn(288))
+	}
+	w.Flush()
+	raw := buf.Bytes()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := wrap(bitio.NewBitReadBuffer(bytes.NewReader(raw)))
+		for j := 0; j < 1024; j++ {
+			table.Decode(r)
+		}
+	}
+}
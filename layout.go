@@ -0,0 +1,180 @@
+package bitio
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldLayout describes the position of struct field in bit-field data.
+type FieldLayout struct {
+	Name    string    // dotted field path (ex: "Header.Entries[2].Size")
+	Offset  int       // bit offset from start of struct (-1: depends on value)
+	Bits    int       // bit size of field (-1: depends on value)
+	Endian  ByteOrder // endianness of field value
+	Dynamic bool      // field size depends on value
+}
+
+// Layout returns the layout of struct fields.
+// rt is struct type or pointer type of struct.
+// Nested struct and fixed length slice of struct are expanded to their fields.
+// Returns error if rt is not struct or has invalid tags.
+func Layout(rt reflect.Type) ([]FieldLayout, error) {
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	plan, err := compilePlan(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, _ := plan.layout(nil, "", 0)
+	return layout, nil
+}
+
+// SizeOf returns bit size of bit-field data of v.
+// v is struct value or pointer of struct.
+// The size is static for fixed layout, or depends on length of slices for variable layout.
+// Returns error if v is not struct or has invalid tags.
+func SizeOf(v interface{}) (int, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return 0, fmt.Errorf("SizeOf: argument wants to struct")
+	}
+
+	plan, err := compilePlan(rv.Type())
+	if err != nil {
+		return 0, err
+	}
+
+	return plan.sizeOf(rv), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// layout appends field layouts of struct to dst.
+// offset is bit offset of struct (-1: depends on value).
+// Returns appended layouts and bit offset of end of struct.
+func (p *structPlan) layout(dst []FieldLayout, prefix string, offset int) ([]FieldLayout, int) {
+	for i := range p.fields {
+		fp := &p.fields[i]
+		name := prefix + fp.name
+
+		switch {
+		case fp.sub != nil && fp.kind != reflect.Slice:
+			// nested struct
+			dst, offset = fp.sub.layout(dst, name+".", offset)
+
+		case fp.sub != nil && fp.lenRef < 0:
+			// fixed length slice of struct
+			for j := 0; j < fp.len; j++ {
+				dst, offset = fp.sub.layout(dst, fmt.Sprintf("%s[%d].", name, j), offset)
+			}
+
+		default:
+			bits := fp.staticBits()
+			dst = append(dst, FieldLayout{
+				Name:    name,
+				Offset:  offset,
+				Bits:    bits,
+				Endian:  fp.endian,
+				Dynamic: bits < 0,
+			})
+			if offset >= 0 && bits >= 0 {
+				offset += bits
+			} else {
+				offset = -1
+			}
+		}
+	}
+
+	return dst, offset
+}
+
+// sizeOf returns bit size of struct value rv.
+func (p *structPlan) sizeOf(rv reflect.Value) int {
+	if p.bits >= 0 {
+		return p.bits
+	}
+
+	size := 0
+	for i := range p.fields {
+		fp := &p.fields[i]
+		size += fp.sizeOf(rv, rv.Field(fp.index))
+	}
+	return size
+}
+
+// staticBits returns static bit size of field (-1: depends on value).
+func (fp *fieldPlan) staticBits() int {
+	if fp.enc != nil || fp.block != nil {
+		return -1
+	}
+
+	bits := fp.bits
+	if fp.sub != nil {
+		bits = fp.sub.bits
+	}
+
+	if fp.kind != reflect.Slice {
+		return bits
+	}
+	if fp.lenRef >= 0 || bits < 0 {
+		return -1
+	}
+	return bits * fp.len
+}
+
+// sizeOf returns bit size of field value ptr.
+// rv is struct value which has the field.
+func (fp *fieldPlan) sizeOf(rv, ptr reflect.Value) int {
+	if bits := fp.staticBits(); bits >= 0 {
+		return bits
+	}
+	if fp.block != nil {
+		return fp.block.bitLen(ptr)
+	}
+	if fp.kind != reflect.Slice {
+		switch {
+		case fp.enc != nil && fp.lenOf >= 0:
+			return fp.enc.bitLen(rv, uint64(rv.Field(fp.lenOf).Len()))
+		case fp.enc != nil:
+			return fp.enc.bitLen(rv, encodedValue(ptr))
+		}
+		return fp.sub.sizeOf(ptr)
+	}
+
+	length := fp.len
+	if fp.lenRef >= 0 {
+		length = ptr.Len()
+	}
+	if fp.enc != nil {
+		// zero value for shortage
+		size := 0
+		for i := 0; i < length; i++ {
+			var v uint64
+			if i < ptr.Len() {
+				v = encodedValue(ptr.Index(i))
+			}
+			size += fp.enc.bitLen(rv, v)
+		}
+		return size
+	}
+	if fp.sub == nil {
+		return fp.bits * length
+	}
+
+	// zero value for shortage
+	size := 0
+	for i := 0; i < length; i++ {
+		if i < ptr.Len() {
+			size += fp.sub.sizeOf(ptr.Index(i))
+		} else {
+			size += fp.sub.sizeOf(reflect.Zero(ptr.Type().Elem()))
+		}
+	}
+	return size
+}
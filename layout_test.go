@@ -0,0 +1,132 @@
+package bitio_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+type layoutPoint struct {
+	X uint8 `bit:"4"`
+	Y uint8 `bit:"4"`
+}
+
+type layoutRecord struct {
+	Kind   uint8 `bit:"3"`
+	Origin layoutPoint
+	Corner []layoutPoint `len:"2"`
+	Size   uint16        `bit:"12" endian:"big"`
+	Count  uint8         `bit:"5"`
+	Data   []byte        `byte:"1" len:"Count"`
+	CRC    uint32        `bit:"32"`
+}
+
+func TestLayout(t *testing.T) {
+	exp := []bitio.FieldLayout{
+		{Name: "Kind", Offset: 0, Bits: 3, Endian: bitio.LittleEndian},
+		{Name: "Origin.X", Offset: 3, Bits: 4, Endian: bitio.LittleEndian},
+		{Name: "Origin.Y", Offset: 7, Bits: 4, Endian: bitio.LittleEndian},
+		{Name: "Corner[0].X", Offset: 11, Bits: 4, Endian: bitio.LittleEndian},
+		{Name: "Corner[0].Y", Offset: 15, Bits: 4, Endian: bitio.LittleEndian},
+		{Name: "Corner[1].X", Offset: 19, Bits: 4, Endian: bitio.LittleEndian},
+		{Name: "Corner[1].Y", Offset: 23, Bits: 4, Endian: bitio.LittleEndian},
+		{Name: "Size", Offset: 27, Bits: 12, Endian: bitio.BigEndian},
+		{Name: "Count", Offset: 39, Bits: 5, Endian: bitio.LittleEndian},
+		{Name: "Data", Offset: 44, Bits: -1, Endian: bitio.LittleEndian, Dynamic: true},
+		{Name: "CRC", Offset: -1, Bits: 32, Endian: bitio.LittleEndian},
+	}
+
+	for _, rt := range []reflect.Type{
+		reflect.TypeOf(layoutRecord{}),
+		reflect.TypeOf(&layoutRecord{}),
+	} {
+		layout, err := bitio.Layout(rt)
+		if err != nil {
+			t.Fatalf("Layout %v error: %v", rt, err)
+		}
+		if reflect.DeepEqual(layout, exp) == false {
+			t.Fatalf("Layout %v returns %+v, want %+v", rt, layout, exp)
+		}
+	}
+}
+
+func TestLayout_Error(t *testing.T) {
+	if _, err := bitio.Layout(reflect.TypeOf(0)); err == nil {
+		t.Fatalf("Layout int wants error")
+	}
+	if _, err := bitio.Layout(reflect.TypeOf(struct{ Val int }{})); err == nil {
+		t.Fatalf("Layout invalid tag wants error")
+	}
+}
+
+func TestSizeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		bits int
+	}{
+		{
+			name: "static",
+			v:    layoutPoint{},
+			bits: 8,
+		},
+		{
+			name: "dynamic",
+			v:    &layoutRecord{Data: []byte{1, 2, 3}},
+			bits: 44 + 24 + 32,
+		},
+		{
+			name: "dynamic slice of struct",
+			v: &struct {
+				Count  uint8 `bit:"4"`
+				Points []struct {
+					Len  uint8  `bit:"4"`
+					Data []bool `bit:"1" len:"Len"`
+				} `len:"Count"`
+			}{
+				Points: []struct {
+					Len  uint8  `bit:"4"`
+					Data []bool `bit:"1" len:"Len"`
+				}{
+					{Data: []bool{true}},
+					{Data: []bool{true, false, true}},
+				},
+			},
+			bits: 4 + (4 + 1) + (4 + 3),
+		},
+	}
+
+	for _, tt := range tests {
+		bits, err := bitio.SizeOf(tt.v)
+		if err != nil {
+			t.Fatalf("SizeOf %q error: %v", tt.name, err)
+		}
+		if bits != tt.bits {
+			t.Fatalf("SizeOf %q returns %d, want %d", tt.name, bits, tt.bits)
+		}
+
+		w := bitio.NewBitFieldWriter(new(bytes.Buffer))
+		if n, err := w.WriteStruct(tt.v); err != nil || n != bits {
+			t.Fatalf("SizeOf %q returns %d, WriteStruct writes %d (%v)", tt.name, bits, n, err)
+		}
+	}
+}
+
+func TestSizeOf_BitFieldTests(t *testing.T) {
+	for _, tt := range bitfieldTests {
+		r := bitio.NewBitFieldReader(bytes.NewReader(tt.raw))
+		if _, err := r.ReadStruct(tt.ptr); err != nil {
+			t.Fatalf("SizeOf test initialize %q error: %v", tt.name, err)
+		}
+
+		bits, err := bitio.SizeOf(tt.ptr)
+		if err != nil {
+			t.Fatalf("SizeOf %q error: %v", tt.name, err)
+		}
+		if bits != tt.bits {
+			t.Fatalf("SizeOf %q returns %d, want %d", tt.name, bits, tt.bits)
+		}
+	}
+}
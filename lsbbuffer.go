@@ -0,0 +1,246 @@
+package bitio
+
+import (
+	"fmt"
+	"io"
+)
+
+// NewLSBReadBuffer returns LSBReadBuffer
+func NewLSBReadBuffer(r io.Reader) *LSBReadBuffer {
+	return &LSBReadBuffer{
+		r:    byteQueue{r: r},
+		buff: 0,
+		left: 0,
+	}
+}
+
+// LSBReadBuffer is implemented by BitReader and BitPeeker.
+// Bits are read from the least significant bit of each byte. (ex: DEFLATE)
+// The first read bit is stored to the least significant bit of value,
+// so multi-bit value should be converted with BigEndian. (bitio.Read(r, n, bitio.BigEndian, &v))
+type LSBReadBuffer struct {
+	r    byteQueue
+	buff byte // unread bits are right justified
+	left int
+}
+
+// ReadBit reads single data (bitSize) and returns read size.
+// If error happen, err will be set.
+// Output data is stored right justified, the first read bit is the least significant bit.
+func (obj *LSBReadBuffer) ReadBit(b *byte, bitSize int) (nBit int, err error) {
+	if b == nil {
+		return 0, fmt.Errorf("bitio: argument *b is null pointer")
+	}
+	if bitSize > 8 {
+		return 0, fmt.Errorf("bitio: ReadBit requires read size <= 8")
+	}
+	*b = 0
+
+	for nBit < bitSize {
+		if obj.left == 0 {
+			if obj.buff, err = obj.r.ReadByte(); err != nil {
+				return
+			}
+			obj.left = 8
+		}
+
+		n := bitSize - nBit
+		if n > obj.left {
+			n = obj.left
+		}
+		*b |= (obj.buff & (1<<uint(n) - 1)) << uint(nBit)
+		obj.buff >>= uint(n)
+		obj.left -= n
+		nBit += n
+	}
+
+	return
+}
+
+// ReadBits reads data (bitSize) and returns read size.
+// If error happen, err will be set.
+// Output data is stored right justified, the first read bit is the least significant bit of p[len(p)-1].
+func (obj *LSBReadBuffer) ReadBits(p []byte, bitSize int) (nBit int, err error) {
+	if len(p)*8 < bitSize {
+		return 0, fmt.Errorf("bitio: argument p[] is %d bits, want %d bits", len(p)*8, bitSize)
+	}
+
+	for i := len(p) - (bitSize+7)/8; i < len(p); i++ {
+		p[i] = 0
+	}
+	for i := len(p) - 1; nBit < bitSize; i-- {
+		n := bitSize - nBit
+		if n > 8 {
+			n = 8
+		}
+
+		var m int
+		m, err = obj.ReadBit(&p[i], n)
+		nBit += m
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Read reads data len(p) size and returns read size.
+// If error happen, err will be set.
+func (obj *LSBReadBuffer) Read(p []byte) (nByte int, err error) {
+	for nByte < len(p) {
+		if _, err = obj.ReadBit(&p[nByte], 8); err != nil {
+			return
+		}
+		nByte++
+	}
+	return
+}
+
+// PeekBits returns next bits (bitSize <= 56) without consuming them.
+// Output data is stored right justified, the first bit is the least significant bit.
+// nBit is less than bitSize only at the end of data, and missing bits are 0.
+func (obj *LSBReadBuffer) PeekBits(bitSize int) (v uint64, nBit int, err error) {
+	if bitSize < 0 || bitSize > 56 {
+		return 0, 0, fmt.Errorf("bitio: PeekBits requires read size <= 56")
+	}
+
+	queued, err := obj.r.fill((bitSize - obj.left + 7) / 8)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	v = uint64(obj.buff)
+	nBit = obj.left
+	for _, b := range queued {
+		if nBit >= bitSize {
+			break
+		}
+		v |= uint64(b) << uint(nBit)
+		nBit += 8
+	}
+
+	if nBit > bitSize {
+		nBit = bitSize
+	}
+	return v & (1<<uint(bitSize) - 1), nBit, nil
+}
+
+// SkipBits consumes bits (bitSize) and returns consumed size.
+// If error happen, err will be set.
+func (obj *LSBReadBuffer) SkipBits(bitSize int) (nBit int, err error) {
+	var b byte
+	for nBit < bitSize {
+		n := bitSize - nBit
+		if n > 8 {
+			n = 8
+		}
+		m, err := obj.ReadBit(&b, n)
+		nBit += m
+		if err != nil {
+			return nBit, err
+		}
+	}
+	return nBit, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// NewLSBWriteBuffer returns LSBWriteBuffer
+func NewLSBWriteBuffer(w io.Writer) *LSBWriteBuffer {
+	return &LSBWriteBuffer{
+		w:    w,
+		buff: 0,
+		left: 0,
+	}
+}
+
+// LSBWriteBuffer is implemented by BitWriter.
+// Bits are written from the least significant bit of each byte. (ex: DEFLATE)
+// The least significant bit of value is written first,
+// so multi-bit value should be converted with BigEndian. (bitio.Write(w, n, bitio.BigEndian, v))
+type LSBWriteBuffer struct {
+	w    io.Writer
+	buff byte // written bits are right justified
+	left int
+}
+
+// WriteBit writes single data (bitSize) and returns write size.
+// If error happen, err will be set.
+// Input data is stored right justified, the least significant bit is written first.
+func (obj *LSBWriteBuffer) WriteBit(p byte, bitSize int) (nBit int, err error) {
+	if bitSize > 8 {
+		return 0, fmt.Errorf("bitio: WriteBit requires write size <= 8")
+	}
+
+	for nBit < bitSize {
+		n := bitSize - nBit
+		if n > 8-obj.left {
+			n = 8 - obj.left
+		}
+		obj.buff |= (p >> uint(nBit) & (1<<uint(n) - 1)) << uint(obj.left)
+		obj.left += n
+		nBit += n
+
+		if obj.left == 8 {
+			if err = obj.Flush(); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// WriteBits writes data (bitSize) and returns write size.
+// If error happen, err will be set.
+// Input data is stored right justified, the least significant bit of p[len(p)-1] is written first.
+func (obj *LSBWriteBuffer) WriteBits(p []byte, bitSize int) (nBit int, err error) {
+	if len(p)*8 < bitSize {
+		return 0, fmt.Errorf("bitio: argument p[] is %d bits, want %d bits", len(p)*8, bitSize)
+	}
+
+	for i := len(p) - 1; nBit < bitSize; i-- {
+		n := bitSize - nBit
+		if n > 8 {
+			n = 8
+		}
+
+		var m int
+		m, err = obj.WriteBit(p[i], n)
+		nBit += m
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Write writes data len(p) size and returns write size.
+// If error happen, err will be set.
+func (obj *LSBWriteBuffer) Write(p []byte) (nByte int, err error) {
+	for nByte < len(p) {
+		if _, err = obj.WriteBit(p[nByte], 8); err != nil {
+			return
+		}
+		nByte++
+	}
+	return
+}
+
+// Flush writes data if obj.buff is not empty. (0 left padding)
+// If error happen, err will be set.
+func (obj *LSBWriteBuffer) Flush() error {
+	if obj.left == 0 {
+		return nil
+	}
+
+	if _, err := obj.w.Write([]byte{obj.buff}); err != nil {
+		return err
+	}
+
+	obj.buff = 0
+	obj.left = 0
+	return nil
+}
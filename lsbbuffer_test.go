@@ -0,0 +1,131 @@
+package bitio_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+func TestLSB_interface(t *testing.T) {
+	// Only compile test
+
+	var r bitio.BitReader = &bitio.LSBReadBuffer{}
+	_ = r
+	var p bitio.BitPeeker = &bitio.LSBReadBuffer{}
+	_ = p
+	var w bitio.BitWriter = &bitio.LSBWriteBuffer{}
+	_ = w
+}
+
+var lsbTests = []struct {
+	name  string
+	bits  []int
+	value []uint64
+	raw   []byte
+}{
+	{
+		name:  "bits in byte",
+		bits:  []int{1, 2, 5},
+		value: []uint64{1, 1, 0x1f},
+		raw:   []byte{0xfb},
+	},
+	{
+		name:  "value over byte boundary",
+		bits:  []int{4, 12, 8},
+		value: []uint64{0x5, 0xabc, 0x12},
+		raw:   []byte{0xc5, 0xab, 0x12},
+	},
+	{
+		name:  "64 bit value",
+		bits:  []int{3, 64},
+		value: []uint64{0x0, 0x0123456789abcdef},
+		raw:   []byte{0x78, 0x6f, 0x5e, 0x4d, 0x3c, 0x2b, 0x1a, 0x09, 0x00},
+	},
+}
+
+func TestLSBWriteBuffer_Write(t *testing.T) {
+	for _, tt := range lsbTests {
+		b := new(bytes.Buffer)
+		w := bitio.NewLSBWriteBuffer(b)
+		for i, n := range tt.bits {
+			if err := bitio.Write(w, n, bitio.BigEndian, tt.value[i]); err != nil {
+				t.Fatalf("%q write error: %v", tt.name, err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("%q flush error: %v", tt.name, err)
+		}
+
+		if reflect.DeepEqual(b.Bytes(), tt.raw) == false {
+			t.Fatalf("%q write %#v, want %#v", tt.name, b.Bytes(), tt.raw)
+		}
+	}
+}
+
+func TestLSBReadBuffer_Read(t *testing.T) {
+	for _, tt := range lsbTests {
+		r := bitio.NewLSBReadBuffer(bytes.NewReader(tt.raw))
+		for i, n := range tt.bits {
+			var v uint64
+			if err := bitio.Read(r, n, bitio.BigEndian, &v); err != nil {
+				t.Fatalf("%q read error: %v", tt.name, err)
+			}
+			if v != tt.value[i] {
+				t.Fatalf("%q read %#x, want %#x", tt.name, v, tt.value[i])
+			}
+		}
+	}
+}
+
+func TestLSBBuffer_Bytes(t *testing.T) {
+	raw := []byte{0x12, 0x34, 0x56}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewLSBWriteBuffer(b)
+	w.WriteBit(0x0f, 4)
+	w.Write(raw)
+	w.Flush()
+	if exp := []byte{0x2f, 0x41, 0x63, 0x05}; reflect.DeepEqual(b.Bytes(), exp) == false {
+		t.Fatalf("Write %#v, want %#v", b.Bytes(), exp)
+	}
+
+	r := bitio.NewLSBReadBuffer(b)
+	var v byte
+	r.ReadBit(&v, 4)
+	p := make([]byte, 3)
+	if _, err := r.Read(p); err != nil || reflect.DeepEqual(p, raw) == false {
+		t.Fatalf("Read %#v (%v), want %#v", p, err, raw)
+	}
+	if _, err := r.Read(p); err != io.EOF {
+		t.Fatalf("Read error %v, want %v", err, io.EOF)
+	}
+}
+
+func TestLSBReadBuffer_PeekBits(t *testing.T) {
+	r := bitio.NewLSBReadBuffer(bytes.NewReader([]byte{0xc5, 0xab, 0x12}))
+
+	tests := []struct {
+		skip int
+		peek int
+		v    uint64
+		nBit int
+	}{
+		{0, 4, 0x5, 4},
+		{4, 12, 0xabc, 12},
+		{0, 16, 0x2abc, 16},
+		{12, 16, 0x12, 8},
+		{8, 8, 0x00, 0},
+	}
+	for _, tt := range tests {
+		if n, err := r.SkipBits(tt.skip); err != nil || n != tt.skip {
+			t.Fatalf("SkipBits(%d) = %d (%v)", tt.skip, n, err)
+		}
+		v, n, err := r.PeekBits(tt.peek)
+		if err != nil || v != tt.v || n != tt.nBit {
+			t.Fatalf("PeekBits(%d) = %#x, %d (%v), want %#x, %d", tt.peek, v, n, err, tt.v, tt.nBit)
+		}
+	}
+}
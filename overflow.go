@@ -0,0 +1,100 @@
+package bitio
+
+import (
+	"fmt"
+)
+
+// OverflowPolicy is the handling of value which does not fit in bit-field size.
+//
+// Signed value of n bits is accepted in range [-2^(n-1), 2^n-1] by all policies,
+// because both of two's complement and unsigned value are written as same bits.
+// OverflowSaturate writes signed value out of range as the bound of two's complement (-2^(n-1) or 2^(n-1)-1).
+// Only 0 is accepted by 0 bit.
+type OverflowPolicy int
+
+const (
+	// OverflowError returns error wrapping ErrValueOverflow. (default)
+	OverflowError OverflowPolicy = iota
+	// OverflowTruncate writes lower bits of value.
+	OverflowTruncate
+	// OverflowSaturate writes the nearest value in range.
+	OverflowSaturate
+)
+
+// WriteOption is the option of writing value.
+type WriteOption interface {
+	applyWrite(*writeConfig)
+}
+
+// writeConfig store write options.
+type writeConfig struct {
+	overflow OverflowPolicy
+}
+
+func (p OverflowPolicy) applyWrite(c *writeConfig) {
+	c.overflow = p
+}
+
+func newWriteConfig(opts []WriteOption) writeConfig {
+	c := writeConfig{}
+	for _, opt := range opts {
+		opt.applyWrite(&c)
+	}
+	return c
+}
+
+// fitUint returns unsigned value v fitted in nBit bits by overflow policy.
+func fitUint(v uint64, nBit int, policy OverflowPolicy) (uint64, error) {
+	if nBit >= 64 {
+		return v, nil
+	}
+
+	var max uint64
+	if nBit > 0 {
+		max = uint64(1)<<nBit - 1
+	}
+	if v <= max {
+		return v, nil
+	}
+
+	switch policy {
+	case OverflowTruncate:
+		return v & max, nil
+	case OverflowSaturate:
+		return max, nil
+	default:
+		return 0, fmt.Errorf("value %d exceeds %d bit: %w", v, nBit, ErrValueOverflow)
+	}
+}
+
+// fitInt returns signed value v fitted in nBit bits by overflow policy.
+func fitInt(v int64, nBit int, policy OverflowPolicy) (uint64, error) {
+	if nBit >= 64 {
+		return uint64(v), nil
+	}
+	if nBit <= 0 {
+		if v == 0 || policy == OverflowTruncate || policy == OverflowSaturate {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("value %d exceeds %d bit: %w", v, nBit, ErrValueOverflow)
+	}
+
+	min := -(int64(1) << (nBit - 1))
+	max := int64(1)<<nBit - 1
+	if min <= v && v <= max {
+		return uint64(v), nil
+	}
+
+	switch policy {
+	case OverflowTruncate:
+		return uint64(v) & uint64(max), nil
+	case OverflowSaturate:
+		// bound of two's complement
+		if v < min {
+			return uint64(min), nil
+		}
+		return uint64(-min - 1), nil
+	default:
+		return 0, fmt.Errorf("value %d exceeds %d bit: %w", v, nBit, ErrValueOverflow)
+	}
+}
@@ -0,0 +1,208 @@
+package bitio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+func TestWrite_Overflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		write  func(w bitio.BitWriter, policy bitio.OverflowPolicy) error
+		policy bitio.OverflowPolicy
+		exp    []byte // nil: ErrValueOverflow
+	}{
+		// unsigned
+		{"uint 300 error", writeValue(uint16(300), 8), bitio.OverflowError, nil},
+		{"uint 300 truncate", writeValue(uint16(300), 8), bitio.OverflowTruncate, []byte{0x2c}},
+		{"uint 300 saturate", writeValue(uint16(300), 8), bitio.OverflowSaturate, []byte{0xff}},
+		{"uint 255 error", writeValue(uint16(255), 8), bitio.OverflowError, []byte{0xff}},
+		// signed
+		{"int -100 error", writeValue(int8(-100), 4), bitio.OverflowError, nil},
+		{"int -100 truncate", writeValue(int8(-100), 4), bitio.OverflowTruncate, []byte{0xc0}},
+		{"int -100 saturate", writeValue(int8(-100), 4), bitio.OverflowSaturate, []byte{0x80}},
+		{"int 20 saturate", writeValue(int8(20), 4), bitio.OverflowSaturate, []byte{0x70}},
+		{"int 10 saturate", writeValue(int8(10), 4), bitio.OverflowSaturate, []byte{0xa0}},
+		{"int 10 error", writeValue(int8(10), 4), bitio.OverflowError, []byte{0xa0}},
+		{"int -8 error", writeValue(int8(-8), 4), bitio.OverflowError, []byte{0x80}},
+		{"int 15 error", writeValue(int8(15), 4), bitio.OverflowError, []byte{0xf0}},
+		{"int 16 error", writeValue(int8(16), 4), bitio.OverflowError, nil},
+		// 0 bit
+		{"uint 0 in 0 bit", writeValue(uint16(0), 0), bitio.OverflowError, []byte{}},
+		{"uint 1 in 0 bit error", writeValue(uint16(1), 0), bitio.OverflowError, nil},
+		{"uint 1 in 0 bit saturate", writeValue(uint16(1), 0), bitio.OverflowSaturate, []byte{}},
+		{"int 0 in 0 bit", writeValue(int8(0), 0), bitio.OverflowError, []byte{}},
+		{"int -1 in 0 bit error", writeValue(int8(-1), 0), bitio.OverflowError, nil},
+		{"int -1 in 0 bit truncate", writeValue(int8(-1), 0), bitio.OverflowTruncate, []byte{}},
+		// full size
+		{"int64 min", writeValue(int64(-1<<63), 64), bitio.OverflowError, []byte{0, 0, 0, 0, 0, 0, 0, 0x80}},
+	}
+
+	for _, tt := range tests {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+
+		err := tt.write(w, tt.policy)
+		if tt.exp == nil {
+			if errors.Is(err, bitio.ErrValueOverflow) == false {
+				t.Fatalf("Write %q error %v, want %v", tt.name, err, bitio.ErrValueOverflow)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Write %q error: %v", tt.name, err)
+		}
+
+		w.Flush()
+		if bytes.Equal(b.Bytes(), tt.exp) == false {
+			t.Fatalf("Write %q write %#v, want %#v", tt.name, b.Bytes(), tt.exp)
+		}
+	}
+}
+
+func TestWrite_OverflowSaturateSigned(t *testing.T) {
+	tests := []struct {
+		v    int64
+		nBit int
+		exp  int64
+	}{
+		{1000, 4, 7},
+		{-1000, 4, -8},
+		{7, 4, 7},
+		{-8, 4, -8},
+		{1 << 40, 32, 1<<31 - 1},
+		{-1 << 40, 32, -1 << 31},
+	}
+
+	for _, tt := range tests {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		if err := bitio.Write(w, tt.nBit, bitio.BigEndian, tt.v, bitio.OverflowSaturate); err != nil {
+			t.Fatalf("Write(%d, %d bit) error: %v", tt.v, tt.nBit, err)
+		}
+		w.Flush()
+
+		r := bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes()))
+		var got int64
+		err := bitio.Read(r, tt.nBit, bitio.BigEndian, &got)
+		got = got << (64 - tt.nBit) >> (64 - tt.nBit) // two's complement of n bits
+		if err != nil || got != tt.exp {
+			t.Fatalf("Write(%d, %d bit) reads back %d (%v), want %d", tt.v, tt.nBit, got, err, tt.exp)
+		}
+	}
+}
+
+func writeValue[T int8 | int64 | uint16](v T, nBit int) func(bitio.BitWriter, bitio.OverflowPolicy) error {
+	return func(w bitio.BitWriter, policy bitio.OverflowPolicy) error {
+		return bitio.Write(w, nBit, bitio.LittleEndian, v, policy)
+	}
+}
+
+func TestWriteSlice_Overflow(t *testing.T) {
+	w := bitio.NewBitWriteBuffer(io.Discard)
+	err := bitio.WriteSlice(w, 4, bitio.BigEndian, []uint8{1, 2, 16})
+	if errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteSlice error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	b := new(bytes.Buffer)
+	w = bitio.NewBitWriteBuffer(b)
+	if err = bitio.WriteSlice(w, 4, bitio.BigEndian, []uint8{1, 16}, bitio.OverflowSaturate); err != nil {
+		t.Fatalf("WriteSlice error: %v", err)
+	}
+	w.Flush()
+	if reflect.DeepEqual(b.Bytes(), []byte{0x1f}) == false {
+		t.Fatalf("WriteSlice write %#v, want %#v", b.Bytes(), []byte{0x1f})
+	}
+}
+
+type overflowRecord struct {
+	Val1 uint16  `bit:"8"`
+	Val2 []int32 `bit:"4" len:"2"`
+}
+
+func TestBitFieldWriter_Overflow(t *testing.T) {
+	v := &overflowRecord{Val1: 300, Val2: []int32{-1, 7}}
+
+	w := bitio.NewBitFieldWriter(io.Discard)
+	_, err := w.WriteStruct(v)
+
+	var fe *bitio.FieldError
+	if errors.As(err, &fe) == false || fe.Field != "Val1" {
+		t.Fatalf("WriteStruct error %v, want FieldError of Val1", err)
+	}
+	if errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteStruct error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	b := new(bytes.Buffer)
+	w = bitio.NewBitFieldWriter(b)
+	w.SetOverflowPolicy(bitio.OverflowSaturate)
+	if _, err = w.WriteStruct(v); err != nil {
+		t.Fatalf("WriteStruct error: %v", err)
+	}
+	w.Flush()
+	if exp := []byte{0xff, 0xf7}; reflect.DeepEqual(b.Bytes(), exp) == false {
+		t.Fatalf("WriteStruct write %#v, want %#v", b.Bytes(), exp)
+	}
+}
+
+type saturateRecord struct {
+	Val int8 `bit:"4"`
+}
+
+func TestBitFieldWriter_OverflowSaturate(t *testing.T) {
+	// saturate does not change value accepted by default policy
+	for _, v := range []int8{-8, -1, 7, 10, 15} {
+		var exp []byte
+		for _, policy := range []bitio.OverflowPolicy{bitio.OverflowError, bitio.OverflowSaturate} {
+			b := new(bytes.Buffer)
+			w := bitio.NewBitFieldWriter(b)
+			w.SetOverflowPolicy(policy)
+			if _, err := w.WriteStruct(&saturateRecord{v}); err != nil {
+				t.Fatalf("WriteStruct %d error: %v", v, err)
+			}
+			w.Flush()
+			if exp == nil {
+				exp = b.Bytes()
+			} else if bytes.Equal(b.Bytes(), exp) == false {
+				t.Fatalf("WriteStruct %d with saturate write %#v, want %#v", v, b.Bytes(), exp)
+			}
+		}
+	}
+}
+
+func TestCodec_Overflow(t *testing.T) {
+	c, err := bitio.Compile[overflowRecord]()
+	if err != nil {
+		t.Fatalf("Compile error: %v", err)
+	}
+	v := &overflowRecord{Val1: 300, Val2: []int32{-9, 7}}
+
+	_, err = c.Write(bitio.NewBitWriteBuffer(io.Discard), v)
+	if errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("Codec write error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	if _, err = c.Write(w, v, bitio.OverflowTruncate); err != nil {
+		t.Fatalf("Codec write error: %v", err)
+	}
+	w.Flush()
+	if exp := []byte{0x2c, 0x77}; reflect.DeepEqual(b.Bytes(), exp) == false {
+		t.Fatalf("Codec write %#v, want %#v", b.Bytes(), exp)
+	}
+}
+
+func TestBitWriteBuffer_WriteBit_Oversize(t *testing.T) {
+	w := bitio.NewBitWriteBuffer(io.Discard)
+	if _, err := w.WriteBit(0x01, 9); err == nil {
+		t.Fatalf("WriteBit 9 bit wants error")
+	}
+}
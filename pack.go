@@ -0,0 +1,194 @@
+package bitio
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+//go:generate go run gen_pack.go
+
+// packChunk is number of values packed at once by WritePackedUints and ReadPackedUints.
+const packChunk = 512
+
+// PackUints packs src values of elemBit bits to dst, and returns number of written bytes.
+// Output is the same as WriteSlice with BigEndian, and the last byte is 0 right padded.
+// If value does not fit in number of bits, it is handled by OverflowPolicy option. (default: OverflowError)
+func PackUints[T uint32 | uint64](dst []byte, elemBit int, src []T, opts ...WriteOption) (int, error) {
+	if err := checkPackSize[T](elemBit); err != nil {
+		return 0, err
+	}
+	size := (len(src)*elemBit + 7) / 8
+	if len(dst) < size {
+		return 0, fmt.Errorf("bitio: argument dst[] is %d bytes, want %d bytes", len(dst), size)
+	}
+
+	config := newWriteConfig(opts)
+	kernel := packKernels[elemBit]
+	max := uint64(1)<<uint(elemBit) - 1 // elemBit = 64 is all bits
+
+	var block [8]uint64
+	n, off := 0, 0
+	for ; n+8 <= len(src); n += 8 {
+		over := uint64(0)
+		for i, v := range src[n : n+8] {
+			block[i] = uint64(v)
+			over |= uint64(v) &^ max
+		}
+		if over != 0 && elemBit < 64 {
+			if err := fitBlock(block[:], n, elemBit, config.overflow); err != nil {
+				return 0, err
+			}
+		}
+		kernel(dst[off:], &block)
+		off += elemBit
+	}
+
+	// tail values
+	if n < len(src) {
+		clear(block[:])
+		for i, v := range src[n:] {
+			block[i] = uint64(v)
+		}
+		if elemBit < 64 {
+			if err := fitBlock(block[:len(src)-n], n, elemBit, config.overflow); err != nil {
+				return 0, err
+			}
+		}
+		var tail [64]byte
+		kernel(tail[:], &block)
+		copy(dst[off:size], tail[:])
+
+		// 0 right padding
+		if pad := size*8 - len(src)*elemBit; pad > 0 {
+			dst[size-1] &^= byte(1)<<uint(pad) - 1
+		}
+	}
+
+	return size, nil
+}
+
+// UnpackUints unpacks len(dst) values of elemBit bits from src.
+// Input is the same as ReadSlice with BigEndian.
+func UnpackUints[T uint32 | uint64](dst []T, elemBit int, src []byte) error {
+	if err := checkPackSize[T](elemBit); err != nil {
+		return err
+	}
+	if bits := len(dst) * elemBit; len(src)*8 < bits {
+		return fmt.Errorf("insufficient size of source, want %d bit, has %d bit: %w", bits, len(src)*8, ErrUnexpectedEOF)
+	}
+
+	kernel := unpackKernels[elemBit]
+
+	var block [8]uint64
+	n, off := 0, 0
+	for ; n+8 <= len(dst); n += 8 {
+		kernel(&block, src[off:])
+		for i, v := range block {
+			dst[n+i] = T(v)
+		}
+		off += elemBit
+	}
+
+	// tail values
+	if n < len(dst) {
+		var tail [64]byte
+		copy(tail[:], src[off:])
+		kernel(&block, tail[:])
+		for i := range dst[n:] {
+			dst[n+i] = T(block[i])
+		}
+	}
+
+	return nil
+}
+
+// WritePackedUints writes src values of elemBit bits to BitWriter.
+// Output is the same as WriteSlice with BigEndian, and BitWriteBuffer is written by packed bytes.
+// If value does not fit in number of bits, it is handled by OverflowPolicy option. (default: OverflowError)
+func WritePackedUints[T uint32 | uint64](bw BitWriter, elemBit int, src []T, opts ...WriteOption) error {
+	if err := checkPackSize[T](elemBit); err != nil {
+		return err
+	}
+	if _, ok := bw.(*BitWriteBuffer); !ok {
+		return WriteSlice(bw, elemBit, BigEndian, src, opts...)
+	}
+
+	buf := make([]byte, packChunk*elemBit/8)
+	for n := 0; n < len(src); n += packChunk {
+		chunk := src[n:min(n+packChunk, len(src))]
+		size, err := PackUints(buf, elemBit, chunk, opts...)
+		if err != nil {
+			return fmt.Errorf("elements from %d: %w", n, err)
+		}
+
+		// the last chunk may have padding bits
+		bits := len(chunk) * elemBit
+		if pad := size*8 - bits; pad > 0 {
+			rightShift(buf[:size], uint(pad))
+		}
+		if m, err := bw.WriteBits(buf[:size], bits); err != nil {
+			return err
+		} else if m != bits {
+			return fmt.Errorf("insufficient size of write, want %d bit, write %d bit", bits, m)
+		}
+	}
+	return nil
+}
+
+// ReadPackedUints reads len(dst) values of elemBit bits from BitReader.
+// Input is the same as ReadSlice with BigEndian, and BitReadBuffer is read by packed bytes.
+func ReadPackedUints[T uint32 | uint64](br BitReader, elemBit int, dst []T) error {
+	if err := checkPackSize[T](elemBit); err != nil {
+		return err
+	}
+	if _, ok := br.(*BitReadBuffer); !ok {
+		return ReadSlice(br, elemBit, BigEndian, dst)
+	}
+
+	buf := make([]byte, packChunk*elemBit/8)
+	for n := 0; n < len(dst); n += packChunk {
+		chunk := dst[n:min(n+packChunk, len(dst))]
+		bits := len(chunk) * elemBit
+		size := (bits + 7) / 8
+
+		if m, err := br.ReadBits(buf[:size], bits); err != nil {
+			if n > 0 || m > 0 {
+				err = unexpectedEOF(err)
+			}
+			return err
+		} else if m != bits {
+			return fmt.Errorf("insufficient size of read, want %d bit, read %d bit: %w", bits, m, ErrUnexpectedEOF)
+		}
+
+		// the last chunk may have padding bits (read data is right justified)
+		if pad := size*8 - bits; pad > 0 {
+			leftShift(buf[:size], uint(pad))
+		}
+		if err := UnpackUints(chunk, elemBit, buf[:size]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPackSize checks element bit size of T.
+func checkPackSize[T uint32 | uint64](elemBit int) error {
+	var zero T
+	if tsize := int(unsafe.Sizeof(zero)) * 8; elemBit < 1 || elemBit > tsize {
+		return fmt.Errorf("element size %d bit is out of range [1, %d] of %T", elemBit, tsize, zero)
+	}
+	return nil
+}
+
+// fitBlock fits values in elemBit bits by overflow policy.
+// base is element index of block[0].
+func fitBlock(block []uint64, base, elemBit int, policy OverflowPolicy) error {
+	for i, v := range block {
+		fv, err := fitUint(v, elemBit, policy)
+		if err != nil {
+			return fmt.Errorf("element %d: %w", base+i, err)
+		}
+		block[i] = fv
+	}
+	return nil
+}
@@ -0,0 +1,2197 @@
+// Code generated by gen_pack.go; DO NOT EDIT.
+
+package bitio
+
+import "encoding/binary"
+
+// packKernels packs 8 values of width (index) bits to width bytes.
+var packKernels = [65]func(dst []byte, src *[8]uint64){
+	1:  pack8x1,
+	2:  pack8x2,
+	3:  pack8x3,
+	4:  pack8x4,
+	5:  pack8x5,
+	6:  pack8x6,
+	7:  pack8x7,
+	8:  pack8x8,
+	9:  pack8x9,
+	10: pack8x10,
+	11: pack8x11,
+	12: pack8x12,
+	13: pack8x13,
+	14: pack8x14,
+	15: pack8x15,
+	16: pack8x16,
+	17: pack8x17,
+	18: pack8x18,
+	19: pack8x19,
+	20: pack8x20,
+	21: pack8x21,
+	22: pack8x22,
+	23: pack8x23,
+	24: pack8x24,
+	25: pack8x25,
+	26: pack8x26,
+	27: pack8x27,
+	28: pack8x28,
+	29: pack8x29,
+	30: pack8x30,
+	31: pack8x31,
+	32: pack8x32,
+	33: pack8x33,
+	34: pack8x34,
+	35: pack8x35,
+	36: pack8x36,
+	37: pack8x37,
+	38: pack8x38,
+	39: pack8x39,
+	40: pack8x40,
+	41: pack8x41,
+	42: pack8x42,
+	43: pack8x43,
+	44: pack8x44,
+	45: pack8x45,
+	46: pack8x46,
+	47: pack8x47,
+	48: pack8x48,
+	49: pack8x49,
+	50: pack8x50,
+	51: pack8x51,
+	52: pack8x52,
+	53: pack8x53,
+	54: pack8x54,
+	55: pack8x55,
+	56: pack8x56,
+	57: pack8x57,
+	58: pack8x58,
+	59: pack8x59,
+	60: pack8x60,
+	61: pack8x61,
+	62: pack8x62,
+	63: pack8x63,
+	64: pack8x64,
+}
+
+// unpackKernels unpacks 8 values of width (index) bits from width bytes.
+var unpackKernels = [65]func(dst *[8]uint64, src []byte){
+	1:  unpack8x1,
+	2:  unpack8x2,
+	3:  unpack8x3,
+	4:  unpack8x4,
+	5:  unpack8x5,
+	6:  unpack8x6,
+	7:  unpack8x7,
+	8:  unpack8x8,
+	9:  unpack8x9,
+	10: unpack8x10,
+	11: unpack8x11,
+	12: unpack8x12,
+	13: unpack8x13,
+	14: unpack8x14,
+	15: unpack8x15,
+	16: unpack8x16,
+	17: unpack8x17,
+	18: unpack8x18,
+	19: unpack8x19,
+	20: unpack8x20,
+	21: unpack8x21,
+	22: unpack8x22,
+	23: unpack8x23,
+	24: unpack8x24,
+	25: unpack8x25,
+	26: unpack8x26,
+	27: unpack8x27,
+	28: unpack8x28,
+	29: unpack8x29,
+	30: unpack8x30,
+	31: unpack8x31,
+	32: unpack8x32,
+	33: unpack8x33,
+	34: unpack8x34,
+	35: unpack8x35,
+	36: unpack8x36,
+	37: unpack8x37,
+	38: unpack8x38,
+	39: unpack8x39,
+	40: unpack8x40,
+	41: unpack8x41,
+	42: unpack8x42,
+	43: unpack8x43,
+	44: unpack8x44,
+	45: unpack8x45,
+	46: unpack8x46,
+	47: unpack8x47,
+	48: unpack8x48,
+	49: unpack8x49,
+	50: unpack8x50,
+	51: unpack8x51,
+	52: unpack8x52,
+	53: unpack8x53,
+	54: unpack8x54,
+	55: unpack8x55,
+	56: unpack8x56,
+	57: unpack8x57,
+	58: unpack8x58,
+	59: unpack8x59,
+	60: unpack8x60,
+	61: unpack8x61,
+	62: unpack8x62,
+	63: unpack8x63,
+	64: unpack8x64,
+}
+
+func pack8x1(dst []byte, src *[8]uint64) {
+	_ = dst[0]
+	w0 := src[0]<<63 | src[1]<<62 | src[2]<<61 | src[3]<<60 | src[4]<<59 | src[5]<<58 | src[6]<<57 | src[7]<<56
+	dst[0] = byte(w0 >> 56)
+}
+
+func unpack8x1(dst *[8]uint64, src []byte) {
+	_ = src[0]
+	w0 := uint64(src[0]) << 56
+	dst[0] = (w0) >> 63
+	dst[1] = (w0 << 1) >> 63
+	dst[2] = (w0 << 2) >> 63
+	dst[3] = (w0 << 3) >> 63
+	dst[4] = (w0 << 4) >> 63
+	dst[5] = (w0 << 5) >> 63
+	dst[6] = (w0 << 6) >> 63
+	dst[7] = (w0 << 7) >> 63
+}
+
+func pack8x2(dst []byte, src *[8]uint64) {
+	_ = dst[1]
+	w0 := src[0]<<62 | src[1]<<60 | src[2]<<58 | src[3]<<56 | src[4]<<54 | src[5]<<52 | src[6]<<50 | src[7]<<48
+	dst[0] = byte(w0 >> 56)
+	dst[1] = byte(w0 >> 48)
+}
+
+func unpack8x2(dst *[8]uint64, src []byte) {
+	_ = src[1]
+	w0 := uint64(src[0])<<56 | uint64(src[1])<<48
+	dst[0] = (w0) >> 62
+	dst[1] = (w0 << 2) >> 62
+	dst[2] = (w0 << 4) >> 62
+	dst[3] = (w0 << 6) >> 62
+	dst[4] = (w0 << 8) >> 62
+	dst[5] = (w0 << 10) >> 62
+	dst[6] = (w0 << 12) >> 62
+	dst[7] = (w0 << 14) >> 62
+}
+
+func pack8x3(dst []byte, src *[8]uint64) {
+	_ = dst[2]
+	w0 := src[0]<<61 | src[1]<<58 | src[2]<<55 | src[3]<<52 | src[4]<<49 | src[5]<<46 | src[6]<<43 | src[7]<<40
+	dst[0] = byte(w0 >> 56)
+	dst[1] = byte(w0 >> 48)
+	dst[2] = byte(w0 >> 40)
+}
+
+func unpack8x3(dst *[8]uint64, src []byte) {
+	_ = src[2]
+	w0 := uint64(src[0])<<56 | uint64(src[1])<<48 | uint64(src[2])<<40
+	dst[0] = (w0) >> 61
+	dst[1] = (w0 << 3) >> 61
+	dst[2] = (w0 << 6) >> 61
+	dst[3] = (w0 << 9) >> 61
+	dst[4] = (w0 << 12) >> 61
+	dst[5] = (w0 << 15) >> 61
+	dst[6] = (w0 << 18) >> 61
+	dst[7] = (w0 << 21) >> 61
+}
+
+func pack8x4(dst []byte, src *[8]uint64) {
+	_ = dst[3]
+	w0 := src[0]<<60 | src[1]<<56 | src[2]<<52 | src[3]<<48 | src[4]<<44 | src[5]<<40 | src[6]<<36 | src[7]<<32
+	dst[0] = byte(w0 >> 56)
+	dst[1] = byte(w0 >> 48)
+	dst[2] = byte(w0 >> 40)
+	dst[3] = byte(w0 >> 32)
+}
+
+func unpack8x4(dst *[8]uint64, src []byte) {
+	_ = src[3]
+	w0 := uint64(src[0])<<56 | uint64(src[1])<<48 | uint64(src[2])<<40 | uint64(src[3])<<32
+	dst[0] = (w0) >> 60
+	dst[1] = (w0 << 4) >> 60
+	dst[2] = (w0 << 8) >> 60
+	dst[3] = (w0 << 12) >> 60
+	dst[4] = (w0 << 16) >> 60
+	dst[5] = (w0 << 20) >> 60
+	dst[6] = (w0 << 24) >> 60
+	dst[7] = (w0 << 28) >> 60
+}
+
+func pack8x5(dst []byte, src *[8]uint64) {
+	_ = dst[4]
+	w0 := src[0]<<59 | src[1]<<54 | src[2]<<49 | src[3]<<44 | src[4]<<39 | src[5]<<34 | src[6]<<29 | src[7]<<24
+	dst[0] = byte(w0 >> 56)
+	dst[1] = byte(w0 >> 48)
+	dst[2] = byte(w0 >> 40)
+	dst[3] = byte(w0 >> 32)
+	dst[4] = byte(w0 >> 24)
+}
+
+func unpack8x5(dst *[8]uint64, src []byte) {
+	_ = src[4]
+	w0 := uint64(src[0])<<56 | uint64(src[1])<<48 | uint64(src[2])<<40 | uint64(src[3])<<32 | uint64(src[4])<<24
+	dst[0] = (w0) >> 59
+	dst[1] = (w0 << 5) >> 59
+	dst[2] = (w0 << 10) >> 59
+	dst[3] = (w0 << 15) >> 59
+	dst[4] = (w0 << 20) >> 59
+	dst[5] = (w0 << 25) >> 59
+	dst[6] = (w0 << 30) >> 59
+	dst[7] = (w0 << 35) >> 59
+}
+
+func pack8x6(dst []byte, src *[8]uint64) {
+	_ = dst[5]
+	w0 := src[0]<<58 | src[1]<<52 | src[2]<<46 | src[3]<<40 | src[4]<<34 | src[5]<<28 | src[6]<<22 | src[7]<<16
+	dst[0] = byte(w0 >> 56)
+	dst[1] = byte(w0 >> 48)
+	dst[2] = byte(w0 >> 40)
+	dst[3] = byte(w0 >> 32)
+	dst[4] = byte(w0 >> 24)
+	dst[5] = byte(w0 >> 16)
+}
+
+func unpack8x6(dst *[8]uint64, src []byte) {
+	_ = src[5]
+	w0 := uint64(src[0])<<56 | uint64(src[1])<<48 | uint64(src[2])<<40 | uint64(src[3])<<32 | uint64(src[4])<<24 | uint64(src[5])<<16
+	dst[0] = (w0) >> 58
+	dst[1] = (w0 << 6) >> 58
+	dst[2] = (w0 << 12) >> 58
+	dst[3] = (w0 << 18) >> 58
+	dst[4] = (w0 << 24) >> 58
+	dst[5] = (w0 << 30) >> 58
+	dst[6] = (w0 << 36) >> 58
+	dst[7] = (w0 << 42) >> 58
+}
+
+func pack8x7(dst []byte, src *[8]uint64) {
+	_ = dst[6]
+	w0 := src[0]<<57 | src[1]<<50 | src[2]<<43 | src[3]<<36 | src[4]<<29 | src[5]<<22 | src[6]<<15 | src[7]<<8
+	dst[0] = byte(w0 >> 56)
+	dst[1] = byte(w0 >> 48)
+	dst[2] = byte(w0 >> 40)
+	dst[3] = byte(w0 >> 32)
+	dst[4] = byte(w0 >> 24)
+	dst[5] = byte(w0 >> 16)
+	dst[6] = byte(w0 >> 8)
+}
+
+func unpack8x7(dst *[8]uint64, src []byte) {
+	_ = src[6]
+	w0 := uint64(src[0])<<56 | uint64(src[1])<<48 | uint64(src[2])<<40 | uint64(src[3])<<32 | uint64(src[4])<<24 | uint64(src[5])<<16 | uint64(src[6])<<8
+	dst[0] = (w0) >> 57
+	dst[1] = (w0 << 7) >> 57
+	dst[2] = (w0 << 14) >> 57
+	dst[3] = (w0 << 21) >> 57
+	dst[4] = (w0 << 28) >> 57
+	dst[5] = (w0 << 35) >> 57
+	dst[6] = (w0 << 42) >> 57
+	dst[7] = (w0 << 49) >> 57
+}
+
+func pack8x8(dst []byte, src *[8]uint64) {
+	_ = dst[7]
+	w0 := src[0]<<56 | src[1]<<48 | src[2]<<40 | src[3]<<32 | src[4]<<24 | src[5]<<16 | src[6]<<8 | src[7]
+	binary.BigEndian.PutUint64(dst[0:], w0)
+}
+
+func unpack8x8(dst *[8]uint64, src []byte) {
+	_ = src[7]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	dst[0] = (w0) >> 56
+	dst[1] = (w0 << 8) >> 56
+	dst[2] = (w0 << 16) >> 56
+	dst[3] = (w0 << 24) >> 56
+	dst[4] = (w0 << 32) >> 56
+	dst[5] = (w0 << 40) >> 56
+	dst[6] = (w0 << 48) >> 56
+	dst[7] = (w0 << 56) >> 56
+}
+
+func pack8x9(dst []byte, src *[8]uint64) {
+	_ = dst[8]
+	w0 := src[0]<<55 | src[1]<<46 | src[2]<<37 | src[3]<<28 | src[4]<<19 | src[5]<<10 | src[6]<<1 | src[7]>>8
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[7] << 56
+	dst[8] = byte(w1 >> 56)
+}
+
+func unpack8x9(dst *[8]uint64, src []byte) {
+	_ = src[8]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := uint64(src[8]) << 56
+	dst[0] = (w0) >> 55
+	dst[1] = (w0 << 9) >> 55
+	dst[2] = (w0 << 18) >> 55
+	dst[3] = (w0 << 27) >> 55
+	dst[4] = (w0 << 36) >> 55
+	dst[5] = (w0 << 45) >> 55
+	dst[6] = (w0 << 54) >> 55
+	dst[7] = (w0<<63)>>55 | w1>>56
+}
+
+func pack8x10(dst []byte, src *[8]uint64) {
+	_ = dst[9]
+	w0 := src[0]<<54 | src[1]<<44 | src[2]<<34 | src[3]<<24 | src[4]<<14 | src[5]<<4 | src[6]>>6
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[6]<<58 | src[7]<<48
+	dst[8] = byte(w1 >> 56)
+	dst[9] = byte(w1 >> 48)
+}
+
+func unpack8x10(dst *[8]uint64, src []byte) {
+	_ = src[9]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := uint64(src[8])<<56 | uint64(src[9])<<48
+	dst[0] = (w0) >> 54
+	dst[1] = (w0 << 10) >> 54
+	dst[2] = (w0 << 20) >> 54
+	dst[3] = (w0 << 30) >> 54
+	dst[4] = (w0 << 40) >> 54
+	dst[5] = (w0 << 50) >> 54
+	dst[6] = (w0<<60)>>54 | w1>>58
+	dst[7] = (w1 << 6) >> 54
+}
+
+func pack8x11(dst []byte, src *[8]uint64) {
+	_ = dst[10]
+	w0 := src[0]<<53 | src[1]<<42 | src[2]<<31 | src[3]<<20 | src[4]<<9 | src[5]>>2
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[5]<<62 | src[6]<<51 | src[7]<<40
+	dst[8] = byte(w1 >> 56)
+	dst[9] = byte(w1 >> 48)
+	dst[10] = byte(w1 >> 40)
+}
+
+func unpack8x11(dst *[8]uint64, src []byte) {
+	_ = src[10]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := uint64(src[8])<<56 | uint64(src[9])<<48 | uint64(src[10])<<40
+	dst[0] = (w0) >> 53
+	dst[1] = (w0 << 11) >> 53
+	dst[2] = (w0 << 22) >> 53
+	dst[3] = (w0 << 33) >> 53
+	dst[4] = (w0 << 44) >> 53
+	dst[5] = (w0<<55)>>53 | w1>>62
+	dst[6] = (w1 << 2) >> 53
+	dst[7] = (w1 << 13) >> 53
+}
+
+func pack8x12(dst []byte, src *[8]uint64) {
+	_ = dst[11]
+	w0 := src[0]<<52 | src[1]<<40 | src[2]<<28 | src[3]<<16 | src[4]<<4 | src[5]>>8
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[5]<<56 | src[6]<<44 | src[7]<<32
+	dst[8] = byte(w1 >> 56)
+	dst[9] = byte(w1 >> 48)
+	dst[10] = byte(w1 >> 40)
+	dst[11] = byte(w1 >> 32)
+}
+
+func unpack8x12(dst *[8]uint64, src []byte) {
+	_ = src[11]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := uint64(src[8])<<56 | uint64(src[9])<<48 | uint64(src[10])<<40 | uint64(src[11])<<32
+	dst[0] = (w0) >> 52
+	dst[1] = (w0 << 12) >> 52
+	dst[2] = (w0 << 24) >> 52
+	dst[3] = (w0 << 36) >> 52
+	dst[4] = (w0 << 48) >> 52
+	dst[5] = (w0<<60)>>52 | w1>>56
+	dst[6] = (w1 << 8) >> 52
+	dst[7] = (w1 << 20) >> 52
+}
+
+func pack8x13(dst []byte, src *[8]uint64) {
+	_ = dst[12]
+	w0 := src[0]<<51 | src[1]<<38 | src[2]<<25 | src[3]<<12 | src[4]>>1
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[4]<<63 | src[5]<<50 | src[6]<<37 | src[7]<<24
+	dst[8] = byte(w1 >> 56)
+	dst[9] = byte(w1 >> 48)
+	dst[10] = byte(w1 >> 40)
+	dst[11] = byte(w1 >> 32)
+	dst[12] = byte(w1 >> 24)
+}
+
+func unpack8x13(dst *[8]uint64, src []byte) {
+	_ = src[12]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := uint64(src[8])<<56 | uint64(src[9])<<48 | uint64(src[10])<<40 | uint64(src[11])<<32 | uint64(src[12])<<24
+	dst[0] = (w0) >> 51
+	dst[1] = (w0 << 13) >> 51
+	dst[2] = (w0 << 26) >> 51
+	dst[3] = (w0 << 39) >> 51
+	dst[4] = (w0<<52)>>51 | w1>>63
+	dst[5] = (w1 << 1) >> 51
+	dst[6] = (w1 << 14) >> 51
+	dst[7] = (w1 << 27) >> 51
+}
+
+func pack8x14(dst []byte, src *[8]uint64) {
+	_ = dst[13]
+	w0 := src[0]<<50 | src[1]<<36 | src[2]<<22 | src[3]<<8 | src[4]>>6
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[4]<<58 | src[5]<<44 | src[6]<<30 | src[7]<<16
+	dst[8] = byte(w1 >> 56)
+	dst[9] = byte(w1 >> 48)
+	dst[10] = byte(w1 >> 40)
+	dst[11] = byte(w1 >> 32)
+	dst[12] = byte(w1 >> 24)
+	dst[13] = byte(w1 >> 16)
+}
+
+func unpack8x14(dst *[8]uint64, src []byte) {
+	_ = src[13]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := uint64(src[8])<<56 | uint64(src[9])<<48 | uint64(src[10])<<40 | uint64(src[11])<<32 | uint64(src[12])<<24 | uint64(src[13])<<16
+	dst[0] = (w0) >> 50
+	dst[1] = (w0 << 14) >> 50
+	dst[2] = (w0 << 28) >> 50
+	dst[3] = (w0 << 42) >> 50
+	dst[4] = (w0<<56)>>50 | w1>>58
+	dst[5] = (w1 << 6) >> 50
+	dst[6] = (w1 << 20) >> 50
+	dst[7] = (w1 << 34) >> 50
+}
+
+func pack8x15(dst []byte, src *[8]uint64) {
+	_ = dst[14]
+	w0 := src[0]<<49 | src[1]<<34 | src[2]<<19 | src[3]<<4 | src[4]>>11
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[4]<<53 | src[5]<<38 | src[6]<<23 | src[7]<<8
+	dst[8] = byte(w1 >> 56)
+	dst[9] = byte(w1 >> 48)
+	dst[10] = byte(w1 >> 40)
+	dst[11] = byte(w1 >> 32)
+	dst[12] = byte(w1 >> 24)
+	dst[13] = byte(w1 >> 16)
+	dst[14] = byte(w1 >> 8)
+}
+
+func unpack8x15(dst *[8]uint64, src []byte) {
+	_ = src[14]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := uint64(src[8])<<56 | uint64(src[9])<<48 | uint64(src[10])<<40 | uint64(src[11])<<32 | uint64(src[12])<<24 | uint64(src[13])<<16 | uint64(src[14])<<8
+	dst[0] = (w0) >> 49
+	dst[1] = (w0 << 15) >> 49
+	dst[2] = (w0 << 30) >> 49
+	dst[3] = (w0 << 45) >> 49
+	dst[4] = (w0<<60)>>49 | w1>>53
+	dst[5] = (w1 << 11) >> 49
+	dst[6] = (w1 << 26) >> 49
+	dst[7] = (w1 << 41) >> 49
+}
+
+func pack8x16(dst []byte, src *[8]uint64) {
+	_ = dst[15]
+	w0 := src[0]<<48 | src[1]<<32 | src[2]<<16 | src[3]
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[4]<<48 | src[5]<<32 | src[6]<<16 | src[7]
+	binary.BigEndian.PutUint64(dst[8:], w1)
+}
+
+func unpack8x16(dst *[8]uint64, src []byte) {
+	_ = src[15]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	dst[0] = (w0) >> 48
+	dst[1] = (w0 << 16) >> 48
+	dst[2] = (w0 << 32) >> 48
+	dst[3] = (w0 << 48) >> 48
+	dst[4] = (w1) >> 48
+	dst[5] = (w1 << 16) >> 48
+	dst[6] = (w1 << 32) >> 48
+	dst[7] = (w1 << 48) >> 48
+}
+
+func pack8x17(dst []byte, src *[8]uint64) {
+	_ = dst[16]
+	w0 := src[0]<<47 | src[1]<<30 | src[2]<<13 | src[3]>>4
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[3]<<60 | src[4]<<43 | src[5]<<26 | src[6]<<9 | src[7]>>8
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[7] << 56
+	dst[16] = byte(w2 >> 56)
+}
+
+func unpack8x17(dst *[8]uint64, src []byte) {
+	_ = src[16]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := uint64(src[16]) << 56
+	dst[0] = (w0) >> 47
+	dst[1] = (w0 << 17) >> 47
+	dst[2] = (w0 << 34) >> 47
+	dst[3] = (w0<<51)>>47 | w1>>60
+	dst[4] = (w1 << 4) >> 47
+	dst[5] = (w1 << 21) >> 47
+	dst[6] = (w1 << 38) >> 47
+	dst[7] = (w1<<55)>>47 | w2>>56
+}
+
+func pack8x18(dst []byte, src *[8]uint64) {
+	_ = dst[17]
+	w0 := src[0]<<46 | src[1]<<28 | src[2]<<10 | src[3]>>8
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[3]<<56 | src[4]<<38 | src[5]<<20 | src[6]<<2 | src[7]>>16
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[7] << 48
+	dst[16] = byte(w2 >> 56)
+	dst[17] = byte(w2 >> 48)
+}
+
+func unpack8x18(dst *[8]uint64, src []byte) {
+	_ = src[17]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := uint64(src[16])<<56 | uint64(src[17])<<48
+	dst[0] = (w0) >> 46
+	dst[1] = (w0 << 18) >> 46
+	dst[2] = (w0 << 36) >> 46
+	dst[3] = (w0<<54)>>46 | w1>>56
+	dst[4] = (w1 << 8) >> 46
+	dst[5] = (w1 << 26) >> 46
+	dst[6] = (w1 << 44) >> 46
+	dst[7] = (w1<<62)>>46 | w2>>48
+}
+
+func pack8x19(dst []byte, src *[8]uint64) {
+	_ = dst[18]
+	w0 := src[0]<<45 | src[1]<<26 | src[2]<<7 | src[3]>>12
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[3]<<52 | src[4]<<33 | src[5]<<14 | src[6]>>5
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[6]<<59 | src[7]<<40
+	dst[16] = byte(w2 >> 56)
+	dst[17] = byte(w2 >> 48)
+	dst[18] = byte(w2 >> 40)
+}
+
+func unpack8x19(dst *[8]uint64, src []byte) {
+	_ = src[18]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := uint64(src[16])<<56 | uint64(src[17])<<48 | uint64(src[18])<<40
+	dst[0] = (w0) >> 45
+	dst[1] = (w0 << 19) >> 45
+	dst[2] = (w0 << 38) >> 45
+	dst[3] = (w0<<57)>>45 | w1>>52
+	dst[4] = (w1 << 12) >> 45
+	dst[5] = (w1 << 31) >> 45
+	dst[6] = (w1<<50)>>45 | w2>>59
+	dst[7] = (w2 << 5) >> 45
+}
+
+func pack8x20(dst []byte, src *[8]uint64) {
+	_ = dst[19]
+	w0 := src[0]<<44 | src[1]<<24 | src[2]<<4 | src[3]>>16
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[3]<<48 | src[4]<<28 | src[5]<<8 | src[6]>>12
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[6]<<52 | src[7]<<32
+	dst[16] = byte(w2 >> 56)
+	dst[17] = byte(w2 >> 48)
+	dst[18] = byte(w2 >> 40)
+	dst[19] = byte(w2 >> 32)
+}
+
+func unpack8x20(dst *[8]uint64, src []byte) {
+	_ = src[19]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := uint64(src[16])<<56 | uint64(src[17])<<48 | uint64(src[18])<<40 | uint64(src[19])<<32
+	dst[0] = (w0) >> 44
+	dst[1] = (w0 << 20) >> 44
+	dst[2] = (w0 << 40) >> 44
+	dst[3] = (w0<<60)>>44 | w1>>48
+	dst[4] = (w1 << 16) >> 44
+	dst[5] = (w1 << 36) >> 44
+	dst[6] = (w1<<56)>>44 | w2>>52
+	dst[7] = (w2 << 12) >> 44
+}
+
+func pack8x21(dst []byte, src *[8]uint64) {
+	_ = dst[20]
+	w0 := src[0]<<43 | src[1]<<22 | src[2]<<1 | src[3]>>20
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[3]<<44 | src[4]<<23 | src[5]<<2 | src[6]>>19
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[6]<<45 | src[7]<<24
+	dst[16] = byte(w2 >> 56)
+	dst[17] = byte(w2 >> 48)
+	dst[18] = byte(w2 >> 40)
+	dst[19] = byte(w2 >> 32)
+	dst[20] = byte(w2 >> 24)
+}
+
+func unpack8x21(dst *[8]uint64, src []byte) {
+	_ = src[20]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := uint64(src[16])<<56 | uint64(src[17])<<48 | uint64(src[18])<<40 | uint64(src[19])<<32 | uint64(src[20])<<24
+	dst[0] = (w0) >> 43
+	dst[1] = (w0 << 21) >> 43
+	dst[2] = (w0 << 42) >> 43
+	dst[3] = (w0<<63)>>43 | w1>>44
+	dst[4] = (w1 << 20) >> 43
+	dst[5] = (w1 << 41) >> 43
+	dst[6] = (w1<<62)>>43 | w2>>45
+	dst[7] = (w2 << 19) >> 43
+}
+
+func pack8x22(dst []byte, src *[8]uint64) {
+	_ = dst[21]
+	w0 := src[0]<<42 | src[1]<<20 | src[2]>>2
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<62 | src[3]<<40 | src[4]<<18 | src[5]>>4
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[5]<<60 | src[6]<<38 | src[7]<<16
+	dst[16] = byte(w2 >> 56)
+	dst[17] = byte(w2 >> 48)
+	dst[18] = byte(w2 >> 40)
+	dst[19] = byte(w2 >> 32)
+	dst[20] = byte(w2 >> 24)
+	dst[21] = byte(w2 >> 16)
+}
+
+func unpack8x22(dst *[8]uint64, src []byte) {
+	_ = src[21]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := uint64(src[16])<<56 | uint64(src[17])<<48 | uint64(src[18])<<40 | uint64(src[19])<<32 | uint64(src[20])<<24 | uint64(src[21])<<16
+	dst[0] = (w0) >> 42
+	dst[1] = (w0 << 22) >> 42
+	dst[2] = (w0<<44)>>42 | w1>>62
+	dst[3] = (w1 << 2) >> 42
+	dst[4] = (w1 << 24) >> 42
+	dst[5] = (w1<<46)>>42 | w2>>60
+	dst[6] = (w2 << 4) >> 42
+	dst[7] = (w2 << 26) >> 42
+}
+
+func pack8x23(dst []byte, src *[8]uint64) {
+	_ = dst[22]
+	w0 := src[0]<<41 | src[1]<<18 | src[2]>>5
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<59 | src[3]<<36 | src[4]<<13 | src[5]>>10
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[5]<<54 | src[6]<<31 | src[7]<<8
+	dst[16] = byte(w2 >> 56)
+	dst[17] = byte(w2 >> 48)
+	dst[18] = byte(w2 >> 40)
+	dst[19] = byte(w2 >> 32)
+	dst[20] = byte(w2 >> 24)
+	dst[21] = byte(w2 >> 16)
+	dst[22] = byte(w2 >> 8)
+}
+
+func unpack8x23(dst *[8]uint64, src []byte) {
+	_ = src[22]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := uint64(src[16])<<56 | uint64(src[17])<<48 | uint64(src[18])<<40 | uint64(src[19])<<32 | uint64(src[20])<<24 | uint64(src[21])<<16 | uint64(src[22])<<8
+	dst[0] = (w0) >> 41
+	dst[1] = (w0 << 23) >> 41
+	dst[2] = (w0<<46)>>41 | w1>>59
+	dst[3] = (w1 << 5) >> 41
+	dst[4] = (w1 << 28) >> 41
+	dst[5] = (w1<<51)>>41 | w2>>54
+	dst[6] = (w2 << 10) >> 41
+	dst[7] = (w2 << 33) >> 41
+}
+
+func pack8x24(dst []byte, src *[8]uint64) {
+	_ = dst[23]
+	w0 := src[0]<<40 | src[1]<<16 | src[2]>>8
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<56 | src[3]<<32 | src[4]<<8 | src[5]>>16
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[5]<<48 | src[6]<<24 | src[7]
+	binary.BigEndian.PutUint64(dst[16:], w2)
+}
+
+func unpack8x24(dst *[8]uint64, src []byte) {
+	_ = src[23]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	dst[0] = (w0) >> 40
+	dst[1] = (w0 << 24) >> 40
+	dst[2] = (w0<<48)>>40 | w1>>56
+	dst[3] = (w1 << 8) >> 40
+	dst[4] = (w1 << 32) >> 40
+	dst[5] = (w1<<56)>>40 | w2>>48
+	dst[6] = (w2 << 16) >> 40
+	dst[7] = (w2 << 40) >> 40
+}
+
+func pack8x25(dst []byte, src *[8]uint64) {
+	_ = dst[24]
+	w0 := src[0]<<39 | src[1]<<14 | src[2]>>11
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<53 | src[3]<<28 | src[4]<<3 | src[5]>>22
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[5]<<42 | src[6]<<17 | src[7]>>8
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[7] << 56
+	dst[24] = byte(w3 >> 56)
+}
+
+func unpack8x25(dst *[8]uint64, src []byte) {
+	_ = src[24]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := uint64(src[24]) << 56
+	dst[0] = (w0) >> 39
+	dst[1] = (w0 << 25) >> 39
+	dst[2] = (w0<<50)>>39 | w1>>53
+	dst[3] = (w1 << 11) >> 39
+	dst[4] = (w1 << 36) >> 39
+	dst[5] = (w1<<61)>>39 | w2>>42
+	dst[6] = (w2 << 22) >> 39
+	dst[7] = (w2<<47)>>39 | w3>>56
+}
+
+func pack8x26(dst []byte, src *[8]uint64) {
+	_ = dst[25]
+	w0 := src[0]<<38 | src[1]<<12 | src[2]>>14
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<50 | src[3]<<24 | src[4]>>2
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[4]<<62 | src[5]<<36 | src[6]<<10 | src[7]>>16
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[7] << 48
+	dst[24] = byte(w3 >> 56)
+	dst[25] = byte(w3 >> 48)
+}
+
+func unpack8x26(dst *[8]uint64, src []byte) {
+	_ = src[25]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := uint64(src[24])<<56 | uint64(src[25])<<48
+	dst[0] = (w0) >> 38
+	dst[1] = (w0 << 26) >> 38
+	dst[2] = (w0<<52)>>38 | w1>>50
+	dst[3] = (w1 << 14) >> 38
+	dst[4] = (w1<<40)>>38 | w2>>62
+	dst[5] = (w2 << 2) >> 38
+	dst[6] = (w2 << 28) >> 38
+	dst[7] = (w2<<54)>>38 | w3>>48
+}
+
+func pack8x27(dst []byte, src *[8]uint64) {
+	_ = dst[26]
+	w0 := src[0]<<37 | src[1]<<10 | src[2]>>17
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<47 | src[3]<<20 | src[4]>>7
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[4]<<57 | src[5]<<30 | src[6]<<3 | src[7]>>24
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[7] << 40
+	dst[24] = byte(w3 >> 56)
+	dst[25] = byte(w3 >> 48)
+	dst[26] = byte(w3 >> 40)
+}
+
+func unpack8x27(dst *[8]uint64, src []byte) {
+	_ = src[26]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := uint64(src[24])<<56 | uint64(src[25])<<48 | uint64(src[26])<<40
+	dst[0] = (w0) >> 37
+	dst[1] = (w0 << 27) >> 37
+	dst[2] = (w0<<54)>>37 | w1>>47
+	dst[3] = (w1 << 17) >> 37
+	dst[4] = (w1<<44)>>37 | w2>>57
+	dst[5] = (w2 << 7) >> 37
+	dst[6] = (w2 << 34) >> 37
+	dst[7] = (w2<<61)>>37 | w3>>40
+}
+
+func pack8x28(dst []byte, src *[8]uint64) {
+	_ = dst[27]
+	w0 := src[0]<<36 | src[1]<<8 | src[2]>>20
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<44 | src[3]<<16 | src[4]>>12
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[4]<<52 | src[5]<<24 | src[6]>>4
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[6]<<60 | src[7]<<32
+	dst[24] = byte(w3 >> 56)
+	dst[25] = byte(w3 >> 48)
+	dst[26] = byte(w3 >> 40)
+	dst[27] = byte(w3 >> 32)
+}
+
+func unpack8x28(dst *[8]uint64, src []byte) {
+	_ = src[27]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := uint64(src[24])<<56 | uint64(src[25])<<48 | uint64(src[26])<<40 | uint64(src[27])<<32
+	dst[0] = (w0) >> 36
+	dst[1] = (w0 << 28) >> 36
+	dst[2] = (w0<<56)>>36 | w1>>44
+	dst[3] = (w1 << 20) >> 36
+	dst[4] = (w1<<48)>>36 | w2>>52
+	dst[5] = (w2 << 12) >> 36
+	dst[6] = (w2<<40)>>36 | w3>>60
+	dst[7] = (w3 << 4) >> 36
+}
+
+func pack8x29(dst []byte, src *[8]uint64) {
+	_ = dst[28]
+	w0 := src[0]<<35 | src[1]<<6 | src[2]>>23
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<41 | src[3]<<12 | src[4]>>17
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[4]<<47 | src[5]<<18 | src[6]>>11
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[6]<<53 | src[7]<<24
+	dst[24] = byte(w3 >> 56)
+	dst[25] = byte(w3 >> 48)
+	dst[26] = byte(w3 >> 40)
+	dst[27] = byte(w3 >> 32)
+	dst[28] = byte(w3 >> 24)
+}
+
+func unpack8x29(dst *[8]uint64, src []byte) {
+	_ = src[28]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := uint64(src[24])<<56 | uint64(src[25])<<48 | uint64(src[26])<<40 | uint64(src[27])<<32 | uint64(src[28])<<24
+	dst[0] = (w0) >> 35
+	dst[1] = (w0 << 29) >> 35
+	dst[2] = (w0<<58)>>35 | w1>>41
+	dst[3] = (w1 << 23) >> 35
+	dst[4] = (w1<<52)>>35 | w2>>47
+	dst[5] = (w2 << 17) >> 35
+	dst[6] = (w2<<46)>>35 | w3>>53
+	dst[7] = (w3 << 11) >> 35
+}
+
+func pack8x30(dst []byte, src *[8]uint64) {
+	_ = dst[29]
+	w0 := src[0]<<34 | src[1]<<4 | src[2]>>26
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<38 | src[3]<<8 | src[4]>>22
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[4]<<42 | src[5]<<12 | src[6]>>18
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[6]<<46 | src[7]<<16
+	dst[24] = byte(w3 >> 56)
+	dst[25] = byte(w3 >> 48)
+	dst[26] = byte(w3 >> 40)
+	dst[27] = byte(w3 >> 32)
+	dst[28] = byte(w3 >> 24)
+	dst[29] = byte(w3 >> 16)
+}
+
+func unpack8x30(dst *[8]uint64, src []byte) {
+	_ = src[29]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := uint64(src[24])<<56 | uint64(src[25])<<48 | uint64(src[26])<<40 | uint64(src[27])<<32 | uint64(src[28])<<24 | uint64(src[29])<<16
+	dst[0] = (w0) >> 34
+	dst[1] = (w0 << 30) >> 34
+	dst[2] = (w0<<60)>>34 | w1>>38
+	dst[3] = (w1 << 26) >> 34
+	dst[4] = (w1<<56)>>34 | w2>>42
+	dst[5] = (w2 << 22) >> 34
+	dst[6] = (w2<<52)>>34 | w3>>46
+	dst[7] = (w3 << 18) >> 34
+}
+
+func pack8x31(dst []byte, src *[8]uint64) {
+	_ = dst[30]
+	w0 := src[0]<<33 | src[1]<<2 | src[2]>>29
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<35 | src[3]<<4 | src[4]>>27
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[4]<<37 | src[5]<<6 | src[6]>>25
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[6]<<39 | src[7]<<8
+	dst[24] = byte(w3 >> 56)
+	dst[25] = byte(w3 >> 48)
+	dst[26] = byte(w3 >> 40)
+	dst[27] = byte(w3 >> 32)
+	dst[28] = byte(w3 >> 24)
+	dst[29] = byte(w3 >> 16)
+	dst[30] = byte(w3 >> 8)
+}
+
+func unpack8x31(dst *[8]uint64, src []byte) {
+	_ = src[30]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := uint64(src[24])<<56 | uint64(src[25])<<48 | uint64(src[26])<<40 | uint64(src[27])<<32 | uint64(src[28])<<24 | uint64(src[29])<<16 | uint64(src[30])<<8
+	dst[0] = (w0) >> 33
+	dst[1] = (w0 << 31) >> 33
+	dst[2] = (w0<<62)>>33 | w1>>35
+	dst[3] = (w1 << 29) >> 33
+	dst[4] = (w1<<60)>>33 | w2>>37
+	dst[5] = (w2 << 27) >> 33
+	dst[6] = (w2<<58)>>33 | w3>>39
+	dst[7] = (w3 << 25) >> 33
+}
+
+func pack8x32(dst []byte, src *[8]uint64) {
+	_ = dst[31]
+	w0 := src[0]<<32 | src[1]
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[2]<<32 | src[3]
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[4]<<32 | src[5]
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[6]<<32 | src[7]
+	binary.BigEndian.PutUint64(dst[24:], w3)
+}
+
+func unpack8x32(dst *[8]uint64, src []byte) {
+	_ = src[31]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	dst[0] = (w0) >> 32
+	dst[1] = (w0 << 32) >> 32
+	dst[2] = (w1) >> 32
+	dst[3] = (w1 << 32) >> 32
+	dst[4] = (w2) >> 32
+	dst[5] = (w2 << 32) >> 32
+	dst[6] = (w3) >> 32
+	dst[7] = (w3 << 32) >> 32
+}
+
+func pack8x33(dst []byte, src *[8]uint64) {
+	_ = dst[32]
+	w0 := src[0]<<31 | src[1]>>2
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<62 | src[2]<<29 | src[3]>>4
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<60 | src[4]<<27 | src[5]>>6
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[5]<<58 | src[6]<<25 | src[7]>>8
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[7] << 56
+	dst[32] = byte(w4 >> 56)
+}
+
+func unpack8x33(dst *[8]uint64, src []byte) {
+	_ = src[32]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := uint64(src[32]) << 56
+	dst[0] = (w0) >> 31
+	dst[1] = (w0<<33)>>31 | w1>>62
+	dst[2] = (w1 << 2) >> 31
+	dst[3] = (w1<<35)>>31 | w2>>60
+	dst[4] = (w2 << 4) >> 31
+	dst[5] = (w2<<37)>>31 | w3>>58
+	dst[6] = (w3 << 6) >> 31
+	dst[7] = (w3<<39)>>31 | w4>>56
+}
+
+func pack8x34(dst []byte, src *[8]uint64) {
+	_ = dst[33]
+	w0 := src[0]<<30 | src[1]>>4
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<60 | src[2]<<26 | src[3]>>8
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<56 | src[4]<<22 | src[5]>>12
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[5]<<52 | src[6]<<18 | src[7]>>16
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[7] << 48
+	dst[32] = byte(w4 >> 56)
+	dst[33] = byte(w4 >> 48)
+}
+
+func unpack8x34(dst *[8]uint64, src []byte) {
+	_ = src[33]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := uint64(src[32])<<56 | uint64(src[33])<<48
+	dst[0] = (w0) >> 30
+	dst[1] = (w0<<34)>>30 | w1>>60
+	dst[2] = (w1 << 4) >> 30
+	dst[3] = (w1<<38)>>30 | w2>>56
+	dst[4] = (w2 << 8) >> 30
+	dst[5] = (w2<<42)>>30 | w3>>52
+	dst[6] = (w3 << 12) >> 30
+	dst[7] = (w3<<46)>>30 | w4>>48
+}
+
+func pack8x35(dst []byte, src *[8]uint64) {
+	_ = dst[34]
+	w0 := src[0]<<29 | src[1]>>6
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<58 | src[2]<<23 | src[3]>>12
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<52 | src[4]<<17 | src[5]>>18
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[5]<<46 | src[6]<<11 | src[7]>>24
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[7] << 40
+	dst[32] = byte(w4 >> 56)
+	dst[33] = byte(w4 >> 48)
+	dst[34] = byte(w4 >> 40)
+}
+
+func unpack8x35(dst *[8]uint64, src []byte) {
+	_ = src[34]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := uint64(src[32])<<56 | uint64(src[33])<<48 | uint64(src[34])<<40
+	dst[0] = (w0) >> 29
+	dst[1] = (w0<<35)>>29 | w1>>58
+	dst[2] = (w1 << 6) >> 29
+	dst[3] = (w1<<41)>>29 | w2>>52
+	dst[4] = (w2 << 12) >> 29
+	dst[5] = (w2<<47)>>29 | w3>>46
+	dst[6] = (w3 << 18) >> 29
+	dst[7] = (w3<<53)>>29 | w4>>40
+}
+
+func pack8x36(dst []byte, src *[8]uint64) {
+	_ = dst[35]
+	w0 := src[0]<<28 | src[1]>>8
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<56 | src[2]<<20 | src[3]>>16
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<48 | src[4]<<12 | src[5]>>24
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[5]<<40 | src[6]<<4 | src[7]>>32
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[7] << 32
+	dst[32] = byte(w4 >> 56)
+	dst[33] = byte(w4 >> 48)
+	dst[34] = byte(w4 >> 40)
+	dst[35] = byte(w4 >> 32)
+}
+
+func unpack8x36(dst *[8]uint64, src []byte) {
+	_ = src[35]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := uint64(src[32])<<56 | uint64(src[33])<<48 | uint64(src[34])<<40 | uint64(src[35])<<32
+	dst[0] = (w0) >> 28
+	dst[1] = (w0<<36)>>28 | w1>>56
+	dst[2] = (w1 << 8) >> 28
+	dst[3] = (w1<<44)>>28 | w2>>48
+	dst[4] = (w2 << 16) >> 28
+	dst[5] = (w2<<52)>>28 | w3>>40
+	dst[6] = (w3 << 24) >> 28
+	dst[7] = (w3<<60)>>28 | w4>>32
+}
+
+func pack8x37(dst []byte, src *[8]uint64) {
+	_ = dst[36]
+	w0 := src[0]<<27 | src[1]>>10
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<54 | src[2]<<17 | src[3]>>20
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<44 | src[4]<<7 | src[5]>>30
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[5]<<34 | src[6]>>3
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[6]<<61 | src[7]<<24
+	dst[32] = byte(w4 >> 56)
+	dst[33] = byte(w4 >> 48)
+	dst[34] = byte(w4 >> 40)
+	dst[35] = byte(w4 >> 32)
+	dst[36] = byte(w4 >> 24)
+}
+
+func unpack8x37(dst *[8]uint64, src []byte) {
+	_ = src[36]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := uint64(src[32])<<56 | uint64(src[33])<<48 | uint64(src[34])<<40 | uint64(src[35])<<32 | uint64(src[36])<<24
+	dst[0] = (w0) >> 27
+	dst[1] = (w0<<37)>>27 | w1>>54
+	dst[2] = (w1 << 10) >> 27
+	dst[3] = (w1<<47)>>27 | w2>>44
+	dst[4] = (w2 << 20) >> 27
+	dst[5] = (w2<<57)>>27 | w3>>34
+	dst[6] = (w3<<30)>>27 | w4>>61
+	dst[7] = (w4 << 3) >> 27
+}
+
+func pack8x38(dst []byte, src *[8]uint64) {
+	_ = dst[37]
+	w0 := src[0]<<26 | src[1]>>12
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<52 | src[2]<<14 | src[3]>>24
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<40 | src[4]<<2 | src[5]>>36
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[5]<<28 | src[6]>>10
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[6]<<54 | src[7]<<16
+	dst[32] = byte(w4 >> 56)
+	dst[33] = byte(w4 >> 48)
+	dst[34] = byte(w4 >> 40)
+	dst[35] = byte(w4 >> 32)
+	dst[36] = byte(w4 >> 24)
+	dst[37] = byte(w4 >> 16)
+}
+
+func unpack8x38(dst *[8]uint64, src []byte) {
+	_ = src[37]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := uint64(src[32])<<56 | uint64(src[33])<<48 | uint64(src[34])<<40 | uint64(src[35])<<32 | uint64(src[36])<<24 | uint64(src[37])<<16
+	dst[0] = (w0) >> 26
+	dst[1] = (w0<<38)>>26 | w1>>52
+	dst[2] = (w1 << 12) >> 26
+	dst[3] = (w1<<50)>>26 | w2>>40
+	dst[4] = (w2 << 24) >> 26
+	dst[5] = (w2<<62)>>26 | w3>>28
+	dst[6] = (w3<<36)>>26 | w4>>54
+	dst[7] = (w4 << 10) >> 26
+}
+
+func pack8x39(dst []byte, src *[8]uint64) {
+	_ = dst[38]
+	w0 := src[0]<<25 | src[1]>>14
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<50 | src[2]<<11 | src[3]>>28
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<36 | src[4]>>3
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<61 | src[5]<<22 | src[6]>>17
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[6]<<47 | src[7]<<8
+	dst[32] = byte(w4 >> 56)
+	dst[33] = byte(w4 >> 48)
+	dst[34] = byte(w4 >> 40)
+	dst[35] = byte(w4 >> 32)
+	dst[36] = byte(w4 >> 24)
+	dst[37] = byte(w4 >> 16)
+	dst[38] = byte(w4 >> 8)
+}
+
+func unpack8x39(dst *[8]uint64, src []byte) {
+	_ = src[38]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := uint64(src[32])<<56 | uint64(src[33])<<48 | uint64(src[34])<<40 | uint64(src[35])<<32 | uint64(src[36])<<24 | uint64(src[37])<<16 | uint64(src[38])<<8
+	dst[0] = (w0) >> 25
+	dst[1] = (w0<<39)>>25 | w1>>50
+	dst[2] = (w1 << 14) >> 25
+	dst[3] = (w1<<53)>>25 | w2>>36
+	dst[4] = (w2<<28)>>25 | w3>>61
+	dst[5] = (w3 << 3) >> 25
+	dst[6] = (w3<<42)>>25 | w4>>47
+	dst[7] = (w4 << 17) >> 25
+}
+
+func pack8x40(dst []byte, src *[8]uint64) {
+	_ = dst[39]
+	w0 := src[0]<<24 | src[1]>>16
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<48 | src[2]<<8 | src[3]>>32
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<32 | src[4]>>8
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<56 | src[5]<<16 | src[6]>>24
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[6]<<40 | src[7]
+	binary.BigEndian.PutUint64(dst[32:], w4)
+}
+
+func unpack8x40(dst *[8]uint64, src []byte) {
+	_ = src[39]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	dst[0] = (w0) >> 24
+	dst[1] = (w0<<40)>>24 | w1>>48
+	dst[2] = (w1 << 16) >> 24
+	dst[3] = (w1<<56)>>24 | w2>>32
+	dst[4] = (w2<<32)>>24 | w3>>56
+	dst[5] = (w3 << 8) >> 24
+	dst[6] = (w3<<48)>>24 | w4>>40
+	dst[7] = (w4 << 24) >> 24
+}
+
+func pack8x41(dst []byte, src *[8]uint64) {
+	_ = dst[40]
+	w0 := src[0]<<23 | src[1]>>18
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<46 | src[2]<<5 | src[3]>>36
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<28 | src[4]>>13
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<51 | src[5]<<10 | src[6]>>31
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[6]<<33 | src[7]>>8
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[7] << 56
+	dst[40] = byte(w5 >> 56)
+}
+
+func unpack8x41(dst *[8]uint64, src []byte) {
+	_ = src[40]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := uint64(src[40]) << 56
+	dst[0] = (w0) >> 23
+	dst[1] = (w0<<41)>>23 | w1>>46
+	dst[2] = (w1 << 18) >> 23
+	dst[3] = (w1<<59)>>23 | w2>>28
+	dst[4] = (w2<<36)>>23 | w3>>51
+	dst[5] = (w3 << 13) >> 23
+	dst[6] = (w3<<54)>>23 | w4>>33
+	dst[7] = (w4<<31)>>23 | w5>>56
+}
+
+func pack8x42(dst []byte, src *[8]uint64) {
+	_ = dst[41]
+	w0 := src[0]<<22 | src[1]>>20
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<44 | src[2]<<2 | src[3]>>40
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[3]<<24 | src[4]>>18
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<46 | src[5]<<4 | src[6]>>38
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[6]<<26 | src[7]>>16
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[7] << 48
+	dst[40] = byte(w5 >> 56)
+	dst[41] = byte(w5 >> 48)
+}
+
+func unpack8x42(dst *[8]uint64, src []byte) {
+	_ = src[41]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := uint64(src[40])<<56 | uint64(src[41])<<48
+	dst[0] = (w0) >> 22
+	dst[1] = (w0<<42)>>22 | w1>>44
+	dst[2] = (w1 << 20) >> 22
+	dst[3] = (w1<<62)>>22 | w2>>24
+	dst[4] = (w2<<40)>>22 | w3>>46
+	dst[5] = (w3 << 18) >> 22
+	dst[6] = (w3<<60)>>22 | w4>>26
+	dst[7] = (w4<<38)>>22 | w5>>48
+}
+
+func pack8x43(dst []byte, src *[8]uint64) {
+	_ = dst[42]
+	w0 := src[0]<<21 | src[1]>>22
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<42 | src[2]>>1
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<63 | src[3]<<20 | src[4]>>23
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<41 | src[5]>>2
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<62 | src[6]<<19 | src[7]>>24
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[7] << 40
+	dst[40] = byte(w5 >> 56)
+	dst[41] = byte(w5 >> 48)
+	dst[42] = byte(w5 >> 40)
+}
+
+func unpack8x43(dst *[8]uint64, src []byte) {
+	_ = src[42]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := uint64(src[40])<<56 | uint64(src[41])<<48 | uint64(src[42])<<40
+	dst[0] = (w0) >> 21
+	dst[1] = (w0<<43)>>21 | w1>>42
+	dst[2] = (w1<<22)>>21 | w2>>63
+	dst[3] = (w2 << 1) >> 21
+	dst[4] = (w2<<44)>>21 | w3>>41
+	dst[5] = (w3<<23)>>21 | w4>>62
+	dst[6] = (w4 << 2) >> 21
+	dst[7] = (w4<<45)>>21 | w5>>40
+}
+
+func pack8x44(dst []byte, src *[8]uint64) {
+	_ = dst[43]
+	w0 := src[0]<<20 | src[1]>>24
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<40 | src[2]>>4
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<60 | src[3]<<16 | src[4]>>28
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<36 | src[5]>>8
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<56 | src[6]<<12 | src[7]>>32
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[7] << 32
+	dst[40] = byte(w5 >> 56)
+	dst[41] = byte(w5 >> 48)
+	dst[42] = byte(w5 >> 40)
+	dst[43] = byte(w5 >> 32)
+}
+
+func unpack8x44(dst *[8]uint64, src []byte) {
+	_ = src[43]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := uint64(src[40])<<56 | uint64(src[41])<<48 | uint64(src[42])<<40 | uint64(src[43])<<32
+	dst[0] = (w0) >> 20
+	dst[1] = (w0<<44)>>20 | w1>>40
+	dst[2] = (w1<<24)>>20 | w2>>60
+	dst[3] = (w2 << 4) >> 20
+	dst[4] = (w2<<48)>>20 | w3>>36
+	dst[5] = (w3<<28)>>20 | w4>>56
+	dst[6] = (w4 << 8) >> 20
+	dst[7] = (w4<<52)>>20 | w5>>32
+}
+
+func pack8x45(dst []byte, src *[8]uint64) {
+	_ = dst[44]
+	w0 := src[0]<<19 | src[1]>>26
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<38 | src[2]>>7
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<57 | src[3]<<12 | src[4]>>33
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<31 | src[5]>>14
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<50 | src[6]<<5 | src[7]>>40
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[7] << 24
+	dst[40] = byte(w5 >> 56)
+	dst[41] = byte(w5 >> 48)
+	dst[42] = byte(w5 >> 40)
+	dst[43] = byte(w5 >> 32)
+	dst[44] = byte(w5 >> 24)
+}
+
+func unpack8x45(dst *[8]uint64, src []byte) {
+	_ = src[44]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := uint64(src[40])<<56 | uint64(src[41])<<48 | uint64(src[42])<<40 | uint64(src[43])<<32 | uint64(src[44])<<24
+	dst[0] = (w0) >> 19
+	dst[1] = (w0<<45)>>19 | w1>>38
+	dst[2] = (w1<<26)>>19 | w2>>57
+	dst[3] = (w2 << 7) >> 19
+	dst[4] = (w2<<52)>>19 | w3>>31
+	dst[5] = (w3<<33)>>19 | w4>>50
+	dst[6] = (w4 << 14) >> 19
+	dst[7] = (w4<<59)>>19 | w5>>24
+}
+
+func pack8x46(dst []byte, src *[8]uint64) {
+	_ = dst[45]
+	w0 := src[0]<<18 | src[1]>>28
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<36 | src[2]>>10
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<54 | src[3]<<8 | src[4]>>38
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<26 | src[5]>>20
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<44 | src[6]>>2
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[6]<<62 | src[7]<<16
+	dst[40] = byte(w5 >> 56)
+	dst[41] = byte(w5 >> 48)
+	dst[42] = byte(w5 >> 40)
+	dst[43] = byte(w5 >> 32)
+	dst[44] = byte(w5 >> 24)
+	dst[45] = byte(w5 >> 16)
+}
+
+func unpack8x46(dst *[8]uint64, src []byte) {
+	_ = src[45]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := uint64(src[40])<<56 | uint64(src[41])<<48 | uint64(src[42])<<40 | uint64(src[43])<<32 | uint64(src[44])<<24 | uint64(src[45])<<16
+	dst[0] = (w0) >> 18
+	dst[1] = (w0<<46)>>18 | w1>>36
+	dst[2] = (w1<<28)>>18 | w2>>54
+	dst[3] = (w2 << 10) >> 18
+	dst[4] = (w2<<56)>>18 | w3>>26
+	dst[5] = (w3<<38)>>18 | w4>>44
+	dst[6] = (w4<<20)>>18 | w5>>62
+	dst[7] = (w5 << 2) >> 18
+}
+
+func pack8x47(dst []byte, src *[8]uint64) {
+	_ = dst[46]
+	w0 := src[0]<<17 | src[1]>>30
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<34 | src[2]>>13
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<51 | src[3]<<4 | src[4]>>43
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<21 | src[5]>>26
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<38 | src[6]>>9
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[6]<<55 | src[7]<<8
+	dst[40] = byte(w5 >> 56)
+	dst[41] = byte(w5 >> 48)
+	dst[42] = byte(w5 >> 40)
+	dst[43] = byte(w5 >> 32)
+	dst[44] = byte(w5 >> 24)
+	dst[45] = byte(w5 >> 16)
+	dst[46] = byte(w5 >> 8)
+}
+
+func unpack8x47(dst *[8]uint64, src []byte) {
+	_ = src[46]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := uint64(src[40])<<56 | uint64(src[41])<<48 | uint64(src[42])<<40 | uint64(src[43])<<32 | uint64(src[44])<<24 | uint64(src[45])<<16 | uint64(src[46])<<8
+	dst[0] = (w0) >> 17
+	dst[1] = (w0<<47)>>17 | w1>>34
+	dst[2] = (w1<<30)>>17 | w2>>51
+	dst[3] = (w2 << 13) >> 17
+	dst[4] = (w2<<60)>>17 | w3>>21
+	dst[5] = (w3<<43)>>17 | w4>>38
+	dst[6] = (w4<<26)>>17 | w5>>55
+	dst[7] = (w5 << 9) >> 17
+}
+
+func pack8x48(dst []byte, src *[8]uint64) {
+	_ = dst[47]
+	w0 := src[0]<<16 | src[1]>>32
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<32 | src[2]>>16
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<48 | src[3]
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[4]<<16 | src[5]>>32
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<32 | src[6]>>16
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[6]<<48 | src[7]
+	binary.BigEndian.PutUint64(dst[40:], w5)
+}
+
+func unpack8x48(dst *[8]uint64, src []byte) {
+	_ = src[47]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	dst[0] = (w0) >> 16
+	dst[1] = (w0<<48)>>16 | w1>>32
+	dst[2] = (w1<<32)>>16 | w2>>48
+	dst[3] = (w2 << 16) >> 16
+	dst[4] = (w3) >> 16
+	dst[5] = (w3<<48)>>16 | w4>>32
+	dst[6] = (w4<<32)>>16 | w5>>48
+	dst[7] = (w5 << 16) >> 16
+}
+
+func pack8x49(dst []byte, src *[8]uint64) {
+	_ = dst[48]
+	w0 := src[0]<<15 | src[1]>>34
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<30 | src[2]>>19
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<45 | src[3]>>4
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<60 | src[4]<<11 | src[5]>>38
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<26 | src[6]>>23
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[6]<<41 | src[7]>>8
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[7] << 56
+	dst[48] = byte(w6 >> 56)
+}
+
+func unpack8x49(dst *[8]uint64, src []byte) {
+	_ = src[48]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := uint64(src[48]) << 56
+	dst[0] = (w0) >> 15
+	dst[1] = (w0<<49)>>15 | w1>>30
+	dst[2] = (w1<<34)>>15 | w2>>45
+	dst[3] = (w2<<19)>>15 | w3>>60
+	dst[4] = (w3 << 4) >> 15
+	dst[5] = (w3<<53)>>15 | w4>>26
+	dst[6] = (w4<<38)>>15 | w5>>41
+	dst[7] = (w5<<23)>>15 | w6>>56
+}
+
+func pack8x50(dst []byte, src *[8]uint64) {
+	_ = dst[49]
+	w0 := src[0]<<14 | src[1]>>36
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<28 | src[2]>>22
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<42 | src[3]>>8
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<56 | src[4]<<6 | src[5]>>44
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<20 | src[6]>>30
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[6]<<34 | src[7]>>16
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[7] << 48
+	dst[48] = byte(w6 >> 56)
+	dst[49] = byte(w6 >> 48)
+}
+
+func unpack8x50(dst *[8]uint64, src []byte) {
+	_ = src[49]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := uint64(src[48])<<56 | uint64(src[49])<<48
+	dst[0] = (w0) >> 14
+	dst[1] = (w0<<50)>>14 | w1>>28
+	dst[2] = (w1<<36)>>14 | w2>>42
+	dst[3] = (w2<<22)>>14 | w3>>56
+	dst[4] = (w3 << 8) >> 14
+	dst[5] = (w3<<58)>>14 | w4>>20
+	dst[6] = (w4<<44)>>14 | w5>>34
+	dst[7] = (w5<<30)>>14 | w6>>48
+}
+
+func pack8x51(dst []byte, src *[8]uint64) {
+	_ = dst[50]
+	w0 := src[0]<<13 | src[1]>>38
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<26 | src[2]>>25
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<39 | src[3]>>12
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<52 | src[4]<<1 | src[5]>>50
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[5]<<14 | src[6]>>37
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[6]<<27 | src[7]>>24
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[7] << 40
+	dst[48] = byte(w6 >> 56)
+	dst[49] = byte(w6 >> 48)
+	dst[50] = byte(w6 >> 40)
+}
+
+func unpack8x51(dst *[8]uint64, src []byte) {
+	_ = src[50]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := uint64(src[48])<<56 | uint64(src[49])<<48 | uint64(src[50])<<40
+	dst[0] = (w0) >> 13
+	dst[1] = (w0<<51)>>13 | w1>>26
+	dst[2] = (w1<<38)>>13 | w2>>39
+	dst[3] = (w2<<25)>>13 | w3>>52
+	dst[4] = (w3 << 12) >> 13
+	dst[5] = (w3<<63)>>13 | w4>>14
+	dst[6] = (w4<<50)>>13 | w5>>27
+	dst[7] = (w5<<37)>>13 | w6>>40
+}
+
+func pack8x52(dst []byte, src *[8]uint64) {
+	_ = dst[51]
+	w0 := src[0]<<12 | src[1]>>40
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<24 | src[2]>>28
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<36 | src[3]>>16
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<48 | src[4]>>4
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<60 | src[5]<<8 | src[6]>>44
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[6]<<20 | src[7]>>32
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[7] << 32
+	dst[48] = byte(w6 >> 56)
+	dst[49] = byte(w6 >> 48)
+	dst[50] = byte(w6 >> 40)
+	dst[51] = byte(w6 >> 32)
+}
+
+func unpack8x52(dst *[8]uint64, src []byte) {
+	_ = src[51]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := uint64(src[48])<<56 | uint64(src[49])<<48 | uint64(src[50])<<40 | uint64(src[51])<<32
+	dst[0] = (w0) >> 12
+	dst[1] = (w0<<52)>>12 | w1>>24
+	dst[2] = (w1<<40)>>12 | w2>>36
+	dst[3] = (w2<<28)>>12 | w3>>48
+	dst[4] = (w3<<16)>>12 | w4>>60
+	dst[5] = (w4 << 4) >> 12
+	dst[6] = (w4<<56)>>12 | w5>>20
+	dst[7] = (w5<<44)>>12 | w6>>32
+}
+
+func pack8x53(dst []byte, src *[8]uint64) {
+	_ = dst[52]
+	w0 := src[0]<<11 | src[1]>>42
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<22 | src[2]>>31
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<33 | src[3]>>20
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<44 | src[4]>>9
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<55 | src[5]<<2 | src[6]>>51
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[6]<<13 | src[7]>>40
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[7] << 24
+	dst[48] = byte(w6 >> 56)
+	dst[49] = byte(w6 >> 48)
+	dst[50] = byte(w6 >> 40)
+	dst[51] = byte(w6 >> 32)
+	dst[52] = byte(w6 >> 24)
+}
+
+func unpack8x53(dst *[8]uint64, src []byte) {
+	_ = src[52]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := uint64(src[48])<<56 | uint64(src[49])<<48 | uint64(src[50])<<40 | uint64(src[51])<<32 | uint64(src[52])<<24
+	dst[0] = (w0) >> 11
+	dst[1] = (w0<<53)>>11 | w1>>22
+	dst[2] = (w1<<42)>>11 | w2>>33
+	dst[3] = (w2<<31)>>11 | w3>>44
+	dst[4] = (w3<<20)>>11 | w4>>55
+	dst[5] = (w4 << 9) >> 11
+	dst[6] = (w4<<62)>>11 | w5>>13
+	dst[7] = (w5<<51)>>11 | w6>>24
+}
+
+func pack8x54(dst []byte, src *[8]uint64) {
+	_ = dst[53]
+	w0 := src[0]<<10 | src[1]>>44
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<20 | src[2]>>34
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<30 | src[3]>>24
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<40 | src[4]>>14
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<50 | src[5]>>4
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<60 | src[6]<<6 | src[7]>>48
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[7] << 16
+	dst[48] = byte(w6 >> 56)
+	dst[49] = byte(w6 >> 48)
+	dst[50] = byte(w6 >> 40)
+	dst[51] = byte(w6 >> 32)
+	dst[52] = byte(w6 >> 24)
+	dst[53] = byte(w6 >> 16)
+}
+
+func unpack8x54(dst *[8]uint64, src []byte) {
+	_ = src[53]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := uint64(src[48])<<56 | uint64(src[49])<<48 | uint64(src[50])<<40 | uint64(src[51])<<32 | uint64(src[52])<<24 | uint64(src[53])<<16
+	dst[0] = (w0) >> 10
+	dst[1] = (w0<<54)>>10 | w1>>20
+	dst[2] = (w1<<44)>>10 | w2>>30
+	dst[3] = (w2<<34)>>10 | w3>>40
+	dst[4] = (w3<<24)>>10 | w4>>50
+	dst[5] = (w4<<14)>>10 | w5>>60
+	dst[6] = (w5 << 4) >> 10
+	dst[7] = (w5<<58)>>10 | w6>>16
+}
+
+func pack8x55(dst []byte, src *[8]uint64) {
+	_ = dst[54]
+	w0 := src[0]<<9 | src[1]>>46
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<18 | src[2]>>37
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<27 | src[3]>>28
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<36 | src[4]>>19
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<45 | src[5]>>10
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<54 | src[6]>>1
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<63 | src[7]<<8
+	dst[48] = byte(w6 >> 56)
+	dst[49] = byte(w6 >> 48)
+	dst[50] = byte(w6 >> 40)
+	dst[51] = byte(w6 >> 32)
+	dst[52] = byte(w6 >> 24)
+	dst[53] = byte(w6 >> 16)
+	dst[54] = byte(w6 >> 8)
+}
+
+func unpack8x55(dst *[8]uint64, src []byte) {
+	_ = src[54]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := uint64(src[48])<<56 | uint64(src[49])<<48 | uint64(src[50])<<40 | uint64(src[51])<<32 | uint64(src[52])<<24 | uint64(src[53])<<16 | uint64(src[54])<<8
+	dst[0] = (w0) >> 9
+	dst[1] = (w0<<55)>>9 | w1>>18
+	dst[2] = (w1<<46)>>9 | w2>>27
+	dst[3] = (w2<<37)>>9 | w3>>36
+	dst[4] = (w3<<28)>>9 | w4>>45
+	dst[5] = (w4<<19)>>9 | w5>>54
+	dst[6] = (w5<<10)>>9 | w6>>63
+	dst[7] = (w6 << 1) >> 9
+}
+
+func pack8x56(dst []byte, src *[8]uint64) {
+	_ = dst[55]
+	w0 := src[0]<<8 | src[1]>>48
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<16 | src[2]>>40
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<24 | src[3]>>32
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<32 | src[4]>>24
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<40 | src[5]>>16
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<48 | src[6]>>8
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<56 | src[7]
+	binary.BigEndian.PutUint64(dst[48:], w6)
+}
+
+func unpack8x56(dst *[8]uint64, src []byte) {
+	_ = src[55]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	dst[0] = (w0) >> 8
+	dst[1] = (w0<<56)>>8 | w1>>16
+	dst[2] = (w1<<48)>>8 | w2>>24
+	dst[3] = (w2<<40)>>8 | w3>>32
+	dst[4] = (w3<<32)>>8 | w4>>40
+	dst[5] = (w4<<24)>>8 | w5>>48
+	dst[6] = (w5<<16)>>8 | w6>>56
+	dst[7] = (w6 << 8) >> 8
+}
+
+func pack8x57(dst []byte, src *[8]uint64) {
+	_ = dst[56]
+	w0 := src[0]<<7 | src[1]>>50
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<14 | src[2]>>43
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<21 | src[3]>>36
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<28 | src[4]>>29
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<35 | src[5]>>22
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<42 | src[6]>>15
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<49 | src[7]>>8
+	binary.BigEndian.PutUint64(dst[48:], w6)
+	w7 := src[7] << 56
+	dst[56] = byte(w7 >> 56)
+}
+
+func unpack8x57(dst *[8]uint64, src []byte) {
+	_ = src[56]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	w7 := uint64(src[56]) << 56
+	dst[0] = (w0) >> 7
+	dst[1] = (w0<<57)>>7 | w1>>14
+	dst[2] = (w1<<50)>>7 | w2>>21
+	dst[3] = (w2<<43)>>7 | w3>>28
+	dst[4] = (w3<<36)>>7 | w4>>35
+	dst[5] = (w4<<29)>>7 | w5>>42
+	dst[6] = (w5<<22)>>7 | w6>>49
+	dst[7] = (w6<<15)>>7 | w7>>56
+}
+
+func pack8x58(dst []byte, src *[8]uint64) {
+	_ = dst[57]
+	w0 := src[0]<<6 | src[1]>>52
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<12 | src[2]>>46
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<18 | src[3]>>40
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<24 | src[4]>>34
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<30 | src[5]>>28
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<36 | src[6]>>22
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<42 | src[7]>>16
+	binary.BigEndian.PutUint64(dst[48:], w6)
+	w7 := src[7] << 48
+	dst[56] = byte(w7 >> 56)
+	dst[57] = byte(w7 >> 48)
+}
+
+func unpack8x58(dst *[8]uint64, src []byte) {
+	_ = src[57]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	w7 := uint64(src[56])<<56 | uint64(src[57])<<48
+	dst[0] = (w0) >> 6
+	dst[1] = (w0<<58)>>6 | w1>>12
+	dst[2] = (w1<<52)>>6 | w2>>18
+	dst[3] = (w2<<46)>>6 | w3>>24
+	dst[4] = (w3<<40)>>6 | w4>>30
+	dst[5] = (w4<<34)>>6 | w5>>36
+	dst[6] = (w5<<28)>>6 | w6>>42
+	dst[7] = (w6<<22)>>6 | w7>>48
+}
+
+func pack8x59(dst []byte, src *[8]uint64) {
+	_ = dst[58]
+	w0 := src[0]<<5 | src[1]>>54
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<10 | src[2]>>49
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<15 | src[3]>>44
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<20 | src[4]>>39
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<25 | src[5]>>34
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<30 | src[6]>>29
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<35 | src[7]>>24
+	binary.BigEndian.PutUint64(dst[48:], w6)
+	w7 := src[7] << 40
+	dst[56] = byte(w7 >> 56)
+	dst[57] = byte(w7 >> 48)
+	dst[58] = byte(w7 >> 40)
+}
+
+func unpack8x59(dst *[8]uint64, src []byte) {
+	_ = src[58]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	w7 := uint64(src[56])<<56 | uint64(src[57])<<48 | uint64(src[58])<<40
+	dst[0] = (w0) >> 5
+	dst[1] = (w0<<59)>>5 | w1>>10
+	dst[2] = (w1<<54)>>5 | w2>>15
+	dst[3] = (w2<<49)>>5 | w3>>20
+	dst[4] = (w3<<44)>>5 | w4>>25
+	dst[5] = (w4<<39)>>5 | w5>>30
+	dst[6] = (w5<<34)>>5 | w6>>35
+	dst[7] = (w6<<29)>>5 | w7>>40
+}
+
+func pack8x60(dst []byte, src *[8]uint64) {
+	_ = dst[59]
+	w0 := src[0]<<4 | src[1]>>56
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<8 | src[2]>>52
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<12 | src[3]>>48
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<16 | src[4]>>44
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<20 | src[5]>>40
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<24 | src[6]>>36
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<28 | src[7]>>32
+	binary.BigEndian.PutUint64(dst[48:], w6)
+	w7 := src[7] << 32
+	dst[56] = byte(w7 >> 56)
+	dst[57] = byte(w7 >> 48)
+	dst[58] = byte(w7 >> 40)
+	dst[59] = byte(w7 >> 32)
+}
+
+func unpack8x60(dst *[8]uint64, src []byte) {
+	_ = src[59]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	w7 := uint64(src[56])<<56 | uint64(src[57])<<48 | uint64(src[58])<<40 | uint64(src[59])<<32
+	dst[0] = (w0) >> 4
+	dst[1] = (w0<<60)>>4 | w1>>8
+	dst[2] = (w1<<56)>>4 | w2>>12
+	dst[3] = (w2<<52)>>4 | w3>>16
+	dst[4] = (w3<<48)>>4 | w4>>20
+	dst[5] = (w4<<44)>>4 | w5>>24
+	dst[6] = (w5<<40)>>4 | w6>>28
+	dst[7] = (w6<<36)>>4 | w7>>32
+}
+
+func pack8x61(dst []byte, src *[8]uint64) {
+	_ = dst[60]
+	w0 := src[0]<<3 | src[1]>>58
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<6 | src[2]>>55
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<9 | src[3]>>52
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<12 | src[4]>>49
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<15 | src[5]>>46
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<18 | src[6]>>43
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<21 | src[7]>>40
+	binary.BigEndian.PutUint64(dst[48:], w6)
+	w7 := src[7] << 24
+	dst[56] = byte(w7 >> 56)
+	dst[57] = byte(w7 >> 48)
+	dst[58] = byte(w7 >> 40)
+	dst[59] = byte(w7 >> 32)
+	dst[60] = byte(w7 >> 24)
+}
+
+func unpack8x61(dst *[8]uint64, src []byte) {
+	_ = src[60]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	w7 := uint64(src[56])<<56 | uint64(src[57])<<48 | uint64(src[58])<<40 | uint64(src[59])<<32 | uint64(src[60])<<24
+	dst[0] = (w0) >> 3
+	dst[1] = (w0<<61)>>3 | w1>>6
+	dst[2] = (w1<<58)>>3 | w2>>9
+	dst[3] = (w2<<55)>>3 | w3>>12
+	dst[4] = (w3<<52)>>3 | w4>>15
+	dst[5] = (w4<<49)>>3 | w5>>18
+	dst[6] = (w5<<46)>>3 | w6>>21
+	dst[7] = (w6<<43)>>3 | w7>>24
+}
+
+func pack8x62(dst []byte, src *[8]uint64) {
+	_ = dst[61]
+	w0 := src[0]<<2 | src[1]>>60
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<4 | src[2]>>58
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<6 | src[3]>>56
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<8 | src[4]>>54
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<10 | src[5]>>52
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<12 | src[6]>>50
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<14 | src[7]>>48
+	binary.BigEndian.PutUint64(dst[48:], w6)
+	w7 := src[7] << 16
+	dst[56] = byte(w7 >> 56)
+	dst[57] = byte(w7 >> 48)
+	dst[58] = byte(w7 >> 40)
+	dst[59] = byte(w7 >> 32)
+	dst[60] = byte(w7 >> 24)
+	dst[61] = byte(w7 >> 16)
+}
+
+func unpack8x62(dst *[8]uint64, src []byte) {
+	_ = src[61]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	w7 := uint64(src[56])<<56 | uint64(src[57])<<48 | uint64(src[58])<<40 | uint64(src[59])<<32 | uint64(src[60])<<24 | uint64(src[61])<<16
+	dst[0] = (w0) >> 2
+	dst[1] = (w0<<62)>>2 | w1>>4
+	dst[2] = (w1<<60)>>2 | w2>>6
+	dst[3] = (w2<<58)>>2 | w3>>8
+	dst[4] = (w3<<56)>>2 | w4>>10
+	dst[5] = (w4<<54)>>2 | w5>>12
+	dst[6] = (w5<<52)>>2 | w6>>14
+	dst[7] = (w6<<50)>>2 | w7>>16
+}
+
+func pack8x63(dst []byte, src *[8]uint64) {
+	_ = dst[62]
+	w0 := src[0]<<1 | src[1]>>62
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]<<2 | src[2]>>61
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]<<3 | src[3]>>60
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]<<4 | src[4]>>59
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]<<5 | src[5]>>58
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]<<6 | src[6]>>57
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]<<7 | src[7]>>56
+	binary.BigEndian.PutUint64(dst[48:], w6)
+	w7 := src[7] << 8
+	dst[56] = byte(w7 >> 56)
+	dst[57] = byte(w7 >> 48)
+	dst[58] = byte(w7 >> 40)
+	dst[59] = byte(w7 >> 32)
+	dst[60] = byte(w7 >> 24)
+	dst[61] = byte(w7 >> 16)
+	dst[62] = byte(w7 >> 8)
+}
+
+func unpack8x63(dst *[8]uint64, src []byte) {
+	_ = src[62]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	w7 := uint64(src[56])<<56 | uint64(src[57])<<48 | uint64(src[58])<<40 | uint64(src[59])<<32 | uint64(src[60])<<24 | uint64(src[61])<<16 | uint64(src[62])<<8
+	dst[0] = (w0) >> 1
+	dst[1] = (w0<<63)>>1 | w1>>2
+	dst[2] = (w1<<62)>>1 | w2>>3
+	dst[3] = (w2<<61)>>1 | w3>>4
+	dst[4] = (w3<<60)>>1 | w4>>5
+	dst[5] = (w4<<59)>>1 | w5>>6
+	dst[6] = (w5<<58)>>1 | w6>>7
+	dst[7] = (w6<<57)>>1 | w7>>8
+}
+
+func pack8x64(dst []byte, src *[8]uint64) {
+	_ = dst[63]
+	w0 := src[0]
+	binary.BigEndian.PutUint64(dst[0:], w0)
+	w1 := src[1]
+	binary.BigEndian.PutUint64(dst[8:], w1)
+	w2 := src[2]
+	binary.BigEndian.PutUint64(dst[16:], w2)
+	w3 := src[3]
+	binary.BigEndian.PutUint64(dst[24:], w3)
+	w4 := src[4]
+	binary.BigEndian.PutUint64(dst[32:], w4)
+	w5 := src[5]
+	binary.BigEndian.PutUint64(dst[40:], w5)
+	w6 := src[6]
+	binary.BigEndian.PutUint64(dst[48:], w6)
+	w7 := src[7]
+	binary.BigEndian.PutUint64(dst[56:], w7)
+}
+
+func unpack8x64(dst *[8]uint64, src []byte) {
+	_ = src[63]
+	w0 := binary.BigEndian.Uint64(src[0:])
+	w1 := binary.BigEndian.Uint64(src[8:])
+	w2 := binary.BigEndian.Uint64(src[16:])
+	w3 := binary.BigEndian.Uint64(src[24:])
+	w4 := binary.BigEndian.Uint64(src[32:])
+	w5 := binary.BigEndian.Uint64(src[40:])
+	w6 := binary.BigEndian.Uint64(src[48:])
+	w7 := binary.BigEndian.Uint64(src[56:])
+	dst[0] = w0
+	dst[1] = w1
+	dst[2] = w2
+	dst[3] = w3
+	dst[4] = w4
+	dst[5] = w5
+	dst[6] = w6
+	dst[7] = w7
+}
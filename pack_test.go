@@ -0,0 +1,227 @@
+package bitio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+// randomUints returns n random values of elemBit bits.
+func randomUints[T uint32 | uint64](n, elemBit int, seed int64) []T {
+	rnd := rand.New(rand.NewSource(seed))
+	values := make([]T, n)
+	for i := range values {
+		values[i] = T(rnd.Uint64() >> uint(64-elemBit))
+	}
+	return values
+}
+
+// writeSliceBytes returns output of WriteSlice with BigEndian after prefix bits.
+func writeSliceBytes[T uint32 | uint64](elemBit int, src []T, prefix int) []byte {
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	w.WriteBit(0xff, prefix)
+	bitio.WriteSlice(w, elemBit, bitio.BigEndian, src)
+	w.Flush()
+	return b.Bytes()
+}
+
+func testPackUints[T uint32 | uint64](t *testing.T, maxBit int) {
+	for elemBit := 1; elemBit <= maxBit; elemBit++ {
+		for _, n := range []int{0, 1, 7, 8, 9, 600, 1025} {
+			src := randomUints[T](n, elemBit, int64(elemBit*n))
+			exp := writeSliceBytes(elemBit, src, 0)
+
+			buf := make([]byte, len(exp)+3)
+			size, err := bitio.PackUints(buf, elemBit, src)
+			if err != nil {
+				t.Fatalf("PackUints(%T %d bit x %d) error: %v", src, elemBit, n, err)
+			}
+			if bytes.Equal(buf[:size], exp) == false {
+				t.Fatalf("PackUints(%T %d bit x %d) is different from WriteSlice", src, elemBit, n)
+			}
+
+			dst := make([]T, n)
+			if err := bitio.UnpackUints(dst, elemBit, buf[:size]); err != nil {
+				t.Fatalf("UnpackUints(%T %d bit x %d) error: %v", dst, elemBit, n, err)
+			}
+			if reflect.DeepEqual(dst, src) == false {
+				t.Fatalf("UnpackUints(%T %d bit x %d) read different values", dst, elemBit, n)
+			}
+		}
+	}
+}
+
+func TestPackUints(t *testing.T) {
+	testPackUints[uint32](t, 32)
+	testPackUints[uint64](t, 64)
+}
+
+func testPackedUints[T uint32 | uint64](t *testing.T, maxBit int) {
+	for elemBit := 1; elemBit <= maxBit; elemBit++ {
+		for _, n := range []int{0, 5, 512, 1300} {
+			src := randomUints[T](n, elemBit, int64(elemBit+n))
+			exp := writeSliceBytes(elemBit, src, 3)
+
+			// unaligned stream
+			b := new(bytes.Buffer)
+			w := bitio.NewBitWriteBuffer(b)
+			w.WriteBit(0xff, 3)
+			if err := bitio.WritePackedUints(w, elemBit, src); err != nil {
+				t.Fatalf("WritePackedUints(%T %d bit x %d) error: %v", src, elemBit, n, err)
+			}
+			w.Flush()
+			if bytes.Equal(b.Bytes(), exp) == false {
+				t.Fatalf("WritePackedUints(%T %d bit x %d) is different from WriteSlice", src, elemBit, n)
+			}
+
+			for _, r := range []bitio.BitReader{
+				bitio.NewBitReadBuffer(bytes.NewReader(exp)),
+				bitReaderOnly{bitio.NewBitReadBuffer(bytes.NewReader(exp))},
+			} {
+				var prefix byte
+				r.ReadBit(&prefix, 3)
+				dst := make([]T, n)
+				if err := bitio.ReadPackedUints(r, elemBit, dst); err != nil {
+					t.Fatalf("ReadPackedUints(%T %d bit x %d) error: %v", dst, elemBit, n, err)
+				}
+				if reflect.DeepEqual(dst, src) == false {
+					t.Fatalf("ReadPackedUints(%T %d bit x %d) read different values", dst, elemBit, n)
+				}
+			}
+		}
+	}
+}
+
+func TestPackedUints(t *testing.T) {
+	testPackedUints[uint32](t, 32)
+	testPackedUints[uint64](t, 64)
+}
+
+func TestPackedUints_LSB(t *testing.T) {
+	src := randomUints[uint64](100, 13, 1)
+
+	b := new(bytes.Buffer)
+	w := bitio.NewLSBWriteBuffer(b)
+	bitio.WriteSlice(w, 13, bitio.BigEndian, src)
+	w.Flush()
+	exp := b.Bytes()
+
+	b = new(bytes.Buffer)
+	w = bitio.NewLSBWriteBuffer(b)
+	if err := bitio.WritePackedUints(w, 13, src); err != nil {
+		t.Fatalf("WritePackedUints error: %v", err)
+	}
+	w.Flush()
+	if bytes.Equal(b.Bytes(), exp) == false {
+		t.Fatalf("WritePackedUints to LSBWriteBuffer is different from WriteSlice")
+	}
+
+	dst := make([]uint64, len(src))
+	if err := bitio.ReadPackedUints(bitio.NewLSBReadBuffer(bytes.NewReader(exp)), 13, dst); err != nil || reflect.DeepEqual(dst, src) == false {
+		t.Fatalf("ReadPackedUints from LSBReadBuffer read different values (%v)", err)
+	}
+}
+
+func TestPackUints_Overflow(t *testing.T) {
+	src := []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	buf := make([]byte, 8)
+
+	if _, err := bitio.PackUints(buf, 3, src); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("PackUints error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	if _, err := bitio.PackUints(buf, 4, src[:8]); err != nil {
+		t.Fatalf("PackUints error: %v", err)
+	}
+
+	tests := []struct {
+		policy bitio.OverflowPolicy
+		values []uint32
+	}{
+		{bitio.OverflowTruncate, []uint32{1, 2, 3, 4, 5, 6, 7, 0, 1}},
+		{bitio.OverflowSaturate, []uint32{1, 2, 3, 4, 5, 6, 7, 7, 7}},
+	}
+	for _, tt := range tests {
+		n, err := bitio.PackUints(buf, 3, src, tt.policy)
+		if err != nil {
+			t.Fatalf("PackUints policy %d error: %v", tt.policy, err)
+		}
+		dst := make([]uint32, len(src))
+		bitio.UnpackUints(dst, 3, buf[:n])
+		if reflect.DeepEqual(dst, tt.values) == false {
+			t.Fatalf("PackUints policy %d packs %v, want %v", tt.policy, dst, tt.values)
+		}
+	}
+
+	w := bitio.NewBitWriteBuffer(io.Discard)
+	if err := bitio.WritePackedUints(w, 3, src); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WritePackedUints error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+}
+
+func TestPackUints_Error(t *testing.T) {
+	buf := make([]byte, 16)
+	if _, err := bitio.PackUints(buf, 0, []uint64{0}); err == nil {
+		t.Fatalf("PackUints 0 bit wants error")
+	}
+	if _, err := bitio.PackUints(buf, 33, []uint32{0}); err == nil {
+		t.Fatalf("PackUints uint32 33 bit wants error")
+	}
+	if _, err := bitio.PackUints(buf, 65, []uint64{0}); err == nil {
+		t.Fatalf("PackUints uint64 65 bit wants error")
+	}
+	if _, err := bitio.PackUints(buf[:1], 3, []uint64{0, 0, 0}); err == nil {
+		t.Fatalf("PackUints short buffer wants error")
+	}
+	if err := bitio.UnpackUints(make([]uint64, 3), 3, buf[:1]); errors.Is(err, bitio.ErrUnexpectedEOF) == false {
+		t.Fatalf("UnpackUints error %v, want %v", err, bitio.ErrUnexpectedEOF)
+	}
+
+	r := bitio.NewBitReadBuffer(bytes.NewReader([]byte{0x12, 0x34}))
+	if err := bitio.ReadPackedUints(r, 7, make([]uint32, 3)); errors.Is(err, bitio.ErrUnexpectedEOF) == false {
+		t.Fatalf("ReadPackedUints error %v, want %v", err, bitio.ErrUnexpectedEOF)
+	}
+}
+
+func BenchmarkPackUints(b *testing.B) {
+	src := randomUints[uint32](1<<16, 13, 1)
+	buf := make([]byte, len(src)*13/8+1)
+	b.SetBytes(int64(len(src) * 4))
+	for i := 0; i < b.N; i++ {
+		bitio.PackUints(buf, 13, src)
+	}
+}
+
+func BenchmarkUnpackUints(b *testing.B) {
+	src := randomUints[uint32](1<<16, 13, 1)
+	buf := make([]byte, len(src)*13/8+1)
+	bitio.PackUints(buf, 13, src)
+	dst := make([]uint32, len(src))
+	b.SetBytes(int64(len(src) * 4))
+	for i := 0; i < b.N; i++ {
+		bitio.UnpackUints(dst, 13, buf)
+	}
+}
+
+func BenchmarkWriteSlice(b *testing.B) {
+	src := randomUints[uint32](1<<16, 13, 1)
+	b.SetBytes(int64(len(src) * 4))
+	for i := 0; i < b.N; i++ {
+		w := bitio.NewBitWriteBuffer(io.Discard)
+		bitio.WriteSlice(w, 13, bitio.BigEndian, src)
+	}
+}
+
+func BenchmarkWritePackedUints(b *testing.B) {
+	src := randomUints[uint32](1<<16, 13, 1)
+	b.SetBytes(int64(len(src) * 4))
+	for i := 0; i < b.N; i++ {
+		w := bitio.NewBitWriteBuffer(io.Discard)
+		bitio.WritePackedUints(w, 13, src)
+	}
+}
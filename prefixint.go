@@ -0,0 +1,217 @@
+package bitio
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strconv"
+)
+
+// MaxQUICVarint is max value of QUIC variable-length integer. (2^62-1)
+const MaxQUICVarint = 1<<62 - 1
+
+// ReadQUICVarint reads QUIC variable-length integer. (RFC 9000)
+// 2 bit prefix is log2 of byte size (1, 2, 4, 8), and the rest is big-endian value.
+func ReadQUICVarint(r BitReader) (uint64, error) {
+	_, v, err := readQUICVarint(r, make([]byte, 8))
+	return v, err
+}
+
+// WriteQUICVarint writes v as QUIC variable-length integer of the shortest size.
+func WriteQUICVarint(w BitWriter, v uint64) error {
+	_, err := writeQUICVarint(w, v, make([]byte, 8))
+	return err
+}
+
+// ReadHPACKInt reads HPACK integer of prefix bits (1..8). (RFC 7541)
+// Value less than 2^prefix-1 is in prefix bits, and others are 2^prefix-1 and
+// the rest in 7 bit groups from the least significant (continuation bit 0x80).
+func ReadHPACKInt(r BitReader, prefix int) (uint64, error) {
+	_, v, err := readHPACKInt(r, prefix, make([]byte, 8))
+	return v, err
+}
+
+// WriteHPACKInt writes v as HPACK integer of prefix bits (1..8).
+func WriteHPACKInt(w BitWriter, prefix int, v uint64) error {
+	_, err := writeHPACKInt(w, prefix, v, make([]byte, 8))
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// readQUICVarint reads QUIC variable-length integer and returns read size and value.
+func readQUICVarint(r BitReader, buf []byte) (int, uint64, error) {
+	size, err := readUint64(r, 2, BigEndian, buf)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	n := 8<<size - 2
+	v, err := readUint64(r, n, BigEndian, buf)
+	if err != nil {
+		return 0, 0, unexpectedEOF(err)
+	}
+	return n + 2, v, nil
+}
+
+// writeQUICVarint writes v as QUIC variable-length integer and returns write size.
+func writeQUICVarint(w BitWriter, v uint64, buf []byte) (int, error) {
+	if v > MaxQUICVarint {
+		return 0, fmt.Errorf("QUIC varint value %d exceeds %d: %w", v, uint64(MaxQUICVarint), ErrValueOverflow)
+	}
+
+	size := quicVarintSize(v)
+	if err := writeUint64(w, 2, BigEndian, uint64(size), buf); err != nil {
+		return 0, err
+	}
+	n := 8<<size - 2
+	if err := writeUint64(w, n, BigEndian, v, buf); err != nil {
+		return 0, err
+	}
+	return n + 2, nil
+}
+
+// quicVarintSize returns 2 bit prefix (log2 of byte size) of v.
+func quicVarintSize(v uint64) int {
+	switch {
+	case v < 1<<6:
+		return 0
+	case v < 1<<14:
+		return 1
+	case v < 1<<30:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// readHPACKInt reads HPACK integer and returns read size and value.
+func readHPACKInt(r BitReader, prefix int, buf []byte) (int, uint64, error) {
+	if err := checkHPACKPrefix(prefix); err != nil {
+		return 0, 0, err
+	}
+
+	v, err := readUint64(r, prefix, BigEndian, buf)
+	if err != nil {
+		return 0, 0, err
+	}
+	max := uint64(1)<<uint(prefix) - 1
+	if v < max {
+		return prefix, v, nil
+	}
+
+	n, rest, err := readULEB128(r)
+	if err != nil {
+		return 0, 0, unexpectedEOF(err)
+	}
+	sum, carry := bits.Add64(max, rest, 0)
+	if carry != 0 {
+		return 0, 0, fmt.Errorf("HPACK integer exceeds 64 bit: %w", ErrValueOverflow)
+	}
+	return prefix + n, sum, nil
+}
+
+// writeHPACKInt writes v as HPACK integer and returns write size.
+func writeHPACKInt(w BitWriter, prefix int, v uint64, buf []byte) (int, error) {
+	if err := checkHPACKPrefix(prefix); err != nil {
+		return 0, err
+	}
+
+	max := uint64(1)<<uint(prefix) - 1
+	if v < max {
+		if err := writeUint64(w, prefix, BigEndian, v, buf); err != nil {
+			return 0, err
+		}
+		return prefix, nil
+	}
+
+	if err := writeUint64(w, prefix, BigEndian, max, buf); err != nil {
+		return 0, err
+	}
+	n, err := writeULEB128(w, v-max)
+	if err != nil {
+		return 0, err
+	}
+	return prefix + n, nil
+}
+
+// hpackIntLen returns bit size of v as HPACK integer.
+func hpackIntLen(prefix int, v uint64) int {
+	max := uint64(1)<<uint(prefix) - 1
+	if v < max {
+		return prefix
+	}
+	return prefix + uleb128Len(v-max)
+}
+
+// checkHPACKPrefix checks prefix bits of HPACK integer.
+func checkHPACKPrefix(prefix int) error {
+	if prefix < 1 || prefix > 8 {
+		return fmt.Errorf("bitio: HPACK prefix %d is out of range [1, 8]", prefix)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// prefixIntEncoding is valueEncoding of `encoding:"quicvar"` and `encoding:"hpack" prefix:"N"`.
+type prefixIntEncoding struct {
+	prefix int // prefix bits of HPACK (0: QUIC)
+	signed bool
+}
+
+func newPrefixIntEncoding(ctx *encodingContext) (valueEncoding, error) {
+	if ctx.param != "" {
+		return nil, fmt.Errorf("unexpected parameter %q", ctx.param)
+	}
+	enc := &prefixIntEncoding{signed: ctx.signed}
+
+	v, ok := ctx.tag.Lookup("prefix")
+	switch {
+	case ctx.name == "quicvar" && ok:
+		return nil, fmt.Errorf("unexpected prefix %q", v)
+	case ctx.name == "hpack":
+		prefix, err := strconv.Atoi(v)
+		if err != nil || checkHPACKPrefix(prefix) != nil {
+			return nil, fmt.Errorf("prefix %q is out of range [1, 8]", v)
+		}
+		enc.prefix = prefix
+	}
+	return enc, nil
+}
+
+func (enc *prefixIntEncoding) read(r BitReader, rv reflect.Value, buf []byte) (uint64, int, error) {
+	var n int
+	var v uint64
+	var err error
+	if enc.prefix == 0 {
+		n, v, err = readQUICVarint(r, buf)
+	} else {
+		n, v, err = readHPACKInt(r, enc.prefix, buf)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	if enc.signed && int64(v) < 0 {
+		return 0, 0, fmt.Errorf("HPACK integer %d exceeds int64: %w", v, ErrValueOverflow)
+	}
+	return v, n, nil
+}
+
+func (enc *prefixIntEncoding) write(w BitWriter, rv reflect.Value, v uint64, buf []byte) (int, error) {
+	v, err := unsignedValue(v, enc.signed)
+	if err != nil {
+		return 0, err
+	}
+	if enc.prefix == 0 {
+		return writeQUICVarint(w, v, buf)
+	}
+	return writeHPACKInt(w, enc.prefix, v, buf)
+}
+
+func (enc *prefixIntEncoding) bitLen(rv reflect.Value, v uint64) int {
+	if enc.prefix == 0 {
+		return 8 << quicVarintSize(v)
+	}
+	return hpackIntLen(enc.prefix, v)
+}
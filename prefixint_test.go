@@ -0,0 +1,234 @@
+package bitio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+func TestQUICVarint(t *testing.T) {
+	// RFC 9000 Appendix A.1
+	tests := []struct {
+		v   uint64
+		raw string
+	}{
+		{151288809941952652, "c2197c5eff14e88c"},
+		{494878333, "9d7f3e7d"},
+		{15293, "7bbd"},
+		{37, "25"},
+		{0, "00"},
+		{63, "3f"},
+		{64, "4040"},
+		{bitio.MaxQUICVarint, "ffffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		if got := writeHex(t, func(w bitio.BitWriter) error { return bitio.WriteQUICVarint(w, tt.v) }); got != tt.raw {
+			t.Fatalf("WriteQUICVarint(%d) writes %s, want %s", tt.v, got, tt.raw)
+		}
+		if v, err := bitio.ReadQUICVarint(hexReader(tt.raw)); err != nil || v != tt.v {
+			t.Fatalf("ReadQUICVarint(%s) returns %d, %v, want %d", tt.raw, v, err, tt.v)
+		}
+	}
+
+	// non-shortest encoding is accepted
+	if v, err := bitio.ReadQUICVarint(hexReader("4025")); err != nil || v != 37 {
+		t.Fatalf("ReadQUICVarint(4025) returns %d, %v, want 37", v, err)
+	}
+
+	if err := bitio.WriteQUICVarint(bitio.NewBitWriteBuffer(io.Discard), bitio.MaxQUICVarint+1); !errors.Is(err, bitio.ErrValueOverflow) {
+		t.Fatalf("WriteQUICVarint(2^62) error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	if _, err := bitio.ReadQUICVarint(hexReader("")); err != io.EOF {
+		t.Fatalf("ReadQUICVarint() of empty data error %v, want %v", err, io.EOF)
+	}
+	if _, err := bitio.ReadQUICVarint(hexReader("9d7f")); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("ReadQUICVarint(9d7f) error %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestHPACKInt(t *testing.T) {
+	// RFC 7541 Appendix C.1 (prefix is placed after 8-N flag bits of 1)
+	tests := []struct {
+		prefix int
+		v      uint64
+		raw    string
+	}{
+		{5, 10, "01010"},
+		{5, 1337, "11111_10011010_00001010"},
+		{8, 42, "00101010"},
+		{5, 30, "11110"},
+		{5, 31, "11111_00000000"},
+		{1, 0, "0"},
+		{1, 1, "1_00000000"},
+		{8, math.MaxUint64, "11111111_10000000_11111110_11111111_11111111_11111111_11111111_11111111_11111111_11111111_00000001"},
+	}
+
+	for _, tt := range tests {
+		flag := 8 - tt.prefix
+		raw := binaryToByteArray(strings.Repeat("1", flag) + tt.raw)
+
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		w.WriteBit(0xff, flag)
+		if err := bitio.WriteHPACKInt(w, tt.prefix, tt.v); err != nil {
+			t.Fatalf("WriteHPACKInt(%d, %d) error: %v", tt.prefix, tt.v, err)
+		}
+		w.Flush()
+		if !reflect.DeepEqual(b.Bytes(), raw) {
+			t.Fatalf("WriteHPACKInt(%d, %d) writes %x, want %x", tt.prefix, tt.v, b.Bytes(), raw)
+		}
+
+		r := bitio.NewBitReadBuffer(bytes.NewReader(raw))
+		var head byte
+		r.ReadBit(&head, flag)
+		if v, err := bitio.ReadHPACKInt(r, tt.prefix); err != nil || v != tt.v {
+			t.Fatalf("ReadHPACKInt(%d, %x) returns %d, %v, want %d", tt.prefix, raw, v, err, tt.v)
+		}
+	}
+}
+
+func TestHPACKInt_Error(t *testing.T) {
+	for _, prefix := range []int{0, 9} {
+		if err := bitio.WriteHPACKInt(bitio.NewBitWriteBuffer(io.Discard), prefix, 1); err == nil {
+			t.Fatalf("WriteHPACKInt(%d) must be error", prefix)
+		}
+		if _, err := bitio.ReadHPACKInt(hexReader("00"), prefix); err == nil {
+			t.Fatalf("ReadHPACKInt(%d) must be error", prefix)
+		}
+	}
+
+	tests := []struct {
+		raw string
+		err error
+	}{
+		{"", io.EOF},
+		{"ff", io.ErrUnexpectedEOF},
+		{"ff80", io.ErrUnexpectedEOF},
+		// 255 + 2^64-127
+		{"ff81ffffffffffffffff01", bitio.ErrValueOverflow},
+	}
+	for _, tt := range tests {
+		if _, err := bitio.ReadHPACKInt(hexReader(tt.raw), 8); !errors.Is(err, tt.err) {
+			t.Fatalf("ReadHPACKInt(8, %s) error %v, want %v", tt.raw, err, tt.err)
+		}
+	}
+}
+
+// quicStreamFrame is STREAM frame of QUIC with OFF, LEN and FIN bits.
+type quicStreamFrame struct {
+	Type     uint8  `bit:"5"` // 0b00001
+	Off      bool   `bit:"1"`
+	Len      bool   `bit:"1"`
+	Fin      bool   `bit:"1"`
+	StreamID uint64 `encoding:"quicvar"`
+	Offset   uint64 `encoding:"quicvar"`
+	Length   uint16 `encoding:"quicvar"`
+	Data     []byte `byte:"1" len:"Length"`
+}
+
+// hpackLiteral is literal header field without indexing of HPACK (indexed name).
+type hpackLiteral struct {
+	Pattern   uint8  `bit:"4"` // 0b0000
+	NameIndex uint   `encoding:"hpack" prefix:"4"`
+	Huffman   bool   `bit:"1"`
+	ValueLen  int    `encoding:"hpack" prefix:"7"`
+	Value     []byte `byte:"1" len:"ValueLen"`
+}
+
+func TestPrefixInt_Struct(t *testing.T) {
+	frame := &quicStreamFrame{Type: 1, Off: true, Len: true, StreamID: 4, Offset: 15293, Length: 3, Data: []byte("abc")}
+	raw, _ := hex.DecodeString("0e" + "04" + "7bbd" + "03" + "616263")
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitFieldWriter(b)
+	if n, err := w.WriteStruct(frame); err != nil || n != 8*len(raw) {
+		t.Fatalf("WriteStruct write %d bit (%v), want %d bit", n, err, 8*len(raw))
+	}
+	w.Flush()
+	if !bytes.Equal(b.Bytes(), raw) {
+		t.Fatalf("WriteStruct write %x, want %x", b.Bytes(), raw)
+	}
+	if bits, err := bitio.SizeOf(frame); err != nil || bits != 8*len(raw) {
+		t.Fatalf("SizeOf returns %d (%v), want %d", bits, err, 8*len(raw))
+	}
+
+	got := &quicStreamFrame{}
+	if _, err := bitio.NewBitFieldReader(bytes.NewReader(raw)).ReadStruct(got); err != nil {
+		t.Fatalf("ReadStruct error: %v", err)
+	}
+	if reflect.DeepEqual(got, frame) == false {
+		t.Fatalf("ReadStruct read %+v, want %+v", got, frame)
+	}
+}
+
+func TestPrefixInt_Struct_HPACK(t *testing.T) {
+	// RFC 7541 Appendix C.2.2 (:path: /sample/path)
+	raw, _ := hex.DecodeString("040c2f73616d706c652f70617468")
+	exp := &hpackLiteral{NameIndex: 4, ValueLen: 12, Value: []byte("/sample/path")}
+
+	got := &hpackLiteral{}
+	if n, err := bitio.NewBitFieldReader(bytes.NewReader(raw)).ReadStruct(got); err != nil || n != 8*len(raw) {
+		t.Fatalf("ReadStruct read %d bit (%v), want %d bit", n, err, 8*len(raw))
+	}
+	if reflect.DeepEqual(got, exp) == false {
+		t.Fatalf("ReadStruct read %+v, want %+v", got, exp)
+	}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitFieldWriter(b)
+	w.WriteStruct(exp)
+	w.Flush()
+	if !bytes.Equal(b.Bytes(), raw) {
+		t.Fatalf("WriteStruct write %x, want %x", b.Bytes(), raw)
+	}
+}
+
+func TestPrefixInt_Struct_Error(t *testing.T) {
+	invalid := []interface{}{
+		&struct {
+			Val uint `encoding:"hpack"`
+		}{},
+		&struct {
+			Val uint `encoding:"hpack" prefix:"9"`
+		}{},
+		&struct {
+			Val uint `encoding:"hpack:5"`
+		}{},
+		&struct {
+			Val uint `encoding:"quicvar" prefix:"5"`
+		}{},
+	}
+	for _, ptr := range invalid {
+		if _, err := bitio.SizeOf(ptr); errors.Is(err, bitio.ErrInvalidTag) == false {
+			t.Fatalf("%T error %v, want %v", ptr, err, bitio.ErrInvalidTag)
+		}
+	}
+
+	w := bitio.NewBitFieldWriter(io.Discard)
+	if _, err := w.WriteStruct(&struct {
+		Val uint64 `encoding:"quicvar"`
+	}{1 << 62}); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteStruct quicvar 2^62 error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	if _, err := w.WriteStruct(&struct {
+		Val int `encoding:"hpack" prefix:"5"`
+	}{-1}); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteStruct negative hpack error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	// 2^62-1 does not fit in uint16
+	r := bitio.NewBitFieldReader(bytes.NewReader(bytes.Repeat([]byte{0xff}, 8)))
+	if _, err := r.ReadStruct(&struct {
+		Val uint16 `encoding:"quicvar"`
+	}{}); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("ReadStruct quicvar into uint16 error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+}
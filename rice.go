@@ -0,0 +1,346 @@
+package bitio
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+)
+
+// maxUnary is the max unary quotient of Rice and Golomb code.
+const maxUnary = 1 << 16
+
+// ReadRice reads Rice code of parameter k.
+// The code is unary quotient (zeros terminated by 1) and k bits remainder.
+// Return error wrapping ErrValueOverflow if quotient exceeds 65536 (2^16).
+func ReadRice(r BitReader, k int) (uint64, error) {
+	_, v, err := readRice(r, k, make([]byte, 8))
+	return v, err
+}
+
+// WriteRice writes v as Rice code of parameter k.
+// Return error wrapping ErrValueOverflow if quotient exceeds 65536 (2^16).
+func WriteRice(w BitWriter, k int, v uint64) error {
+	_, err := writeRice(w, k, v, make([]byte, 8))
+	return err
+}
+
+// ReadSignedRice reads Rice code of parameter k as signed value.
+// Signed value is folded to unsigned value. (0, -1, 1, -2, ...) -> (0, 1, 2, 3, ...)
+func ReadSignedRice(r BitReader, k int) (int64, error) {
+	v, err := ReadRice(r, k)
+	if err != nil {
+		return 0, err
+	}
+	return unfoldSigned(v), nil
+}
+
+// WriteSignedRice writes signed value v as Rice code of parameter k.
+// Signed value is folded to unsigned value. (0, -1, 1, -2, ...) -> (0, 1, 2, 3, ...)
+func WriteSignedRice(w BitWriter, k int, v int64) error {
+	return WriteRice(w, k, foldSigned(v))
+}
+
+// ReadGolomb reads Golomb code of parameter m.
+// The code is unary quotient (zeros terminated by 1) and truncated binary remainder.
+// Return error wrapping ErrValueOverflow if quotient exceeds 65536 (2^16).
+func ReadGolomb(r BitReader, m uint64) (uint64, error) {
+	_, v, err := readGolomb(r, m, make([]byte, 8))
+	return v, err
+}
+
+// WriteGolomb writes v as Golomb code of parameter m.
+// Return error wrapping ErrValueOverflow if quotient exceeds 65536 (2^16).
+func WriteGolomb(w BitWriter, m uint64, v uint64) error {
+	_, err := writeGolomb(w, m, v, make([]byte, 8))
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// readRice reads Rice code of parameter k and returns read size and value.
+// buf is a work space of 8 bytes.
+func readRice(r BitReader, k int, buf []byte) (int, uint64, error) {
+	if k < 0 || k > 63 {
+		return 0, 0, fmt.Errorf("bitio: rice parameter %d is out of range", k)
+	}
+
+	q, err := readZeros(r, maxQuotient(1<<uint(k)), buf)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	v := uint64(q) << uint(k)
+	if k > 0 {
+		low, err := readUint64(r, k, BigEndian, buf)
+		if err != nil {
+			return 0, 0, unexpectedEOF(err)
+		}
+		v |= low
+	}
+
+	return q + 1 + k, v, nil
+}
+
+// writeRice writes v as Rice code of parameter k and returns write size.
+// buf is a work space of 8 bytes.
+func writeRice(w BitWriter, k int, v uint64, buf []byte) (int, error) {
+	if k < 0 || k > 63 {
+		return 0, fmt.Errorf("bitio: rice parameter %d is out of range", k)
+	}
+
+	q := v >> uint(k)
+	if q > maxUnary {
+		return 0, fmt.Errorf("rice value %d exceeds unary size %d bit: %w", v, maxUnary, ErrValueOverflow)
+	}
+	if err := writeUnary(w, int(q), buf); err != nil {
+		return 0, err
+	}
+	if k > 0 {
+		if err := writeUint64(w, k, BigEndian, v, buf); err != nil {
+			return 0, err
+		}
+	}
+
+	return int(q) + 1 + k, nil
+}
+
+// riceLen returns bit size of v as Rice code of parameter k.
+func riceLen(k int, v uint64) int {
+	return int(v>>uint(k)) + 1 + k
+}
+
+// readGolomb reads Golomb code of parameter m and returns read size and value.
+// buf is a work space of 8 bytes.
+func readGolomb(r BitReader, m uint64, buf []byte) (int, uint64, error) {
+	if m == 0 {
+		return 0, 0, fmt.Errorf("bitio: golomb parameter needs positive value")
+	}
+
+	q, err := readZeros(r, maxQuotient(m), buf)
+	if err != nil {
+		return 0, 0, err
+	}
+	n := q + 1
+
+	// truncated binary remainder
+	b, cutoff := golombCutoff(m)
+	var rem uint64
+	if b > 1 {
+		if rem, err = readUint64(r, b-1, BigEndian, buf); err != nil {
+			return 0, 0, unexpectedEOF(err)
+		}
+		n += b - 1
+	}
+	if b > 0 && rem >= cutoff {
+		bit, err := readUint64(r, 1, BigEndian, buf)
+		if err != nil {
+			return 0, 0, unexpectedEOF(err)
+		}
+		rem = (rem<<1 | bit) - cutoff
+		n++
+	}
+
+	hi, lo := bits.Mul64(uint64(q), m)
+	v, carry := bits.Add64(lo, rem, 0)
+	if hi != 0 || carry != 0 {
+		return 0, 0, fmt.Errorf("golomb value exceeds 64 bit: %w", ErrValueOverflow)
+	}
+	return n, v, nil
+}
+
+// writeGolomb writes v as Golomb code of parameter m and returns write size.
+// buf is a work space of 8 bytes.
+func writeGolomb(w BitWriter, m uint64, v uint64, buf []byte) (int, error) {
+	if m == 0 {
+		return 0, fmt.Errorf("bitio: golomb parameter needs positive value")
+	}
+
+	q := v / m
+	if q > maxUnary {
+		return 0, fmt.Errorf("golomb value %d exceeds unary size %d bit: %w", v, maxUnary, ErrValueOverflow)
+	}
+	if err := writeUnary(w, int(q), buf); err != nil {
+		return 0, err
+	}
+
+	// truncated binary remainder
+	b, cutoff := golombCutoff(m)
+	rem := v % m
+	size := b
+	if rem < cutoff {
+		size--
+	} else {
+		rem += cutoff
+	}
+	if size > 0 {
+		if err := writeUint64(w, size, BigEndian, rem, buf); err != nil {
+			return 0, err
+		}
+	}
+
+	return int(q) + 1 + size, nil
+}
+
+// golombLen returns bit size of v as Golomb code of parameter m.
+func golombLen(m uint64, v uint64) int {
+	b, cutoff := golombCutoff(m)
+	if v%m < cutoff {
+		b--
+	}
+	return int(v/m) + 1 + b
+}
+
+// golombCutoff returns bit size b = ceil(log2(m)) and cutoff 2^b - m of truncated binary.
+func golombCutoff(m uint64) (int, uint64) {
+	b := bits.Len64(m - 1)
+	if b == 64 {
+		return b, -m
+	}
+	return b, 1<<uint(b) - m
+}
+
+// maxQuotient returns max unary quotient of 64 bit value and divisor m. (up to maxUnary)
+func maxQuotient(m uint64) int {
+	return int(min(math.MaxUint64/m, maxUnary))
+}
+
+// writeUnary writes q zero bits and terminating 1 bit.
+// buf is a work space of 8 bytes.
+func writeUnary(w BitWriter, q int, buf []byte) error {
+	for ; q >= 64; q -= 64 {
+		if err := writeUint64(w, 64, BigEndian, 0, buf); err != nil {
+			return err
+		}
+	}
+	return writeUint64(w, q+1, BigEndian, 1, buf)
+}
+
+// foldSigned maps signed value to unsigned value.
+// (0, -1, 1, -2, ...) -> (0, 1, 2, 3, ...)
+func foldSigned(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+// unfoldSigned maps unsigned value to signed value.
+func unfoldSigned(u uint64) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// riceEncoding is valueEncoding of `encoding:"rice:N"`, `encoding:"rice" param:"Field"`,
+// `encoding:"golomb:M"` and `encoding:"golomb" param:"Field"`.
+// Signed value is folded to unsigned value.
+type riceEncoding struct {
+	golomb bool
+	param  uint64 // fixed parameter k or m
+	ref    int    // struct field index of parameter (-1: fixed)
+	signed bool   // field is signed integer
+}
+
+func newRiceEncoding(ctx *encodingContext) (valueEncoding, error) {
+	enc := &riceEncoding{golomb: ctx.name == "golomb", ref: -1, signed: ctx.signed}
+
+	name, ok := ctx.tag.Lookup("param")
+	switch {
+	case ok && ctx.param != "":
+		return nil, fmt.Errorf("parameter is set twice")
+	case ok:
+		if enc.ref, ok = ctx.field(name); !ok {
+			return nil, fmt.Errorf("parameter %q", name)
+		}
+	default:
+		v, err := strconv.ParseUint(ctx.param, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q", ctx.param)
+		}
+		if err = enc.check(v); err != nil {
+			return nil, err
+		}
+		enc.param = v
+	}
+
+	return enc, nil
+}
+
+func (enc *riceEncoding) read(r BitReader, rv reflect.Value, buf []byte) (uint64, int, error) {
+	param, err := enc.paramOf(rv)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var n int
+	var u uint64
+	if enc.golomb {
+		n, u, err = readGolomb(r, param, buf)
+	} else {
+		n, u, err = readRice(r, int(param), buf)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if enc.signed {
+		return uint64(unfoldSigned(u)), n, nil
+	}
+	return u, n, nil
+}
+
+func (enc *riceEncoding) write(w BitWriter, rv reflect.Value, v uint64, buf []byte) (int, error) {
+	param, err := enc.paramOf(rv)
+	if err != nil {
+		return 0, err
+	}
+
+	if enc.signed {
+		v = foldSigned(int64(v))
+	}
+	if enc.golomb {
+		return writeGolomb(w, param, v, buf)
+	}
+	return writeRice(w, int(param), v, buf)
+}
+
+func (enc *riceEncoding) bitLen(rv reflect.Value, v uint64) int {
+	param, err := enc.paramOf(rv)
+	if err != nil {
+		return 0
+	}
+
+	if enc.signed {
+		v = foldSigned(int64(v))
+	}
+	if enc.golomb {
+		return golombLen(param, v)
+	}
+	return riceLen(int(param), v)
+}
+
+// paramOf returns parameter k or m of struct value rv.
+func (enc *riceEncoding) paramOf(rv reflect.Value) (uint64, error) {
+	if enc.ref < 0 {
+		return enc.param, nil
+	}
+
+	v := intValue(rv.Field(enc.ref))
+	if v < 0 {
+		return 0, fmt.Errorf("parameter %d is out of range", v)
+	}
+	if err := enc.check(uint64(v)); err != nil {
+		return 0, err
+	}
+	return uint64(v), nil
+}
+
+// check checks parameter k or m.
+func (enc *riceEncoding) check(v uint64) error {
+	if enc.golomb && v == 0 {
+		return fmt.Errorf("golomb parameter needs positive value")
+	}
+	if !enc.golomb && v > 63 {
+		return fmt.Errorf("rice parameter %d is out of range [0, 63]", v)
+	}
+	return nil
+}
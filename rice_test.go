@@ -0,0 +1,266 @@
+package bitio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+// bitReaderOnly hides optional methods of BitReader.
+type bitReaderOnly struct {
+	bitio.BitReader
+}
+
+var riceTests = []struct {
+	k   int
+	v   uint64
+	raw string
+}{
+	{0, 0, "1"},
+	{0, 3, "0001"},
+	{2, 0, "100"},
+	{2, 5, "0101"},
+	{2, 7, "0111"},
+	{4, 100, "0000001_0100"},
+	{1, 40, "00000000000000000000_1_0"},
+	{63, 1<<64 - 1, "01_" + strings.Repeat("1", 63)},
+}
+
+func TestWriteRice(t *testing.T) {
+	for _, tt := range riceTests {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		if err := bitio.WriteRice(w, tt.k, tt.v); err != nil {
+			t.Fatalf("WriteRice(%d, %d) error: %v", tt.k, tt.v, err)
+		}
+		w.Flush()
+
+		if exp := binaryToByteArray(tt.raw); reflect.DeepEqual(b.Bytes(), exp) == false {
+			t.Fatalf("WriteRice(%d, %d) write %#v, want %#v", tt.k, tt.v, b.Bytes(), exp)
+		}
+	}
+}
+
+func TestReadRice(t *testing.T) {
+	for _, tt := range riceTests {
+		readers := []bitio.BitReader{
+			bitio.NewBitReadBuffer(bytes.NewReader(binaryToByteArray(tt.raw))),
+			bitReaderOnly{bitio.NewBitReadBuffer(bytes.NewReader(binaryToByteArray(tt.raw)))},
+		}
+		for _, r := range readers {
+			v, err := bitio.ReadRice(r, tt.k)
+			if err != nil {
+				t.Fatalf("ReadRice(%d) %q error: %v", tt.k, tt.raw, err)
+			}
+			if v != tt.v {
+				t.Fatalf("ReadRice(%d) %q read %d, want %d", tt.k, tt.raw, v, tt.v)
+			}
+		}
+	}
+}
+
+func TestSignedRice(t *testing.T) {
+	values := []int64{0, -1, 1, -2, 2, 1<<62 - 1, -1 << 62}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	for _, v := range values {
+		if err := bitio.WriteSignedRice(w, 62, v); err != nil {
+			t.Fatalf("WriteSignedRice(%d) error: %v", v, err)
+		}
+	}
+	w.Flush()
+
+	// 0 -> 0, -1 -> 1, 1 -> 2
+	if exp := binaryToByteArray("1" + strings.Repeat("0", 62) + "1" + strings.Repeat("0", 61) + "1"); bytes.HasPrefix(b.Bytes(), exp[:15]) == false {
+		t.Fatalf("WriteSignedRice write %#v, want prefix %#v", b.Bytes()[:15], exp[:15])
+	}
+
+	r := bitio.NewBitReadBuffer(b)
+	for _, exp := range values {
+		if v, err := bitio.ReadSignedRice(r, 62); err != nil || v != exp {
+			t.Fatalf("ReadSignedRice read %d (%v), want %d", v, err, exp)
+		}
+	}
+}
+
+func TestGolomb(t *testing.T) {
+	tests := []struct {
+		m   uint64
+		v   uint64
+		raw string
+	}{
+		{1, 2, "001"},
+		{3, 0, "10"},
+		{3, 1, "110"},
+		{3, 2, "111"},
+		{3, 3, "010"},
+		{4, 5, "0101"},
+		{5, 9, "01111"},
+		{1<<63 + 1, 1<<63 + 2, "01_" + strings.Repeat("0", 62) + "1"},
+	}
+
+	for _, tt := range tests {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		if err := bitio.WriteGolomb(w, tt.m, tt.v); err != nil {
+			t.Fatalf("WriteGolomb(%d, %d) error: %v", tt.m, tt.v, err)
+		}
+		w.Flush()
+
+		exp := binaryToByteArray(tt.raw)
+		if reflect.DeepEqual(b.Bytes(), exp) == false {
+			t.Fatalf("WriteGolomb(%d, %d) write %#v, want %#v", tt.m, tt.v, b.Bytes(), exp)
+		}
+
+		r := bitio.NewBitReadBuffer(bytes.NewReader(exp))
+		if v, err := bitio.ReadGolomb(r, tt.m); err != nil || v != tt.v {
+			t.Fatalf("ReadGolomb(%d) %q read %d (%v), want %d", tt.m, tt.raw, v, err, tt.v)
+		}
+	}
+}
+
+func TestRice_Error(t *testing.T) {
+	w := bitio.NewBitWriteBuffer(io.Discard)
+	if err := bitio.WriteRice(w, 64, 0); err == nil {
+		t.Fatalf("WriteRice k=64 wants error")
+	}
+	if err := bitio.WriteGolomb(w, 0, 0); err == nil {
+		t.Fatalf("WriteGolomb m=0 wants error")
+	}
+	if err := bitio.WriteRice(w, 0, 1<<40); !errors.Is(err, bitio.ErrValueOverflow) {
+		t.Fatalf("WriteRice k=0 of 2^40 error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	if err := bitio.WriteGolomb(w, 3, 1<<40); !errors.Is(err, bitio.ErrValueOverflow) {
+		t.Fatalf("WriteGolomb m=3 of 2^40 error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	if err := bitio.WriteRice(w, 0, 1<<16); err != nil {
+		t.Fatalf("WriteRice k=0 of 2^16 error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		raw  []byte
+		k    int
+		err  error
+	}{
+		{"empty", []byte{}, 2, io.EOF},
+		{"EOF in prefix", []byte{0x00, 0x00}, 2, bitio.ErrUnexpectedEOF},
+		{"EOF in remainder", []byte{0x01}, 2, bitio.ErrUnexpectedEOF},
+		{"too long prefix", []byte{0x00, 0x00}, 60, bitio.ErrValueOverflow},
+		{"too long unary", make([]byte, 1<<13+1), 0, bitio.ErrValueOverflow},
+	}
+	for _, tt := range tests {
+		readers := []bitio.BitReader{
+			bitio.NewBitReadBuffer(bytes.NewReader(tt.raw)),
+			bitReaderOnly{bitio.NewBitReadBuffer(bytes.NewReader(tt.raw))},
+		}
+		for _, r := range readers {
+			if _, err := bitio.ReadRice(r, tt.k); errors.Is(err, tt.err) == false {
+				t.Fatalf("ReadRice %q error %v, want %v", tt.name, err, tt.err)
+			}
+		}
+	}
+
+	raw := make([]byte, 1<<13+1)
+	if _, err := bitio.ReadGolomb(bitio.NewBitReadBuffer(bytes.NewReader(raw)), 3); !errors.Is(err, bitio.ErrValueOverflow) {
+		t.Fatalf("ReadGolomb of too long unary error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+}
+
+type riceRecord struct {
+	RiceK    uint8   `bit:"4"`
+	Count    uint8   `bit:"4"`
+	Residual []int16 `encoding:"rice" param:"RiceK" len:"Count"`
+	Gap      uint    `encoding:"golomb:3"`
+}
+
+func TestRice_Struct(t *testing.T) {
+	v := &riceRecord{RiceK: 2, Residual: []int16{0, -1, 3, -4}, Gap: 3}
+	// residual folded to 0, 1, 6, 7
+	raw := binaryToByteArray("0010_0100_100_101_0110_0111_010")
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitFieldWriter(b)
+	n, err := w.WriteStruct(v)
+	if err != nil {
+		t.Fatalf("WriteStruct error: %v", err)
+	}
+	w.Flush()
+	if n != 25 || reflect.DeepEqual(b.Bytes(), raw) == false {
+		t.Fatalf("WriteStruct write %#v (%d bit), want %#v (25 bit)", b.Bytes(), n, raw)
+	}
+
+	if bits, err := bitio.SizeOf(v); err != nil || bits != 25 {
+		t.Fatalf("SizeOf returns %d (%v), want 25", bits, err)
+	}
+
+	got := &riceRecord{}
+	r := bitio.NewBitFieldReader(bytes.NewReader(raw))
+	if n, err = r.ReadStruct(got); err != nil || n != 25 {
+		t.Fatalf("ReadStruct read %d bit (%v), want 25 bit", n, err)
+	}
+	if reflect.DeepEqual(got, v) == false {
+		t.Fatalf("ReadStruct read %+v, want %+v", got, v)
+	}
+}
+
+func TestRice_Struct_Error(t *testing.T) {
+	v := &riceRecord{RiceK: 64, Residual: []int16{0}, Gap: 0}
+	w := bitio.NewBitFieldWriter(io.Discard)
+	if _, err := w.WriteStruct(v); err == nil {
+		t.Fatalf("WriteStruct rice parameter 64 wants error")
+	}
+
+	invalid := []interface{}{
+		&struct {
+			Val uint `encoding:"rice"`
+		}{},
+		&struct {
+			Val uint `encoding:"rice:64"`
+		}{},
+		&struct {
+			Val uint `encoding:"golomb:0"`
+		}{},
+		&struct {
+			Val uint `encoding:"rice" param:"K"`
+			K   uint `bit:"4"`
+		}{},
+		&struct {
+			K   string `byte:"1"`
+			Val uint   `encoding:"rice" param:"K"`
+		}{},
+		&struct {
+			K   uint `bit:"4"`
+			Val uint `encoding:"rice:2" param:"K"`
+		}{},
+	}
+	for _, ptr := range invalid {
+		if _, err := bitio.SizeOf(ptr); errors.Is(err, bitio.ErrInvalidTag) == false {
+			t.Fatalf("%T error %v, want %v", ptr, err, bitio.ErrInvalidTag)
+		}
+	}
+}
+
+func BenchmarkReadRice(b *testing.B) {
+	buf := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(buf)
+	for i := 0; i < 1024; i++ {
+		bitio.WriteRice(w, 2, uint64(i%64))
+	}
+	w.Flush()
+	raw := buf.Bytes()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := bitio.NewBitReadBuffer(bytes.NewReader(raw))
+		for j := 0; j < 1024; j++ {
+			bitio.ReadRice(r, 2)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+// Package rle implements Parquet RLE/bit-packing hybrid encoding of integers.
+//
+// Encoded data is a sequence of runs (without 4 bytes length prefix of data page v1):
+//
+//	rle-run        ULEB128(count << 1)             value (ceil(width/8) bytes, little endian)
+//	bit-packed-run ULEB128(number of groups << 1 | 1) groups of 8 values (LSB first, width bytes)
+//
+// The number of values is not stored, and the last bit-packed group may be padded by 0.
+package rle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hidez8891/bitio"
+)
+
+// MaxBitWidth is max bit width of values.
+const MaxBitWidth = 32
+
+// maxGroups is max number of groups of bit-packed run written by encoder. (1 byte header)
+const maxGroups = 63
+
+// ErrCorrupted is returned when encoded data is invalid.
+var ErrCorrupted = errors.New("rle: corrupted data")
+
+// checkWidth checks bit width.
+func checkWidth(bitWidth int) error {
+	if bitWidth < 0 || bitWidth > MaxBitWidth {
+		return fmt.Errorf("rle: bit width %d is out of range [0, %d]", bitWidth, MaxBitWidth)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Encode writes values of bitWidth bits as hybrid runs.
+// Runs of 8 or more same values are written as RLE run, others are bit-packed.
+// If error happen, err will be set.
+func Encode(w io.Writer, bitWidth int, values []uint32) error {
+	if err := checkWidth(bitWidth); err != nil {
+		return err
+	}
+	for i, v := range values {
+		if bitWidth < 32 && v>>uint(bitWidth) != 0 {
+			return fmt.Errorf("rle: element %d: value %d exceeds %d bit: %w", i, v, bitWidth, bitio.ErrValueOverflow)
+		}
+	}
+
+	e := &encoder{w: bitio.NewLSBWriteBuffer(w), width: bitWidth}
+
+	// pending values are bit-packed
+	start := 0
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+
+		// align pending values to group
+		if j-i >= 8 {
+			i += (8 - (i-start)%8) % 8
+		}
+		if j-i >= 8 {
+			if err := e.bitPacked(values[start:i]); err != nil {
+				return err
+			}
+			if err := e.rle(values[i], j-i); err != nil {
+				return err
+			}
+			start = j
+		}
+		i = j
+	}
+	if err := e.bitPacked(values[start:]); err != nil {
+		return err
+	}
+
+	return e.w.Flush()
+}
+
+// encoder writes runs.
+type encoder struct {
+	w     *bitio.LSBWriteBuffer
+	width int
+	buf   [4]byte
+}
+
+// rle writes RLE run of count values.
+func (e *encoder) rle(v uint32, count int) error {
+	if err := bitio.WriteULEB128(e.w, uint64(count)<<1); err != nil {
+		return err
+	}
+	for i := 0; i < (e.width+7)/8; i++ {
+		if _, err := e.w.WriteBit(byte(v>>uint(8*i)), 8); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bitPacked writes bit-packed runs of values. The last group is padded by 0.
+func (e *encoder) bitPacked(values []uint32) error {
+	for len(values) > 0 {
+		groups := min((len(values)+7)/8, maxGroups)
+		if err := bitio.WriteULEB128(e.w, uint64(groups)<<1|1); err != nil {
+			return err
+		}
+
+		for i := 0; i < groups*8; i++ {
+			var v uint32
+			if i < len(values) {
+				v = values[i]
+			}
+			e.buf = [4]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+			if _, err := e.w.WriteBits(e.buf[:], e.width); err != nil {
+				return err
+			}
+		}
+		values = values[min(groups*8, len(values)):]
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Decode reads len(dst) values of bitWidth bits.
+// If error happen, err will be set.
+func Decode(r io.Reader, bitWidth int, dst []uint32) error {
+	d, err := NewDecoder(r, bitWidth)
+	if err != nil {
+		return err
+	}
+
+	for n := 0; n < len(dst); {
+		m, err := d.Read(dst[n:])
+		n += m
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// NewDecoder returns Decoder of bitWidth bits values.
+func NewDecoder(r io.Reader, bitWidth int) (*Decoder, error) {
+	if err := checkWidth(bitWidth); err != nil {
+		return nil, err
+	}
+	return &Decoder{
+		r:     bitio.NewLSBReadBuffer(r),
+		width: bitWidth,
+	}, nil
+}
+
+// Decoder is streaming decoder of hybrid runs.
+type Decoder struct {
+	r      *bitio.LSBReadBuffer
+	width  int
+	left   int    // number of values left in current run
+	packed bool   // current run is bit-packed
+	value  uint32 // value of RLE run
+	buf    [4]byte
+}
+
+// Read reads values to dst and returns number of read values.
+// Padding values of the last bit-packed group are also read.
+// Returns io.EOF if there are no more runs.
+func (d *Decoder) Read(dst []uint32) (n int, err error) {
+	for n < len(dst) {
+		if d.left == 0 {
+			if err = d.next(); err != nil {
+				if err == io.EOF && n > 0 {
+					err = nil
+				}
+				return
+			}
+			continue
+		}
+
+		m := min(d.left, len(dst)-n)
+		if !d.packed {
+			for i := range dst[n : n+m] {
+				dst[n+i] = d.value
+			}
+		} else {
+			for i := range dst[n : n+m] {
+				if dst[n+i], err = d.readPacked(); err != nil {
+					return n + i, err
+				}
+			}
+		}
+		d.left -= m
+		n += m
+	}
+	return
+}
+
+// next reads header of next run.
+func (d *Decoder) next() error {
+	header, err := bitio.ReadULEB128(d.r)
+	if err != nil {
+		if errors.Is(err, bitio.ErrValueOverflow) {
+			err = fmt.Errorf("%w: run header exceeds 64 bit", ErrCorrupted)
+		}
+		return err
+	}
+	if header>>1 == 0 || header>>1 > 1<<31/8 {
+		return fmt.Errorf("%w: run header %#x", ErrCorrupted, header)
+	}
+
+	if header&1 == 1 {
+		d.packed = true
+		d.left = int(header>>1) * 8
+		return nil
+	}
+
+	d.packed = false
+	d.left = int(header >> 1)
+	d.value = 0
+	for i := 0; i < (d.width+7)/8; i++ {
+		var b byte
+		if n, err := d.r.ReadBit(&b, 8); err != nil || n != 8 {
+			return unexpectedEOF(err)
+		}
+		d.value |= uint32(b) << uint(8*i)
+	}
+	if d.width < 32 && d.value>>uint(d.width) != 0 {
+		return fmt.Errorf("%w: RLE value %d exceeds %d bit", ErrCorrupted, d.value, d.width)
+	}
+	return nil
+}
+
+// readPacked reads a value of bit-packed run.
+func (d *Decoder) readPacked() (uint32, error) {
+	if n, err := d.r.ReadBits(d.buf[:], d.width); err != nil || n != d.width {
+		return 0, unexpectedEOF(err)
+	}
+	return uint32(d.buf[0])<<24 | uint32(d.buf[1])<<16 | uint32(d.buf[2])<<8 | uint32(d.buf[3]), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// unexpectedEOF converts io.EOF (or nil of short read) to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == nil || err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+package rle_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/rle"
+)
+
+func seq(n int, v uint32) []uint32 {
+	values := make([]uint32, n)
+	for i := range values {
+		values[i] = v
+	}
+	return values
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		width  int
+		values []uint32
+		exp    []byte
+	}{
+		// bit-packed example of Parquet spec (values 0-7, 3 bits)
+		{3, []uint32{0, 1, 2, 3, 4, 5, 6, 7}, []byte{0x03, 0x88, 0xc6, 0xfa}},
+		// RLE run of 10 ones
+		{1, seq(10, 1), []byte{0x14, 0x01}},
+		// RLE run with 2 bytes header and 2 bytes value
+		{12, seq(300, 0xabc), []byte{0xd8, 0x04, 0xbc, 0x0a}},
+		// RLE run and padded bit-packed run
+		{2, []uint32{1, 1, 1, 1, 1, 1, 1, 1, 2, 3}, []byte{0x10, 0x01, 0x03, 0x0e, 0x00}},
+		// bit-packed run is aligned to group before RLE run
+		{3, append([]uint32{5}, seq(15, 7)...), []byte{0x03, 0xfd, 0xff, 0xff, 0x10, 0x07}},
+		// short run is bit-packed
+		{8, seq(7, 0x5a), []byte{0x03, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x00}},
+		// 0 bit width
+		{0, seq(5, 0), []byte{0x03}},
+		{0, seq(10, 0), []byte{0x14}},
+		{32, seq(8, 0xdeadbeef), []byte{0x10, 0xef, 0xbe, 0xad, 0xde}},
+		{5, nil, []byte{}},
+	}
+
+	for _, tt := range tests {
+		b := new(bytes.Buffer)
+		if err := rle.Encode(b, tt.width, tt.values); err != nil {
+			t.Fatalf("Encode(%d, %v) error: %v", tt.width, tt.values, err)
+		}
+		if !bytes.Equal(b.Bytes(), tt.exp) {
+			t.Fatalf("Encode(%d, %v) writes %x, want %x", tt.width, tt.values, b.Bytes(), tt.exp)
+		}
+
+		got := make([]uint32, len(tt.values))
+		if err := rle.Decode(bytes.NewReader(tt.exp), tt.width, got); err != nil {
+			t.Fatalf("Decode(%d, %x) error: %v", tt.width, tt.exp, err)
+		}
+		if len(got) > 0 && !reflect.DeepEqual(got, tt.values) {
+			t.Fatalf("Decode(%d, %x) reads %v, want %v", tt.width, tt.exp, got, tt.values)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for width := 0; width <= rle.MaxBitWidth; width++ {
+		// mixed runs of various lengths
+		var values []uint32
+		for len(values) < 5000 {
+			v := uint32(rnd.Uint64() & (1<<uint(width) - 1))
+			values = append(values, seq(1+rnd.Intn(20), v)...)
+		}
+
+		b := new(bytes.Buffer)
+		if err := rle.Encode(b, width, values); err != nil {
+			t.Fatalf("Encode(%d) error: %v", width, err)
+		}
+
+		// streaming decode with small buffer
+		d, err := rle.NewDecoder(b, width)
+		if err != nil {
+			t.Fatalf("NewDecoder(%d) error: %v", width, err)
+		}
+		var got []uint32
+		buf := make([]uint32, 7)
+		for {
+			n, err := d.Read(buf)
+			got = append(got, buf[:n]...)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Read(%d) error: %v", width, err)
+			}
+		}
+
+		// padding of the last group
+		if len(got) < len(values) || len(got) >= len(values)+8 {
+			t.Fatalf("Read(%d) reads %d values, want %d", width, len(got), len(values))
+		}
+		if !reflect.DeepEqual(got[:len(values)], values) {
+			t.Fatalf("Read(%d) reads different values", width)
+		}
+	}
+}
+
+func TestEncode_Error(t *testing.T) {
+	b := new(bytes.Buffer)
+	if err := rle.Encode(b, 33, nil); err == nil {
+		t.Fatalf("Encode(33) must be error")
+	}
+	if err := rle.Encode(b, 3, []uint32{1, 8}); !errors.Is(err, bitio.ErrValueOverflow) {
+		t.Fatalf("Encode(3, 8) error %v, want ErrValueOverflow", err)
+	}
+}
+
+func TestDecode_Error(t *testing.T) {
+	tests := []struct {
+		width int
+		src   []byte
+		n     int
+		exp   error
+	}{
+		{3, []byte{}, 1, io.ErrUnexpectedEOF},
+		{3, []byte{0x03, 0x88, 0xc6, 0xfa}, 9, io.ErrUnexpectedEOF},
+		{3, []byte{0x03, 0x88}, 8, io.ErrUnexpectedEOF},
+		{12, []byte{0x14, 0xbc}, 10, io.ErrUnexpectedEOF},
+		{3, []byte{0x94}, 10, io.ErrUnexpectedEOF},
+		{3, []byte{0x00}, 1, rle.ErrCorrupted},
+		{3, []byte{0x14, 0x08}, 10, rle.ErrCorrupted},
+		{3, []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, 1, rle.ErrCorrupted},
+		{3, bytes.Repeat([]byte{0xff}, 11), 1, rle.ErrCorrupted},
+	}
+
+	for _, tt := range tests {
+		dst := make([]uint32, tt.n)
+		if err := rle.Decode(bytes.NewReader(tt.src), tt.width, dst); !errors.Is(err, tt.exp) {
+			t.Fatalf("Decode(%d, %x) error %v, want %v", tt.width, tt.src, err, tt.exp)
+		}
+	}
+
+	if _, err := rle.NewDecoder(bytes.NewReader(nil), -1); err == nil {
+		t.Fatalf("NewDecoder(-1) must be error")
+	}
+}
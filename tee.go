@@ -0,0 +1,259 @@
+package bitio
+
+import "fmt"
+
+// NewTeeBitReader returns TeeBitReader.
+// Reading is started.
+func NewTeeBitReader(r BitReader, sinks ...BitWriter) *TeeBitReader {
+	return &TeeBitReader{
+		r:       r,
+		sinks:   sinks,
+		started: true,
+	}
+}
+
+// TeeBitReader reads from r and writes the read bits to sinks while started.
+// Sink is BitWriter of any bit-level consumer (BitBuffer, crc.Digest, BitWriteBuffer of hash, ...).
+type TeeBitReader struct {
+	r       BitReader
+	sinks   []BitWriter
+	started bool
+}
+
+// Start starts writing of read bits to sinks.
+func (obj *TeeBitReader) Start() {
+	obj.started = true
+}
+
+// Stop stops writing of read bits to sinks.
+func (obj *TeeBitReader) Stop() {
+	obj.started = false
+}
+
+// ReadBit reads single data (bitSize) and returns read size.
+// If error happen, err will be set.
+// Write error of sinks is returned instead of error of r.
+func (obj *TeeBitReader) ReadBit(p *byte, bitSize int) (nBit int, err error) {
+	if nBit, err = obj.r.ReadBit(p, bitSize); nBit != bitSize || !obj.started {
+		return
+	}
+	for _, w := range obj.sinks {
+		if _, werr := w.WriteBit(*p, nBit); werr != nil {
+			return nBit, werr
+		}
+	}
+	return
+}
+
+// ReadBits reads data (bitSize) and returns read size.
+// If error happen, err will be set.
+// Write error of sinks is returned instead of error of r.
+func (obj *TeeBitReader) ReadBits(p []byte, bitSize int) (nBit int, err error) {
+	if nBit, err = obj.r.ReadBits(p, bitSize); nBit != bitSize || !obj.started {
+		return
+	}
+	for _, w := range obj.sinks {
+		if _, werr := w.WriteBits(p, nBit); werr != nil {
+			return nBit, werr
+		}
+	}
+	return
+}
+
+// Read reads data len(p) size and returns read size.
+// If error happen, err will be set.
+// Write error of sinks is returned instead of error of r.
+func (obj *TeeBitReader) Read(p []byte) (nByte int, err error) {
+	if nByte, err = obj.r.Read(p); nByte == 0 || !obj.started {
+		return
+	}
+	for _, w := range obj.sinks {
+		if _, werr := w.Write(p[:nByte]); werr != nil {
+			return nByte, werr
+		}
+	}
+	return
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// NewMultiBitWriter returns MultiBitWriter.
+// Writing to sinks is started.
+func NewMultiBitWriter(w BitWriter, sinks ...BitWriter) *MultiBitWriter {
+	return &MultiBitWriter{
+		w:       w,
+		sinks:   sinks,
+		started: true,
+	}
+}
+
+// MultiBitWriter writes to w, and also to sinks while started.
+type MultiBitWriter struct {
+	w       BitWriter
+	sinks   []BitWriter
+	started bool
+}
+
+// Start starts writing to sinks.
+func (obj *MultiBitWriter) Start() {
+	obj.started = true
+}
+
+// Stop stops writing to sinks. Data is written to w only.
+func (obj *MultiBitWriter) Stop() {
+	obj.started = false
+}
+
+// writers returns writers of current state.
+func (obj *MultiBitWriter) writers() []BitWriter {
+	if !obj.started {
+		return nil
+	}
+	return obj.sinks
+}
+
+// WriteBit writes single data (bitSize) and returns write size.
+// If error happen, err will be set.
+func (obj *MultiBitWriter) WriteBit(p byte, bitSize int) (nBit int, err error) {
+	if nBit, err = obj.w.WriteBit(p, bitSize); err != nil {
+		return
+	}
+	for _, w := range obj.writers() {
+		if _, err = w.WriteBit(p, nBit); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// WriteBits writes data (bitSize) and returns write size.
+// If error happen, err will be set.
+func (obj *MultiBitWriter) WriteBits(p []byte, bitSize int) (nBit int, err error) {
+	if nBit, err = obj.w.WriteBits(p, bitSize); err != nil {
+		return
+	}
+	for _, w := range obj.writers() {
+		if _, err = w.WriteBits(p, nBit); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Write writes data len(p) size and returns write size.
+// If error happen, err will be set.
+func (obj *MultiBitWriter) Write(p []byte) (nByte int, err error) {
+	if nByte, err = obj.w.Write(p); err != nil {
+		return
+	}
+	for _, w := range obj.writers() {
+		if _, err = w.Write(p[:nByte]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Flush flushes w and all sinks.
+// If error happen, err will be set.
+func (obj *MultiBitWriter) Flush() error {
+	if err := obj.w.Flush(); err != nil {
+		return err
+	}
+	for _, w := range obj.sinks {
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// BitBuffer is in-memory BitWriter, which stores bits packed MSB first.
+// The zero value is empty buffer.
+type BitBuffer struct {
+	buf []byte
+	n   int
+}
+
+// Len returns bit size of buffer.
+func (obj *BitBuffer) Len() int {
+	return obj.n
+}
+
+// Bytes returns bytes of buffer. The last byte is padded by 0.
+func (obj *BitBuffer) Bytes() []byte {
+	return obj.buf
+}
+
+// Reset resets buffer to be empty.
+func (obj *BitBuffer) Reset() {
+	obj.buf = obj.buf[:0]
+	obj.n = 0
+}
+
+// String returns hex string of bytes and bit size. (ex: "a5c0 (10 bits)")
+func (obj *BitBuffer) String() string {
+	return fmt.Sprintf("%x (%d bits)", obj.buf, obj.n)
+}
+
+// WriteBit writes single data (bitSize) and returns write size.
+// Input data is stored right justified. (4bit = 0x0f)
+// Return error if bitSize is out of range [0, 8].
+func (obj *BitBuffer) WriteBit(p byte, bitSize int) (nBit int, err error) {
+	if bitSize < 0 || bitSize > 8 {
+		return 0, fmt.Errorf("bitio: WriteBit requires write size <= 8")
+	}
+	if bitSize == 0 {
+		return 0, nil
+	}
+
+	p <<= uint(8 - bitSize)
+	if off := obj.n % 8; off == 0 {
+		obj.buf = append(obj.buf, p)
+	} else {
+		obj.buf[len(obj.buf)-1] |= p >> uint(off)
+		if off+bitSize > 8 {
+			obj.buf = append(obj.buf, p<<uint(8-off))
+		}
+	}
+	obj.n += bitSize
+	return bitSize, nil
+}
+
+// WriteBits writes data (bitSize) and returns write size.
+// Input data is stored right justified. (12bit = 0x0f 0xff)
+// Return error if p is shorter than bitSize.
+func (obj *BitBuffer) WriteBits(p []byte, bitSize int) (nBit int, err error) {
+	if bitSize < 0 || len(p)*8 < bitSize {
+		return 0, fmt.Errorf("bitio: argument p[] is %d bits, want %d bits", len(p)*8, bitSize)
+	}
+
+	p = p[len(p)-(bitSize+7)/8:]
+	if n := bitSize % 8; n > 0 {
+		obj.WriteBit(p[0], n)
+		p = p[1:]
+	}
+	obj.Write(p)
+	return bitSize, nil
+}
+
+// Write writes data len(p) size and returns write size.
+// It never returns error.
+func (obj *BitBuffer) Write(p []byte) (nByte int, err error) {
+	if obj.n%8 == 0 {
+		obj.buf = append(obj.buf, p...)
+		obj.n += 8 * len(p)
+		return len(p), nil
+	}
+	for _, b := range p {
+		obj.WriteBit(b, 8)
+	}
+	return len(p), nil
+}
+
+// Flush does nothing.
+func (obj *BitBuffer) Flush() error {
+	return nil
+}
@@ -0,0 +1,187 @@
+package bitio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/crc"
+)
+
+func TestBitBuffer(t *testing.T) {
+	b := &bitio.BitBuffer{}
+	b.WriteBit(0x5, 3)                 // 101
+	b.WriteBits([]byte{0x3, 0xff}, 10) // 11_11111111
+	b.Write([]byte{0x0f})              // 00001111
+	b.WriteBit(0x1, 1)                 // 1
+
+	exp := binaryToByteArray("10111111_11111000_01111100")
+	if b.Len() != 22 || !bytes.Equal(b.Bytes(), exp) {
+		t.Fatalf("BitBuffer has %s, want %x (22 bits)", b, exp)
+	}
+	if s := b.String(); s != "bff87c (22 bits)" {
+		t.Fatalf("String returns %q", s)
+	}
+
+	b.Reset()
+	b.Write([]byte{0x12, 0x34})
+	if b.Len() != 16 || !bytes.Equal(b.Bytes(), []byte{0x12, 0x34}) {
+		t.Fatalf("BitBuffer has %s after Reset, want 1234 (16 bits)", b)
+	}
+
+	if _, err := b.WriteBit(0, 9); err == nil {
+		t.Fatalf("WriteBit(9) must be error")
+	}
+	if _, err := b.WriteBits([]byte{0}, 9); err == nil {
+		t.Fatalf("WriteBits(9) of 1 byte must be error")
+	}
+}
+
+func TestTeeBitReader(t *testing.T) {
+	data := binaryToByteArray("101_10110_111100001111_0101_10100101")
+
+	sink := &bitio.BitBuffer{}
+	r := bitio.NewTeeBitReader(bitio.NewBitReadBuffer(bytes.NewReader(data)), sink)
+
+	var b byte
+	r.ReadBit(&b, 3)
+	r.Stop()
+	r.ReadBit(&b, 5)
+	r.Start()
+	r.ReadBits(make([]byte, 2), 12)
+	r.Stop()
+	r.ReadBit(&b, 4)
+	r.Start()
+	r.Read(make([]byte, 1))
+
+	exp := binaryToByteArray("101_111100001111_10100101")
+	if sink.Len() != 23 || !bytes.Equal(sink.Bytes(), exp) {
+		t.Fatalf("TeeBitReader writes %s, want %x (23 bits)", sink, exp)
+	}
+}
+
+func TestTeeBitReader_Struct(t *testing.T) {
+	v := &varintRecord{Flag: 1, Size: 624485, Offset: -123456, Delta: -64, Time: 0x2000, Count: 2, Values: []int32{1, -1}}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitFieldWriter(b)
+	n, _ := w.WriteStruct(v)
+	w.Flush()
+	data := append(b.Bytes(), 0xff) // trailing data
+
+	// raw bits and CRC of struct (bits are in stream order)
+	raw := &bitio.BitBuffer{}
+	sum, _ := crc.New(crc.CRC32MPEG2)
+	r := bitio.NewTeeBitReader(bitio.NewBitReadBuffer(bytes.NewReader(data)), raw, sum)
+
+	got := &varintRecord{}
+	if _, err := bitio.NewBitFieldReader2(r).ReadStruct(got); err != nil {
+		t.Fatalf("ReadStruct error: %v", err)
+	}
+	if !reflect.DeepEqual(got, v) {
+		t.Fatalf("ReadStruct read %+v, want %+v", got, v)
+	}
+	if raw.Len() != n || !bytes.Equal(raw.Bytes(), data[:len(data)-1]) {
+		t.Fatalf("TeeBitReader writes %s, want %x (%d bits)", raw, data[:len(data)-1], n)
+	}
+
+	exp, _ := crc.New(crc.CRC32MPEG2)
+	exp.Write(data[:n/8])
+	exp.WriteBit(data[n/8]>>uint(8-n%8), n%8)
+	if sum.Sum64() != exp.Sum64() {
+		t.Fatalf("TeeBitReader CRC is %#x, want %#x", sum.Sum64(), exp.Sum64())
+	}
+}
+
+// errBitWriter is BitWriter which always fails.
+type errBitWriter struct {
+	bitio.BitBuffer
+}
+
+var errSink = errors.New("sink error")
+
+func (w *errBitWriter) WriteBit(p byte, bitSize int) (int, error) {
+	return 0, errSink
+}
+
+func TestTeeBitReader_Error(t *testing.T) {
+	r := bitio.NewTeeBitReader(bitio.NewBitReadBuffer(bytes.NewReader([]byte{0xff})), &errBitWriter{})
+
+	var b byte
+	if _, err := r.ReadBit(&b, 1); err != errSink {
+		t.Fatalf("ReadBit error %v, want %v", err, errSink)
+	}
+
+	// stopped sink is not written
+	r.Stop()
+	if _, err := r.ReadBit(&b, 1); err != nil {
+		t.Fatalf("ReadBit error %v", err)
+	}
+}
+
+// eofBitReader is BitReader which returns io.EOF with the last data.
+type eofBitReader struct {
+	bitio.BitReader
+}
+
+func (r eofBitReader) Read(p []byte) (int, error) {
+	n, _ := r.BitReader.Read(p)
+	return n, io.EOF
+}
+
+func TestTeeBitReader_EOF(t *testing.T) {
+	sink := &bitio.BitBuffer{}
+	r := bitio.NewTeeBitReader(eofBitReader{bitio.NewBitReadBuffer(bytes.NewReader([]byte{0x12, 0x34}))}, sink)
+
+	p := make([]byte, 2)
+	if n, err := r.Read(p); n != 2 || err != io.EOF {
+		t.Fatalf("Read returns (%d, %v), want (2, %v)", n, err, io.EOF)
+	}
+	if !bytes.Equal(sink.Bytes(), []byte{0x12, 0x34}) {
+		t.Fatalf("TeeBitReader writes %s, want 1234 (16 bits)", sink)
+	}
+}
+
+func TestMultiBitWriter(t *testing.T) {
+	out := new(bytes.Buffer)
+	sink := &bitio.BitBuffer{}
+	sum, _ := crc.New(crc.CRC32MPEG2)
+	w := bitio.NewMultiBitWriter(bitio.NewBitWriteBuffer(out), sink, sum)
+
+	w.WriteBit(0x5, 3)
+	w.Stop()
+	w.WriteBit(0x1f, 5)
+	w.Start()
+	w.WriteBits([]byte{0x0a, 0xbc}, 12)
+	w.Write([]byte{0xde})
+	w.Stop()
+	w.WriteBit(0x0, 4)
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+
+	if exp := binaryToByteArray("101_11111_101010111100_11011110_0000"); !bytes.Equal(out.Bytes(), exp) {
+		t.Fatalf("MultiBitWriter writes %x, want %x", out.Bytes(), exp)
+	}
+	exp := binaryToByteArray("101_101010111100_11011110")
+	if sink.Len() != 23 || !bytes.Equal(sink.Bytes(), exp) {
+		t.Fatalf("MultiBitWriter writes %s to sink, want %x (23 bits)", sink, exp)
+	}
+
+	d, _ := crc.New(crc.CRC32MPEG2)
+	d.WriteBits([]byte{0x5}, 3)
+	d.WriteBits([]byte{0x0a, 0xbc}, 12)
+	d.Write([]byte{0xde})
+	if sum.Sum64() != d.Sum64() {
+		t.Fatalf("MultiBitWriter CRC is %#x, want %#x", sum.Sum64(), d.Sum64())
+	}
+
+	// sink error
+	w = bitio.NewMultiBitWriter(bitio.NewBitWriteBuffer(out), &errBitWriter{})
+	if _, err := w.WriteBit(1, 1); err != errSink {
+		t.Fatalf("WriteBit error %v, want %v", err, errSink)
+	}
+}
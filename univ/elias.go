@@ -0,0 +1,134 @@
+package univ
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/hidez8891/bitio"
+)
+
+// gamma is Elias gamma code.
+// N is written as floor(log2(N)) zeros and N in binary.
+type gamma struct{}
+
+func (gamma) Read(r bitio.BitReader) (uint64, error) {
+	// gamma(N) = ue(N-1)
+	v, err := bitio.ReadUE(r)
+	if err != nil {
+		return 0, err
+	}
+	return v + 1, nil
+}
+
+func (gamma) Write(w bitio.BitWriter, v uint64) error {
+	if v == 0 {
+		return fmt.Errorf("gamma code: %w", ErrZeroValue)
+	}
+	return bitio.WriteUE(w, v-1)
+}
+
+func (gamma) Len(v uint64) int {
+	return 2*bits.Len64(v) - 1
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// delta is Elias delta code.
+// N is written as gamma code of bit length of N and N in binary without leading 1.
+type delta struct{}
+
+func (delta) Read(r bitio.BitReader) (uint64, error) {
+	l, err := Gamma.Read(r)
+	if err != nil {
+		return 0, err
+	}
+	if l > 64 {
+		return 0, fmt.Errorf("univ: delta code length %d exceeds 64 bit: %w", l, bitio.ErrValueOverflow)
+	}
+
+	low, err := readBits(r, int(l)-1)
+	if err != nil {
+		return 0, err
+	}
+	return 1<<(l-1) | low, nil
+}
+
+func (delta) Write(w bitio.BitWriter, v uint64) error {
+	if v == 0 {
+		return fmt.Errorf("delta code: %w", ErrZeroValue)
+	}
+
+	l := bits.Len64(v)
+	if err := Gamma.Write(w, uint64(l)); err != nil {
+		return err
+	}
+	if l > 1 {
+		return bitio.Write(w, l-1, bitio.BigEndian, v&(1<<(l-1)-1))
+	}
+	return nil
+}
+
+func (delta) Len(v uint64) int {
+	l := bits.Len64(v)
+	return Gamma.Len(uint64(l)) + l - 1
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// omega is Elias omega code.
+// N is written as recursive groups of bit length and N in binary, terminated by 0.
+type omega struct{}
+
+func (omega) Read(r bitio.BitReader) (uint64, error) {
+	v := uint64(1)
+	for first := true; ; first = false {
+		bit, err := readBit(r)
+		if err != nil {
+			if !first {
+				err = unexpectedEOF(err)
+			}
+			return 0, err
+		}
+		if bit == 0 {
+			return v, nil
+		}
+
+		// group of v+1 bits, leading 1 is already read
+		if v > 63 {
+			return 0, fmt.Errorf("univ: omega code group %d bit exceeds 64 bit: %w", v+1, bitio.ErrValueOverflow)
+		}
+		low, err := readBits(r, int(v))
+		if err != nil {
+			return 0, err
+		}
+		v = 1<<v | low
+	}
+}
+
+func (omega) Write(w bitio.BitWriter, v uint64) error {
+	if v == 0 {
+		return fmt.Errorf("omega code: %w", ErrZeroValue)
+	}
+
+	// groups are written in reverse order
+	var groups [8]uint64
+	n := 0
+	for ; v > 1; v = uint64(bits.Len64(v) - 1) {
+		groups[n] = v
+		n++
+	}
+	for i := n - 1; i >= 0; i-- {
+		if err := bitio.Write(w, bits.Len64(groups[i]), bitio.BigEndian, groups[i]); err != nil {
+			return err
+		}
+	}
+	return bitio.Write(w, 1, bitio.BigEndian, uint8(0))
+}
+
+func (omega) Len(v uint64) int {
+	n := 1
+	for ; v > 1; v = uint64(bits.Len64(v) - 1) {
+		n += bits.Len64(v)
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package univ_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/univ"
+)
+
+func TestGamma(t *testing.T) {
+	testCode(t, "gamma", univ.Gamma, []codeTest{
+		{1, "1"},
+		{2, "010"},
+		{3, "011"},
+		{4, "00100"},
+		{17, "000010001"},
+		{1<<64 - 1, strings.Repeat("0", 63) + strings.Repeat("1", 64)},
+	})
+}
+
+func TestDelta(t *testing.T) {
+	testCode(t, "delta", univ.Delta, []codeTest{
+		{1, "1"},
+		{2, "0100"},
+		{3, "0101"},
+		{4, "01100"},
+		{10, "00100010"},
+		{17, "001010001"},
+		{1<<64 - 1, "000000_1000000_" + strings.Repeat("1", 63)},
+	})
+}
+
+func TestOmega(t *testing.T) {
+	testCode(t, "omega", univ.Omega, []codeTest{
+		{1, "0"},
+		{2, "100"},
+		{3, "110"},
+		{4, "101000"},
+		{7, "101110"},
+		{8, "1110000"},
+		{16, "10100100000"},
+		{100, "10_110_1100100_0"},
+		{1<<64 - 1, "10_101_111111_" + strings.Repeat("1", 64) + "0"},
+	})
+}
+
+func TestElias_Overflow(t *testing.T) {
+	tests := []struct {
+		name string
+		c    univ.Code
+		raw  string
+	}{
+		{"gamma", univ.Gamma, strings.Repeat("0", 64) + "1" + strings.Repeat("0", 64)},
+		{"delta", univ.Delta, "000000_1000001_" + strings.Repeat("0", 64)},
+		{"omega", univ.Omega, "10_110_1000000_1" + strings.Repeat("0", 64) + "0"},
+	}
+
+	for _, tt := range tests {
+		r := bitio.NewBitReadBuffer(bytes.NewReader(binaryToByteArray(tt.raw)))
+		if _, err := tt.c.Read(r); errors.Is(err, bitio.ErrValueOverflow) == false {
+			t.Fatalf("%s read error %v, want %v", tt.name, err, bitio.ErrValueOverflow)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package univ
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/hidez8891/bitio"
+)
+
+// fibs is Fibonacci numbers F(2)..F(93), which are not greater than max uint64.
+var fibs = func() []uint64 {
+	f := []uint64{1, 2}
+	for {
+		a, b := f[len(f)-2], f[len(f)-1]
+		c, carry := bits.Add64(a, b, 0)
+		if carry != 0 {
+			return f
+		}
+		f = append(f, c)
+	}
+}()
+
+// fibonacci is Fibonacci code.
+// N is written as Zeckendorf representation from F(2), terminated by 1. (11 is the end of code)
+type fibonacci struct{}
+
+func (fibonacci) Read(r bitio.BitReader) (uint64, error) {
+	var v uint64
+	var prev byte
+	for i := 0; ; i++ {
+		bit, err := readBit(r)
+		if err != nil {
+			if i > 0 {
+				err = unexpectedEOF(err)
+			}
+			return 0, err
+		}
+		if bit == 1 && prev == 1 {
+			return v, nil
+		}
+		prev = bit
+
+		if bit == 0 {
+			continue
+		}
+		if i >= len(fibs) {
+			return 0, fmt.Errorf("univ: fibonacci code exceeds 64 bit value: %w", bitio.ErrValueOverflow)
+		}
+		var carry uint64
+		if v, carry = bits.Add64(v, fibs[i], 0); carry != 0 {
+			return 0, fmt.Errorf("univ: fibonacci code exceeds 64 bit value: %w", bitio.ErrValueOverflow)
+		}
+	}
+}
+
+func (fibonacci) Write(w bitio.BitWriter, v uint64) error {
+	if v == 0 {
+		return fmt.Errorf("fibonacci code: %w", ErrZeroValue)
+	}
+
+	// Zeckendorf representation (greedy from the largest)
+	k := fibIndex(v)
+	var code [2]uint64 // code bits, code[0] bit 63 is F(2)
+	for i := k; i >= 0; i-- {
+		if fibs[i] <= v {
+			v -= fibs[i]
+			code[i/64] |= 1 << (63 - uint(i%64))
+		}
+	}
+	i := k + 1
+	code[i/64] |= 1 << (63 - uint(i%64)) // terminator
+
+	n := k + 2
+	if n > 64 {
+		if err := bitio.Write(w, 64, bitio.BigEndian, code[0]); err != nil {
+			return err
+		}
+		return bitio.Write(w, n-64, bitio.BigEndian, code[1]>>(128-uint(n)))
+	}
+	return bitio.Write(w, n, bitio.BigEndian, code[0]>>(64-uint(n)))
+}
+
+func (fibonacci) Len(v uint64) int {
+	return fibIndex(v) + 2
+}
+
+// fibIndex returns index of the largest Fibonacci number not greater than v.
+func fibIndex(v uint64) int {
+	k := 0
+	for k+1 < len(fibs) && fibs[k+1] <= v {
+		k++
+	}
+	return k
+}
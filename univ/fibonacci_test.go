@@ -0,0 +1,32 @@
+package univ_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/univ"
+)
+
+func TestFibonacci(t *testing.T) {
+	testCode(t, "fibonacci", univ.Fibonacci, []codeTest{
+		{1, "11"},
+		{2, "011"},
+		{3, "0011"},
+		{4, "1011"},
+		{11, "001011"},
+		{12, "101011"},
+		{65, "0100100011"},
+		{1<<64 - 1, "010100000101000101000001000101010001001000100100000000100100010010001000101000001000101001011"},
+	})
+}
+
+func TestFibonacci_Overflow(t *testing.T) {
+	// F(94) exceeds 64 bit
+	raw := binaryToByteArray("0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001011")
+	r := bitio.NewBitReadBuffer(bytes.NewReader(raw))
+	if _, err := univ.Fibonacci.Read(r); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("fibonacci read error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+}
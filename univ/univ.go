@@ -0,0 +1,157 @@
+// Package univ implements universal integer codes on bitio.BitReader and bitio.BitWriter.
+//
+// Supported codes are unary (0- and 1-terminated), Elias gamma, delta, omega and Fibonacci.
+package univ
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hidez8891/bitio"
+)
+
+// ErrZeroValue is returned when zero is written by code which encodes only positive integers.
+var ErrZeroValue = errors.New("univ: zero value is not encodable")
+
+// Code is the interface of universal integer code.
+type Code interface {
+	// Read reads a code and returns decoded value.
+	Read(r bitio.BitReader) (uint64, error)
+	// Write writes v as a code.
+	Write(w bitio.BitWriter, v uint64) error
+	// Len returns bit size of v as a code.
+	Len(v uint64) int
+}
+
+var (
+	// Unary0 is unary code of v ones terminated by 0. (0 = "0", 2 = "110")
+	Unary0 Code = unary{term: 0}
+	// Unary1 is unary code of v zeros terminated by 1. (0 = "1", 2 = "001")
+	Unary1 Code = unary{term: 1}
+	// Gamma is Elias gamma code of positive integer. (1 = "1", 2 = "010")
+	Gamma Code = gamma{}
+	// Delta is Elias delta code of positive integer. (1 = "1", 2 = "0100")
+	Delta Code = delta{}
+	// Omega is Elias omega code of positive integer. (1 = "0", 2 = "100")
+	Omega Code = omega{}
+	// Fibonacci is Fibonacci code of positive integer. (1 = "11", 2 = "011")
+	Fibonacci Code = fibonacci{}
+)
+
+// ReadSlice reads codes to dst.
+// Return error if element read failed.
+func ReadSlice(r bitio.BitReader, c Code, dst []uint64) error {
+	for i := range dst {
+		v, err := c.Read(r)
+		if err != nil {
+			if i > 0 {
+				err = unexpectedEOF(err)
+			}
+			return err
+		}
+		dst[i] = v
+	}
+	return nil
+}
+
+// WriteSlice writes src as codes.
+// Return error if element write failed.
+func WriteSlice(w bitio.BitWriter, c Code, src []uint64) error {
+	for _, v := range src {
+		if err := c.Write(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SliceLen returns bit size of src as codes.
+func SliceLen(c Code, src []uint64) int {
+	n := 0
+	for _, v := range src {
+		n += c.Len(v)
+	}
+	return n
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// unary is unary code terminated by term bit.
+type unary struct {
+	term byte
+}
+
+func (c unary) Read(r bitio.BitReader) (uint64, error) {
+	var v uint64
+	for {
+		bit, err := readBit(r)
+		if err != nil {
+			if v > 0 {
+				err = unexpectedEOF(err)
+			}
+			return 0, err
+		}
+		if bit == c.term {
+			return v, nil
+		}
+		if v++; v == 0 {
+			return 0, fmt.Errorf("univ: unary code exceeds 64 bit value: %w", bitio.ErrValueOverflow)
+		}
+	}
+}
+
+func (c unary) Write(w bitio.BitWriter, v uint64) error {
+	run := ^uint64(0)
+	if c.term == 1 {
+		run = 0
+	}
+
+	for ; v >= 64; v -= 64 {
+		if err := bitio.Write(w, 64, bitio.BigEndian, run); err != nil {
+			return err
+		}
+	}
+	last := (uint64(1)<<v - 1) << 1 // v ones and 0
+	if c.term == 1 {
+		last = 1 // v zeros and 1
+	}
+	return bitio.Write(w, int(v)+1, bitio.BigEndian, last)
+}
+
+func (c unary) Len(v uint64) int {
+	return int(v) + 1
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// readBit reads 1 bit.
+func readBit(r bitio.BitReader) (byte, error) {
+	var b byte
+	if n, err := r.ReadBit(&b, 1); err != nil {
+		return 0, err
+	} else if n != 1 {
+		return 0, fmt.Errorf("insufficient size of read, want 1 bit, read %d bit: %w", n, io.ErrUnexpectedEOF)
+	}
+	return b, nil
+}
+
+// readBits reads n bits (n <= 64) as big-endian value.
+func readBits(r bitio.BitReader, n int) (uint64, error) {
+	var v uint64
+	if n == 0 {
+		return 0, nil
+	}
+	if err := bitio.Read(r, n, bitio.BigEndian, &v); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return v, nil
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,214 @@
+package univ_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/univ"
+)
+
+type codeTest struct {
+	v   uint64
+	raw string
+}
+
+// testCode tests write, read and length of code.
+func testCode(t *testing.T, name string, c univ.Code, tests []codeTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		if err := c.Write(w, tt.v); err != nil {
+			t.Fatalf("%s write %d error: %v", name, tt.v, err)
+		}
+		w.Flush()
+
+		exp := binaryToByteArray(tt.raw)
+		if reflect.DeepEqual(b.Bytes(), exp) == false {
+			t.Fatalf("%s write %d = %#v, want %#v", name, tt.v, b.Bytes(), exp)
+		}
+		if n := c.Len(tt.v); n != len(strings.Replace(tt.raw, "_", "", -1)) {
+			t.Fatalf("%s length of %d = %d, want %d", name, tt.v, n, len(tt.raw))
+		}
+
+		r := bitio.NewBitReadBuffer(bytes.NewReader(exp))
+		if v, err := c.Read(r); err != nil || v != tt.v {
+			t.Fatalf("%s read %q = %d (%v), want %d", name, tt.raw, v, err, tt.v)
+		}
+	}
+}
+
+func TestUnary(t *testing.T) {
+	testCode(t, "unary0", univ.Unary0, []codeTest{
+		{0, "0"},
+		{1, "10"},
+		{2, "110"},
+		{9, "1111111110"},
+		{70, strings.Repeat("1", 70) + "0"},
+	})
+	testCode(t, "unary1", univ.Unary1, []codeTest{
+		{0, "1"},
+		{1, "01"},
+		{2, "001"},
+		{9, "0000000001"},
+		{70, strings.Repeat("0", 70) + "1"},
+	})
+
+	// range does not depend on terminator bit
+	testCode(t, "unary0", univ.Unary0, []codeTest{{70000, strings.Repeat("1", 70000) + "0"}})
+	testCode(t, "unary1", univ.Unary1, []codeTest{{70000, strings.Repeat("0", 70000) + "1"}})
+}
+
+func TestSlice(t *testing.T) {
+	codes := map[string]univ.Code{
+		"unary0":    univ.Unary0,
+		"unary1":    univ.Unary1,
+		"gamma":     univ.Gamma,
+		"delta":     univ.Delta,
+		"omega":     univ.Omega,
+		"fibonacci": univ.Fibonacci,
+	}
+	src := []uint64{1, 2, 3, 10, 100, 17, 1}
+
+	for name, c := range codes {
+		b := new(bytes.Buffer)
+		w := bitio.NewBitWriteBuffer(b)
+		if err := univ.WriteSlice(w, c, src); err != nil {
+			t.Fatalf("%s WriteSlice error: %v", name, err)
+		}
+		w.Flush()
+
+		if n := univ.SliceLen(c, src); (n+7)/8 != b.Len() {
+			t.Fatalf("%s SliceLen = %d, write %d bytes", name, n, b.Len())
+		}
+
+		dst := make([]uint64, len(src))
+		r := bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes()))
+		if err := univ.ReadSlice(r, c, dst); err != nil {
+			t.Fatalf("%s ReadSlice error: %v", name, err)
+		}
+		if reflect.DeepEqual(dst, src) == false {
+			t.Fatalf("%s ReadSlice = %v, want %v", name, dst, src)
+		}
+
+		// more elements than data
+		dst = make([]uint64, len(src)+8)
+		r = bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes()))
+		if err := univ.ReadSlice(r, c, dst); errors.Is(err, io.ErrUnexpectedEOF) == false {
+			t.Fatalf("%s ReadSlice error %v, want %v", name, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	codes := map[string]univ.Code{
+		"gamma":     univ.Gamma,
+		"delta":     univ.Delta,
+		"omega":     univ.Omega,
+		"fibonacci": univ.Fibonacci,
+	}
+
+	for name, c := range codes {
+		w := bitio.NewBitWriteBuffer(io.Discard)
+		if err := c.Write(w, 0); errors.Is(err, univ.ErrZeroValue) == false {
+			t.Fatalf("%s write 0 error %v, want %v", name, err, univ.ErrZeroValue)
+		}
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(uint64(1), uint64(2))
+	f.Add(uint64(1<<64-1), uint64(1<<63))
+	f.Add(uint64(0), uint64(12345))
+
+	f.Fuzz(func(t *testing.T, a, b uint64) {
+		codes := map[string]univ.Code{
+			"unary0":    univ.Unary0,
+			"unary1":    univ.Unary1,
+			"gamma":     univ.Gamma,
+			"delta":     univ.Delta,
+			"omega":     univ.Omega,
+			"fibonacci": univ.Fibonacci,
+		}
+
+		for name, c := range codes {
+			src := []uint64{a, b}
+			if name == "unary0" || name == "unary1" {
+				src = []uint64{a % 1024, b % 1024}
+			} else {
+				src = []uint64{a | 1, b | 1}
+			}
+
+			buf := new(bytes.Buffer)
+			w := bitio.NewBitWriteBuffer(buf)
+			if err := univ.WriteSlice(w, c, src); err != nil {
+				t.Fatalf("%s WriteSlice %v error: %v", name, src, err)
+			}
+			w.Flush()
+			if n := univ.SliceLen(c, src); (n+7)/8 != buf.Len() {
+				t.Fatalf("%s SliceLen %v = %d, write %d bytes", name, src, n, buf.Len())
+			}
+
+			dst := make([]uint64, len(src))
+			if err := univ.ReadSlice(bitio.NewBitReadBuffer(buf), c, dst); err != nil {
+				t.Fatalf("%s ReadSlice %v error: %v", name, src, err)
+			}
+			if reflect.DeepEqual(dst, src) == false {
+				t.Fatalf("%s ReadSlice = %v, want %v", name, dst, src)
+			}
+		}
+	})
+}
+
+func FuzzRead(f *testing.F) {
+	f.Add([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xff})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe})
+	f.Add([]byte{0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x57})
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		for _, c := range []univ.Code{univ.Unary0, univ.Unary1, univ.Gamma, univ.Delta, univ.Omega, univ.Fibonacci} {
+			r := bitio.NewBitReadBuffer(bytes.NewReader(raw))
+			v, err := c.Read(r)
+			if err != nil {
+				continue
+			}
+
+			// decoded value is encodable
+			buf := new(bytes.Buffer)
+			w := bitio.NewBitWriteBuffer(buf)
+			if err = c.Write(w, v); err != nil {
+				t.Fatalf("%T write decoded value %d error: %v", c, v, err)
+			}
+			w.Flush()
+			if u, err := c.Read(bitio.NewBitReadBuffer(buf)); err != nil || u != v {
+				t.Fatalf("%T read %d (%v), want %d", c, u, err, v)
+			}
+		}
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func binaryToByteArray(str string) []byte {
+	str = strings.Replace(str, "_", "", -1)
+
+	if len(str)%8 != 0 {
+		str += strings.Repeat("0", 8-len(str)%8)
+	}
+
+	b := make([]byte, len(str)/8)
+	for i := 0; i < len(b); i++ {
+		t, _ := strconv.ParseInt(str[:8], 2, 0)
+		b[i] = byte(t)
+		str = str[8:]
+	}
+
+	return b
+}
@@ -0,0 +1,298 @@
+package bitio
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+)
+
+// maxVarintBytes is max byte size of 64 bit varint.
+const maxVarintBytes = 10
+
+// ReadULEB128 reads unsigned LEB128. (7 bit groups from the least significant, continuation bit 0x80)
+func ReadULEB128(r BitReader) (uint64, error) {
+	_, v, err := readULEB128(r)
+	return v, err
+}
+
+// WriteULEB128 writes v as unsigned LEB128.
+func WriteULEB128(w BitWriter, v uint64) error {
+	_, err := writeULEB128(w, v)
+	return err
+}
+
+// ReadSLEB128 reads signed LEB128. (sign is extended from bit 0x40 of the last byte)
+func ReadSLEB128(r BitReader) (int64, error) {
+	_, v, err := readSLEB128(r)
+	return v, err
+}
+
+// WriteSLEB128 writes v as signed LEB128.
+func WriteSLEB128(w BitWriter, v int64) error {
+	_, err := writeSLEB128(w, v)
+	return err
+}
+
+// ReadZigzag reads zigzag varint of protobuf (sint64).
+// Signed value is folded to unsigned value (0, -1, 1, -2, ...) -> (0, 1, 2, 3, ...) and written as unsigned LEB128.
+func ReadZigzag(r BitReader) (int64, error) {
+	_, u, err := readULEB128(r)
+	if err != nil {
+		return 0, err
+	}
+	return unfoldSigned(u), nil
+}
+
+// WriteZigzag writes v as zigzag varint of protobuf (sint64).
+func WriteZigzag(w BitWriter, v int64) error {
+	_, err := writeULEB128(w, foldSigned(v))
+	return err
+}
+
+// ReadVLQ reads big-endian VLQ of MIDI. (7 bit groups from the most significant, continuation bit 0x80)
+func ReadVLQ(r BitReader) (uint64, error) {
+	_, v, err := readVLQ(r)
+	return v, err
+}
+
+// WriteVLQ writes v as big-endian VLQ of MIDI.
+func WriteVLQ(w BitWriter, v uint64) error {
+	_, err := writeVLQ(w, v)
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// readVarintByte reads i-th byte of varint.
+// EOF of the first byte is returned as is, and the others are unexpected.
+func readVarintByte(r BitReader, i int) (byte, error) {
+	var b byte
+	n, err := r.ReadBit(&b, 8)
+	if err != nil {
+		if i > 0 || n > 0 {
+			err = unexpectedEOF(err)
+		}
+		return 0, err
+	}
+	if n != 8 {
+		return 0, fmt.Errorf("insufficient size of read, want 8 bit, read %d bit: %w", n, ErrUnexpectedEOF)
+	}
+	return b, nil
+}
+
+// readULEB128 reads unsigned LEB128 and returns read size and value.
+func readULEB128(r BitReader) (int, uint64, error) {
+	var v uint64
+	for i := 0; i < maxVarintBytes; i++ {
+		b, err := readVarintByte(r, i)
+		if err != nil {
+			return 0, 0, err
+		}
+		if i == maxVarintBytes-1 && b&0x7f > 1 {
+			return 0, 0, fmt.Errorf("LEB128 exceeds 64 bit: %w", ErrValueOverflow)
+		}
+		v |= uint64(b&0x7f) << uint(7*i)
+		if b&0x80 == 0 {
+			return 8 * (i + 1), v, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("LEB128 exceeds %d bytes: %w", maxVarintBytes, ErrValueOverflow)
+}
+
+// writeULEB128 writes v as unsigned LEB128 and returns write size.
+func writeULEB128(w BitWriter, v uint64) (int, error) {
+	n := 0
+	for {
+		b := byte(v & 0x7f)
+		if v >>= 7; v != 0 {
+			b |= 0x80
+		}
+		if err := writeVarintByte(w, b); err != nil {
+			return 0, err
+		}
+		if n += 8; v == 0 {
+			return n, nil
+		}
+	}
+}
+
+// uleb128Len returns bit size of v as unsigned LEB128.
+func uleb128Len(v uint64) int {
+	return 8 * max(1, (bits.Len64(v)+6)/7)
+}
+
+// readSLEB128 reads signed LEB128 and returns read size and value.
+func readSLEB128(r BitReader) (int, int64, error) {
+	var v uint64
+	for i := 0; i < maxVarintBytes; i++ {
+		b, err := readVarintByte(r, i)
+		if err != nil {
+			return 0, 0, err
+		}
+		// the last byte has bit 63 and sign bits
+		if i == maxVarintBytes-1 && b&0x7f != 0 && b&0x7f != 0x7f {
+			return 0, 0, fmt.Errorf("LEB128 exceeds 64 bit: %w", ErrValueOverflow)
+		}
+		v |= uint64(b&0x7f) << uint(7*i)
+		if b&0x80 == 0 {
+			if shift := uint(7 * (i + 1)); shift < 64 && b&0x40 != 0 {
+				v |= ^uint64(0) << shift
+			}
+			return 8 * (i + 1), int64(v), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("LEB128 exceeds %d bytes: %w", maxVarintBytes, ErrValueOverflow)
+}
+
+// writeSLEB128 writes v as signed LEB128 and returns write size.
+func writeSLEB128(w BitWriter, v int64) (int, error) {
+	n := 0
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		done := (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0)
+		if !done {
+			b |= 0x80
+		}
+		if err := writeVarintByte(w, b); err != nil {
+			return 0, err
+		}
+		if n += 8; done {
+			return n, nil
+		}
+	}
+}
+
+// sleb128Len returns bit size of v as signed LEB128.
+func sleb128Len(v int64) int {
+	// bits of value and sign
+	n := bits.Len64(uint64(v^(v>>63))) + 1
+	return 8 * ((n + 6) / 7)
+}
+
+// readVLQ reads big-endian VLQ and returns read size and value.
+func readVLQ(r BitReader) (int, uint64, error) {
+	var v uint64
+	for i := 0; i < maxVarintBytes; i++ {
+		b, err := readVarintByte(r, i)
+		if err != nil {
+			return 0, 0, err
+		}
+		if v>>57 != 0 {
+			return 0, 0, fmt.Errorf("VLQ exceeds 64 bit: %w", ErrValueOverflow)
+		}
+		v = v<<7 | uint64(b&0x7f)
+		if b&0x80 == 0 {
+			return 8 * (i + 1), v, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("VLQ exceeds %d bytes: %w", maxVarintBytes, ErrValueOverflow)
+}
+
+// writeVLQ writes v as big-endian VLQ and returns write size.
+func writeVLQ(w BitWriter, v uint64) (int, error) {
+	n := uleb128Len(v) / 8
+	for i := n - 1; i >= 0; i-- {
+		b := byte(v>>uint(7*i)) & 0x7f
+		if i > 0 {
+			b |= 0x80
+		}
+		if err := writeVarintByte(w, b); err != nil {
+			return 0, err
+		}
+	}
+	return 8 * n, nil
+}
+
+// writeVarintByte writes a byte of varint.
+func writeVarintByte(w BitWriter, b byte) error {
+	if n, err := w.WriteBit(b, 8); err != nil {
+		return err
+	} else if n != 8 {
+		return fmt.Errorf("insufficient size of write, want 8 bit, write %d bit", n)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// varintEncoding is valueEncoding of `encoding:"uleb128"`, `encoding:"sleb128"`,
+// `encoding:"zigzag"` and `encoding:"vlq"`.
+type varintEncoding struct {
+	name   string
+	signed bool // field is signed integer
+}
+
+func newVarintEncoding(ctx *encodingContext) (valueEncoding, error) {
+	if ctx.param != "" {
+		return nil, fmt.Errorf("unexpected parameter %q", ctx.param)
+	}
+	return &varintEncoding{name: ctx.name, signed: ctx.signed}, nil
+}
+
+// signedCode reports whether code is signed.
+func (enc *varintEncoding) signedCode() bool {
+	return enc.name == "sleb128" || enc.name == "zigzag"
+}
+
+func (enc *varintEncoding) read(r BitReader, rv reflect.Value, buf []byte) (uint64, int, error) {
+	var n int
+	var v uint64
+	var err error
+	switch enc.name {
+	case "uleb128":
+		n, v, err = readULEB128(r)
+	case "sleb128":
+		var s int64
+		n, s, err = readSLEB128(r)
+		v = uint64(s)
+	case "zigzag":
+		n, v, err = readULEB128(r)
+		v = uint64(unfoldSigned(v))
+	default:
+		n, v, err = readVLQ(r)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// value must be in range of field sign
+	if enc.signedCode() != enc.signed && int64(v) < 0 {
+		if enc.signed {
+			return 0, 0, fmt.Errorf("%s value %d exceeds int64: %w", enc.name, v, ErrValueOverflow)
+		}
+		return 0, 0, fmt.Errorf("%s value %d is negative: %w", enc.name, int64(v), ErrValueOverflow)
+	}
+	return v, n, nil
+}
+
+func (enc *varintEncoding) write(w BitWriter, rv reflect.Value, v uint64, buf []byte) (int, error) {
+	if enc.signedCode() != enc.signed && int64(v) < 0 {
+		if enc.signed {
+			return 0, fmt.Errorf("negative value %d: %w", int64(v), ErrValueOverflow)
+		}
+		return 0, fmt.Errorf("value %d exceeds int64: %w", v, ErrValueOverflow)
+	}
+
+	switch enc.name {
+	case "uleb128":
+		return writeULEB128(w, v)
+	case "sleb128":
+		return writeSLEB128(w, int64(v))
+	case "zigzag":
+		return writeULEB128(w, foldSigned(int64(v)))
+	default:
+		return writeVLQ(w, v)
+	}
+}
+
+func (enc *varintEncoding) bitLen(rv reflect.Value, v uint64) int {
+	switch enc.name {
+	case "sleb128":
+		return sleb128Len(int64(v))
+	case "zigzag":
+		return uleb128Len(foldSigned(int64(v)))
+	default:
+		return uleb128Len(v)
+	}
+}
@@ -0,0 +1,255 @@
+package bitio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+)
+
+var ulebTests = []struct {
+	v   uint64
+	raw string
+}{
+	{0, "00"},
+	{1, "01"},
+	{127, "7f"},
+	{128, "8001"},
+	{300, "ac02"},
+	{624485, "e58e26"},
+	{math.MaxUint64, "ffffffffffffffffff01"},
+}
+
+var slebTests = []struct {
+	v   int64
+	raw string
+}{
+	{0, "00"},
+	{2, "02"},
+	{-1, "7f"},
+	{63, "3f"},
+	{64, "c000"},
+	{-64, "40"},
+	{-65, "bf7f"},
+	{-123456, "c0bb78"},
+	{math.MaxInt64, "ffffffffffffffffff00"},
+	{math.MinInt64, "8080808080808080807f"},
+}
+
+var zigzagTests = []struct {
+	v   int64
+	raw string
+}{
+	{0, "00"},
+	{-1, "01"},
+	{1, "02"},
+	{-64, "7f"},
+	{64, "8001"},
+	{math.MaxInt64, "feffffffffffffffff01"},
+	{math.MinInt64, "ffffffffffffffffff01"},
+}
+
+var vlqTests = []struct {
+	v   uint64
+	raw string
+}{
+	// MIDI delta-time examples
+	{0, "00"},
+	{0x7f, "7f"},
+	{0x80, "8100"},
+	{0x2000, "c000"},
+	{0x3fff, "ff7f"},
+	{0x4000, "818000"},
+	{0x0fffffff, "ffffff7f"},
+	{math.MaxUint64, "81ffffffffffffffff7f"},
+}
+
+// writeHex returns hex string of bytes written by write.
+func writeHex(t *testing.T, write func(w bitio.BitWriter) error) string {
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	if err := write(w); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+	w.Flush()
+	return hex.EncodeToString(b.Bytes())
+}
+
+// hexReader returns BitReader of hex string.
+func hexReader(s string) bitio.BitReader {
+	raw, _ := hex.DecodeString(s)
+	return bitio.NewBitReadBuffer(bytes.NewReader(raw))
+}
+
+func TestVarint(t *testing.T) {
+	for _, tt := range ulebTests {
+		if got := writeHex(t, func(w bitio.BitWriter) error { return bitio.WriteULEB128(w, tt.v) }); got != tt.raw {
+			t.Fatalf("WriteULEB128(%d) writes %s, want %s", tt.v, got, tt.raw)
+		}
+		if v, err := bitio.ReadULEB128(hexReader(tt.raw)); err != nil || v != tt.v {
+			t.Fatalf("ReadULEB128(%s) returns %d, %v, want %d", tt.raw, v, err, tt.v)
+		}
+	}
+	for _, tt := range slebTests {
+		if got := writeHex(t, func(w bitio.BitWriter) error { return bitio.WriteSLEB128(w, tt.v) }); got != tt.raw {
+			t.Fatalf("WriteSLEB128(%d) writes %s, want %s", tt.v, got, tt.raw)
+		}
+		if v, err := bitio.ReadSLEB128(hexReader(tt.raw)); err != nil || v != tt.v {
+			t.Fatalf("ReadSLEB128(%s) returns %d, %v, want %d", tt.raw, v, err, tt.v)
+		}
+	}
+	for _, tt := range zigzagTests {
+		if got := writeHex(t, func(w bitio.BitWriter) error { return bitio.WriteZigzag(w, tt.v) }); got != tt.raw {
+			t.Fatalf("WriteZigzag(%d) writes %s, want %s", tt.v, got, tt.raw)
+		}
+		if v, err := bitio.ReadZigzag(hexReader(tt.raw)); err != nil || v != tt.v {
+			t.Fatalf("ReadZigzag(%s) returns %d, %v, want %d", tt.raw, v, err, tt.v)
+		}
+	}
+	for _, tt := range vlqTests {
+		if got := writeHex(t, func(w bitio.BitWriter) error { return bitio.WriteVLQ(w, tt.v) }); got != tt.raw {
+			t.Fatalf("WriteVLQ(%d) writes %s, want %s", tt.v, got, tt.raw)
+		}
+		if v, err := bitio.ReadVLQ(hexReader(tt.raw)); err != nil || v != tt.v {
+			t.Fatalf("ReadVLQ(%s) returns %d, %v, want %d", tt.raw, v, err, tt.v)
+		}
+	}
+
+	// overlong encoding of DWARF padding
+	if v, err := bitio.ReadULEB128(hexReader("8080808000")); err != nil || v != 0 {
+		t.Fatalf("ReadULEB128(8080808000) returns %d, %v, want 0", v, err)
+	}
+}
+
+func TestVarint_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		read func(r bitio.BitReader) error
+		raw  string
+		err  error
+	}{
+		{"uleb128 empty", func(r bitio.BitReader) error { _, err := bitio.ReadULEB128(r); return err }, "", io.EOF},
+		{"uleb128 truncated", func(r bitio.BitReader) error { _, err := bitio.ReadULEB128(r); return err }, "80", io.ErrUnexpectedEOF},
+		{"uleb128 65 bit", func(r bitio.BitReader) error { _, err := bitio.ReadULEB128(r); return err }, "ffffffffffffffffff03", bitio.ErrValueOverflow},
+		{"uleb128 11 bytes", func(r bitio.BitReader) error { _, err := bitio.ReadULEB128(r); return err }, "8080808080808080808000", bitio.ErrValueOverflow},
+		{"sleb128 truncated", func(r bitio.BitReader) error { _, err := bitio.ReadSLEB128(r); return err }, "ff", io.ErrUnexpectedEOF},
+		{"sleb128 65 bit", func(r bitio.BitReader) error { _, err := bitio.ReadSLEB128(r); return err }, "ffffffffffffffffff01", bitio.ErrValueOverflow},
+		{"zigzag truncated", func(r bitio.BitReader) error { _, err := bitio.ReadZigzag(r); return err }, "ff", io.ErrUnexpectedEOF},
+		{"vlq truncated", func(r bitio.BitReader) error { _, err := bitio.ReadVLQ(r); return err }, "8180", io.ErrUnexpectedEOF},
+		{"vlq 65 bit", func(r bitio.BitReader) error { _, err := bitio.ReadVLQ(r); return err }, "83ffffffffffffffff7f", bitio.ErrValueOverflow},
+	}
+
+	for _, tt := range tests {
+		if err := tt.read(hexReader(tt.raw)); !errors.Is(err, tt.err) {
+			t.Fatalf("%s error %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+type varintRecord struct {
+	Flag   uint8   `bit:"1"`
+	Size   uint32  `encoding:"uleb128"`
+	Offset int64   `encoding:"sleb128"`
+	Delta  int16   `encoding:"zigzag"`
+	Time   uint    `encoding:"vlq"`
+	Count  uint8   `bit:"7"`
+	Values []int32 `encoding:"zigzag" len:"Count"`
+}
+
+func TestVarint_Struct(t *testing.T) {
+	v := &varintRecord{Flag: 1, Size: 624485, Offset: -123456, Delta: -64, Time: 0x2000, Count: 2, Values: []int32{1, -1}}
+	// bytes after 1 bit flag
+	raw, _ := hex.DecodeString("e58e26" + "c0bb78" + "7f" + "c000" + "02" + "02" + "01")
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitFieldWriter(b)
+	n, err := w.WriteStruct(v)
+	if err != nil {
+		t.Fatalf("WriteStruct error: %v", err)
+	}
+	w.Flush()
+
+	exp := 1 + 8*len(raw) + 7 - 8 // Count is 7 bit
+	if n != exp {
+		t.Fatalf("WriteStruct write %d bit, want %d", n, exp)
+	}
+	if bits, err := bitio.SizeOf(v); err != nil || bits != exp {
+		t.Fatalf("SizeOf returns %d (%v), want %d", bits, err, exp)
+	}
+
+	// the first byte has flag and 7 bits of Size
+	if b.Bytes()[0] != 0x80|0xe5>>1 {
+		t.Fatalf("WriteStruct write %x", b.Bytes())
+	}
+
+	got := &varintRecord{}
+	r := bitio.NewBitFieldReader(bytes.NewReader(b.Bytes()))
+	if n, err = r.ReadStruct(got); err != nil || n != exp {
+		t.Fatalf("ReadStruct read %d bit (%v), want %d bit", n, err, exp)
+	}
+	if reflect.DeepEqual(got, v) == false {
+		t.Fatalf("ReadStruct read %+v, want %+v", got, v)
+	}
+}
+
+func TestVarint_Struct_Error(t *testing.T) {
+	// value does not fit in field
+	tests := []struct {
+		ptr interface{}
+		raw string
+	}{
+		{&struct {
+			Val uint8 `encoding:"uleb128"`
+		}{}, "8002"},
+		{&struct {
+			Val uint `encoding:"sleb128"`
+		}{}, "7f"},
+		{&struct {
+			Val int8 `encoding:"zigzag"`
+		}{}, "8002"},
+		{&struct {
+			Val int64 `encoding:"vlq"`
+		}{}, "81ffffffffffffffff7f"},
+	}
+	for _, tt := range tests {
+		raw, _ := hex.DecodeString(tt.raw)
+		r := bitio.NewBitFieldReader(bytes.NewReader(raw))
+		if _, err := r.ReadStruct(tt.ptr); errors.Is(err, bitio.ErrValueOverflow) == false {
+			t.Fatalf("ReadStruct %T error %v, want %v", tt.ptr, err, bitio.ErrValueOverflow)
+		}
+	}
+
+	w := bitio.NewBitFieldWriter(io.Discard)
+	if _, err := w.WriteStruct(&struct {
+		Val int `encoding:"uleb128"`
+	}{-1}); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteStruct negative uleb128 error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+	if _, err := w.WriteStruct(&struct {
+		Val uint64 `encoding:"zigzag"`
+	}{math.MaxUint64}); errors.Is(err, bitio.ErrValueOverflow) == false {
+		t.Fatalf("WriteStruct zigzag of MaxUint64 error %v, want %v", err, bitio.ErrValueOverflow)
+	}
+
+	invalid := []interface{}{
+		&struct {
+			Val uint `encoding:"uleb128:2"`
+		}{},
+		&struct {
+			Val uint `encoding:"vlq" bit:"8"`
+		}{},
+		&struct {
+			Val string `encoding:"zigzag"`
+		}{},
+	}
+	for _, ptr := range invalid {
+		if _, err := bitio.SizeOf(ptr); errors.Is(err, bitio.ErrInvalidTag) == false {
+			t.Fatalf("%T error %v, want %v", ptr, err, bitio.ErrInvalidTag)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Package vlc implements static variable length code tables defined by bit patterns.
+//
+// Tables are written as in MPEG/H.263 specifications ("0000 0011 01" -> value),
+// and compiled into lookup decoders by package huffman.
+package vlc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/huffman"
+)
+
+var (
+	// ErrAmbiguousTable is returned when a pattern is prefix of another pattern.
+	ErrAmbiguousTable = errors.New("vlc: ambiguous table")
+	// ErrIncompleteTable is returned when some bit sequences match no pattern.
+	ErrIncompleteTable = errors.New("vlc: incomplete table")
+	// ErrInvalidValue is returned when value has no code.
+	ErrInvalidValue = errors.New("vlc: invalid value")
+)
+
+// MaxEscapeBits is max bit size of fixed width value following escape code.
+const MaxEscapeBits = 32
+
+// Entry is a code of table.
+type Entry struct {
+	Pattern string // bit pattern of '0' and '1', spaces and '_' are ignored (ex: "0000 0011 01")
+	Value   int    // decoded value (ignored by escape code)
+	Escape  int    // if positive, code is escape code followed by Escape bits fixed width value
+}
+
+// Options is options of table.
+type Options struct {
+	// AllowIncomplete allows bit sequences which match no pattern. (ex: forbidden codes)
+	// Decoding them returns huffman.ErrInvalidCode.
+	AllowIncomplete bool
+}
+
+// Table is static variable length code table.
+type Table struct {
+	entries []Entry
+	codes   []huffman.Codeword // codes of entries
+	values  map[int]int        // value to entry index
+	escapes []int              // escape entry indexes in definition order
+	decoder *huffman.Table
+}
+
+// New returns Table of entries.
+// Returns error if patterns are invalid, ambiguous or incomplete.
+func New(entries []Entry, opts *Options) (*Table, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("vlc: no entry")
+	}
+
+	t := &Table{
+		entries: append([]Entry(nil), entries...),
+		codes:   make([]huffman.Codeword, len(entries)),
+		values:  make(map[int]int),
+	}
+
+	patterns := make([]string, len(entries))
+	for i, e := range entries {
+		p, err := normalize(e.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		patterns[i] = p
+
+		code, _ := strconv.ParseUint(p, 2, 32)
+		t.codes[i] = huffman.Codeword{Symbol: i, Code: uint32(code), Len: len(p)}
+
+		switch {
+		case e.Escape < 0 || e.Escape > MaxEscapeBits:
+			return nil, fmt.Errorf("vlc: escape size %d of pattern %q is out of range [0, %d]", e.Escape, e.Pattern, MaxEscapeBits)
+		case e.Escape > 0:
+			t.escapes = append(t.escapes, i)
+		default:
+			if j, ok := t.values[e.Value]; ok {
+				return nil, fmt.Errorf("vlc: value %d has patterns %q and %q", e.Value, entries[j].Pattern, e.Pattern)
+			}
+			t.values[e.Value] = i
+		}
+	}
+
+	// prefix pattern is adjacent in sorted patterns
+	sorted := append([]string(nil), patterns...)
+	sort.Strings(sorted)
+	for i := 1; i < len(sorted); i++ {
+		if strings.HasPrefix(sorted[i], sorted[i-1]) {
+			return nil, fmt.Errorf("%w: pattern %q is prefix of %q", ErrAmbiguousTable, sorted[i-1], sorted[i])
+		}
+	}
+
+	// Kraft sum
+	if !opts.AllowIncomplete {
+		sum := uint64(0)
+		for _, p := range patterns {
+			sum += 1 << uint(huffman.MaxCodeLen-len(p))
+		}
+		if sum != 1<<huffman.MaxCodeLen {
+			return nil, fmt.Errorf("%w: patterns cover %d/%d of codes", ErrIncompleteTable, sum, uint64(1)<<huffman.MaxCodeLen)
+		}
+	}
+
+	var err error
+	if t.decoder, err = huffman.FromCodewords(t.codes, huffman.MSBFirst); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Parse returns Table of text definition.
+// Each line is "pattern = value" or "pattern = escape(N)", and '#' starts a comment.
+//
+//	# run-level table
+//	1        = 0
+//	010      = 1
+//	011      = -1
+//	001      = 2
+//	0000 011 = escape(15)
+func Parse(src string, opts *Options) (*Table, error) {
+	var entries []Entry
+
+	s := bufio.NewScanner(strings.NewReader(src))
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		pattern, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("vlc: line %d: missing '='", line)
+		}
+		e := Entry{Pattern: strings.TrimSpace(pattern)}
+
+		value = strings.TrimSpace(value)
+		if arg, ok := strings.CutPrefix(value, "escape("); ok && strings.HasSuffix(arg, ")") {
+			n, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(arg, ")")))
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("vlc: line %d: invalid escape %q", line, value)
+			}
+			e.Escape = n
+		} else {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("vlc: line %d: invalid value %q", line, value)
+			}
+			e.Value = n
+		}
+
+		entries = append(entries, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return New(entries, opts)
+}
+
+// normalize returns pattern without spaces and '_'.
+func normalize(pattern string) (string, error) {
+	var sb strings.Builder
+	for _, c := range pattern {
+		switch c {
+		case '0', '1':
+			sb.WriteRune(c)
+		case ' ', '\t', '_':
+		default:
+			return "", fmt.Errorf("vlc: pattern %q has invalid character %q", pattern, c)
+		}
+	}
+
+	if sb.Len() == 0 || sb.Len() > huffman.MaxCodeLen {
+		return "", fmt.Errorf("vlc: pattern %q length is out of range [1, %d]", pattern, huffman.MaxCodeLen)
+	}
+	return sb.String(), nil
+}
+
+// Entries returns entries of table.
+func (t *Table) Entries() []Entry {
+	return append([]Entry(nil), t.entries...)
+}
+
+// Decode reads a code and returns its value.
+// If code is escape code, returns following fixed width value and escaped = true.
+// Returns io.EOF if no bits are read, io.ErrUnexpectedEOF if code is incomplete.
+func (t *Table) Decode(r bitio.BitReader) (v int, escaped bool, err error) {
+	i, err := t.decoder.Decode(r)
+	if err != nil {
+		return 0, false, err
+	}
+
+	e := t.entries[i]
+	if e.Escape == 0 {
+		return e.Value, false, nil
+	}
+
+	var raw uint64
+	if err := bitio.Read(r, e.Escape, bitio.BigEndian, &raw); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, false, err
+	}
+	return int(raw), true, nil
+}
+
+// Encode writes code of value.
+// If error happen, err will be set.
+func (t *Table) Encode(w bitio.BitWriter, v int) error {
+	i, ok := t.values[v]
+	if !ok {
+		return fmt.Errorf("vlc: value %d: %w", v, ErrInvalidValue)
+	}
+	return t.decoder.Encode(w, i)
+}
+
+// EncodeEscape writes the first escape code which can store v, and v as fixed width value.
+// If error happen, err will be set.
+func (t *Table) EncodeEscape(w bitio.BitWriter, v uint64) error {
+	for _, i := range t.escapes {
+		n := t.entries[i].Escape
+		if v>>uint(n) != 0 {
+			continue
+		}
+		if err := t.decoder.Encode(w, i); err != nil {
+			return err
+		}
+		return bitio.Write(w, n, bitio.BigEndian, v)
+	}
+	return fmt.Errorf("vlc: escape value %d: %w", v, ErrInvalidValue)
+}
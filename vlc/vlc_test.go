@@ -0,0 +1,233 @@
+package vlc_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hidez8891/bitio"
+	"github.com/hidez8891/bitio/huffman"
+	"github.com/hidez8891/bitio/vlc"
+)
+
+// bitReaderOnly hides bitio.BitPeeker of reader.
+type bitReaderOnly struct {
+	bitio.BitReader
+}
+
+// MPEG-2 Table B.1 (macroblock_address_increment)
+const mpeg2TableB1 = `
+1             = 1
+011           = 2
+010           = 3
+0011          = 4
+0010          = 5
+0001 1        = 6
+0001 0        = 7
+0000 111      = 8
+0000 110      = 9
+0000 1011     = 10
+0000 1010     = 11
+0000 1001     = 12
+0000 1000     = 13
+0000 0111     = 14
+0000 0110     = 15
+0000 0101 11  = 16
+0000 0101 10  = 17
+0000 0101 01  = 18
+0000 0101 00  = 19
+0000 0100 11  = 20
+0000 0100 10  = 21
+0000 0100 011 = 22
+0000 0100 010 = 23
+0000 0100 001 = 24
+0000 0100 000 = 25
+0000 0011 111 = 26
+0000 0011 110 = 27
+0000 0011 101 = 28
+0000 0011 100 = 29
+0000 0011 011 = 30
+0000 0011 010 = 31
+0000 0011 001 = 32
+0000 0011 000 = 33
+0000 0001 000 = 0  # macroblock_escape
+0000 0001 111 = -1 # macroblock_stuffing (MPEG-1)
+`
+
+func TestParse(t *testing.T) {
+	if _, err := vlc.Parse(mpeg2TableB1, nil); errors.Is(err, vlc.ErrIncompleteTable) == false {
+		t.Fatalf("Parse error %v, want %v", err, vlc.ErrIncompleteTable)
+	}
+
+	table, err := vlc.Parse(mpeg2TableB1, &vlc.Options{AllowIncomplete: true})
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if n := len(table.Entries()); n != 35 {
+		t.Fatalf("Parse returns %d entries, want 35", n)
+	}
+
+	values := []int{1, 2, 33}
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	for _, v := range values {
+		if err := table.Encode(w, v); err != nil {
+			t.Fatalf("Encode(%d) error: %v", v, err)
+		}
+	}
+	w.Flush()
+
+	// 1_011_0000 0011 000
+	if exp := []byte{0xb0, 0x30}; reflect.DeepEqual(b.Bytes(), exp) == false {
+		t.Fatalf("Encode write %#v, want %#v", b.Bytes(), exp)
+	}
+}
+
+func TestTable_RoundTrip(t *testing.T) {
+	table, _ := vlc.Parse(mpeg2TableB1, &vlc.Options{AllowIncomplete: true})
+
+	var values []int
+	for v := -1; v <= 33; v++ {
+		values = append(values, v, 32-v)
+	}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	for _, v := range values {
+		if err := table.Encode(w, v); err != nil {
+			t.Fatalf("Encode(%d) error: %v", v, err)
+		}
+	}
+	w.Flush()
+
+	for _, r := range []bitio.BitReader{
+		bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes())),
+		bitReaderOnly{bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes()))},
+	} {
+		for i, exp := range values {
+			if v, escaped, err := table.Decode(r); err != nil || escaped || v != exp {
+				t.Fatalf("%T decode #%d %d (%v, %v), want %d", r, i, v, escaped, err, exp)
+			}
+		}
+	}
+}
+
+func TestTable_Escape(t *testing.T) {
+	table, err := vlc.New([]vlc.Entry{
+		{Pattern: "1", Value: 0},
+		{Pattern: "01", Value: 1},
+		{Pattern: "001", Value: 2},
+		{Pattern: "0001", Escape: 6},
+		{Pattern: "0000", Escape: 12},
+	}, nil)
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+
+	b := new(bytes.Buffer)
+	w := bitio.NewBitWriteBuffer(b)
+	table.Encode(w, 2)
+	if err := table.EncodeEscape(w, 5); err != nil {
+		t.Fatalf("EncodeEscape(5) error: %v", err)
+	}
+	if err := table.EncodeEscape(w, 100); err != nil {
+		t.Fatalf("EncodeEscape(100) error: %v", err)
+	}
+	if err := table.EncodeEscape(w, 1<<12); errors.Is(err, vlc.ErrInvalidValue) == false {
+		t.Fatalf("EncodeEscape(4096) error %v, want %v", err, vlc.ErrInvalidValue)
+	}
+	w.Flush()
+
+	// 001_0001 000101_0000 0000 0110 0100
+	if exp := []byte{0x22, 0x28, 0x03, 0x20}; reflect.DeepEqual(b.Bytes(), exp) == false {
+		t.Fatalf("Encode write %#v, want %#v", b.Bytes(), exp)
+	}
+
+	tests := []struct {
+		v       int
+		escaped bool
+	}{
+		{2, false},
+		{5, true},
+		{100, true},
+	}
+	for _, r := range []bitio.BitReader{
+		bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes())),
+		bitReaderOnly{bitio.NewBitReadBuffer(bytes.NewReader(b.Bytes()))},
+	} {
+		for i, tt := range tests {
+			if v, escaped, err := table.Decode(r); err != nil || escaped != tt.escaped || v != tt.v {
+				t.Fatalf("%T decode #%d %d (%v, %v), want %d (%v)", r, i, v, escaped, err, tt.v, tt.escaped)
+			}
+		}
+	}
+}
+
+func TestNew_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []vlc.Entry
+		err     error
+	}{
+		{"no entry", nil, nil},
+		{"prefix", []vlc.Entry{{Pattern: "0", Value: 0}, {Pattern: "01", Value: 1}, {Pattern: "1", Value: 2}}, vlc.ErrAmbiguousTable},
+		{"same pattern", []vlc.Entry{{Pattern: "0", Value: 0}, {Pattern: "0", Value: 1}, {Pattern: "1", Value: 2}}, vlc.ErrAmbiguousTable},
+		{"incomplete", []vlc.Entry{{Pattern: "0", Value: 0}, {Pattern: "10", Value: 1}}, vlc.ErrIncompleteTable},
+		{"invalid character", []vlc.Entry{{Pattern: "0", Value: 0}, {Pattern: "1x", Value: 1}}, nil},
+		{"empty pattern", []vlc.Entry{{Pattern: " _ ", Value: 0}}, nil},
+		{"too long pattern", []vlc.Entry{{Pattern: "0000 0000 0000 0000 0000 0000 0000 0000 1", Value: 0}}, nil},
+		{"same value", []vlc.Entry{{Pattern: "0", Value: 0}, {Pattern: "1", Value: 0}}, nil},
+		{"escape size", []vlc.Entry{{Pattern: "0", Value: 0}, {Pattern: "1", Escape: 33}}, nil},
+	}
+	for _, tt := range tests {
+		_, err := vlc.New(tt.entries, nil)
+		if err == nil || (tt.err != nil && errors.Is(err, tt.err) == false) {
+			t.Fatalf("%s error %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	for _, src := range []string{
+		"0 = 0\n1",
+		"0 = 0\n1 = x",
+		"0 = 0\n1 = escape(0)",
+		"0 = 0\n1 = escape(x)",
+	} {
+		if _, err := vlc.Parse(src, nil); err == nil {
+			t.Fatalf("Parse(%q) wants error", src)
+		}
+	}
+}
+
+func TestTable_Decode_Error(t *testing.T) {
+	table, _ := vlc.Parse(`
+		1    = 0
+		01   = 1
+		001  = escape(8)
+	`, &vlc.Options{AllowIncomplete: true})
+
+	if err := table.Encode(bitio.NewBitWriteBuffer(io.Discard), 2); errors.Is(err, vlc.ErrInvalidValue) == false {
+		t.Fatalf("Encode error %v, want %v", err, vlc.ErrInvalidValue)
+	}
+
+	tests := []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{"empty", []byte{}, io.EOF},
+		{"unassigned code", []byte{0x00}, huffman.ErrInvalidCode},
+		{"EOF in escape value", []byte{0x20}, io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		for _, r := range []bitio.BitReader{
+			bitio.NewBitReadBuffer(bytes.NewReader(tt.raw)),
+			bitReaderOnly{bitio.NewBitReadBuffer(bytes.NewReader(tt.raw))},
+		} {
+			if _, _, err := table.Decode(r); errors.Is(err, tt.err) == false {
+				t.Fatalf("%s %T decode error %v, want %v", tt.name, r, err, tt.err)
+			}
+		}
+	}
+}